@@ -2,7 +2,11 @@ package main
 
 import (
 	"blog/blogpb"
+	"common/auth"
+	"common/protoset"
+	"common/tracing"
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -11,17 +15,48 @@ import (
 )
 
 func main() {
+	traceCfg := tracing.Config{ServiceName: "blog_client"}
+	traceCfg.RegisterFlags(flag.CommandLine)
+	var authCfg auth.ClientConfig
+	authCfg.RegisterFlags(flag.CommandLine)
+	flag.Parse()
 
 	fmt.Println("Blog Client")
 
-	opts := grpc.WithInsecure()
+	tp, err := tracing.Init(context.Background(), traceCfg)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	// flush the spans that are still buffered
+	defer tp.Shutdown(context.Background())
+
+	traceUnary, traceStream := tracing.ClientInterceptors()
+	opts := []grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithChainUnaryInterceptor(traceUnary),
+		grpc.WithChainStreamInterceptor(traceStream),
+	}
+	// the connection is plaintext, so the token has to be allowed without TLS
+	if creds := authCfg.Credentials(true); creds != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(creds))
+	}
 
-	cc, err := grpc.Dial("localhost:50051", opts)
+	cc, err := grpc.Dial("localhost:50051", opts...)
 	if err != nil {
 		log.Fatalf("could not connect: %v", err)
 	}
 	defer cc.Close()
 
+	// "client describe [file]" saves the service descriptors for tooling
+	if flag.Arg(0) == "describe" {
+		path, err := protoset.Describe(cc, "blog.BlogService", flag.Arg(1))
+		if err != nil {
+			log.Fatalf("Failed to describe the service: %v", err)
+		}
+		fmt.Printf("Wrote the descriptors of blog.BlogService to %v\n", path)
+		return
+	}
+
 	c := blogpb.NewBlogServiceClient(cc)
 
 	// create blog
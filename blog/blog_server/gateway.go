@@ -0,0 +1,63 @@
+package main
+
+import (
+	"blog/blogpb"
+	"common/ratelimit"
+	"common/tracing"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// newGateway returns the REST/JSON gateway of the blog service. It translates
+// HTTP requests into gRPC calls to the server serving lis, so they pass
+// through the same interceptors as any other client, and serves the OpenAPI
+// spec read from specFile on /openapi.json. Calls come through lis so that
+// the rate limits apply to the addresses the gateway forwards.
+//
+// gRPC status codes become HTTP statuses, e.g. NOT_FOUND is 404, and the body
+// of a failed request is the status as JSON.
+func newGateway(ctx context.Context, lis *ratelimit.ProxyListener, specFile string) (http.Handler, error) {
+	gwmux := runtime.NewServeMux(runtime.WithProtoErrorHandler(errorHandler))
+	traceUnary, traceStream := tracing.ClientInterceptors()
+	opts := []grpc.DialOption{
+		grpc.WithContextDialer(lis.Dial),
+		grpc.WithInsecure(),
+		grpc.WithChainUnaryInterceptor(traceUnary),
+		grpc.WithChainStreamInterceptor(traceStream),
+	}
+	if err := blogpb.RegisterBlogServiceHandlerFromEndpoint(ctx, gwmux, "gateway", opts); err != nil {
+		return nil, fmt.Errorf("registering blog gateway: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", gwmux)
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		http.ServeFile(w, r, specFile)
+	})
+	// continue the traces of callers that send a traceparent header
+	return otelhttp.NewHandler(mux, "blog_gateway"), nil
+}
+
+// errorHandler writes errors like the default handler, and tells clients that
+// were rate limited when to retry with a Retry-After header.
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if st, ok := status.FromError(err); ok {
+		for _, d := range st.Details() {
+			if info, ok := d.(*errdetails.RetryInfo); ok {
+				// round up, Retry-After is in whole seconds
+				secs := (info.GetRetryDelay().AsDuration().Milliseconds() + 999) / 1000
+				w.Header().Set("Retry-After", strconv.FormatInt(secs, 10))
+			}
+		}
+	}
+	runtime.DefaultHTTPProtoErrorHandler(ctx, mux, m, w, r, err)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"blog/blogpb"
+	"common/ratelimit"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+// newRateLimitedGateway serves the blog service limited by cfg through its
+// gateway, and returns a function listing the blogs over HTTP with the
+// given X-Forwarded-For header, which returns the HTTP status.
+func newRateLimitedGateway(t *testing.T, cfg ratelimit.Config) func(forwardedFor string) int {
+	lis := ratelimit.NewProxyListener()
+	limiter := ratelimit.New(cfg)
+	s := grpc.NewServer(grpc.UnaryInterceptor(limiter.UnaryServerInterceptor()))
+	blogpb.RegisterBlogServiceServer(s, &server{store: newMemoryStore()})
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	gateway, err := newGateway(ctx, lis, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(gateway)
+	t.Cleanup(ts.Close)
+
+	return func(forwardedFor string) int {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/v1/blogs", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res.StatusCode
+	}
+}
+
+// TestGatewayRateLimitsEachClient checks that the callers of the gateway
+// do not share the bucket of the gateway.
+func TestGatewayRateLimitsEachClient(t *testing.T) {
+	// the clients stand behind a proxy on localhost, which the server
+	// trusts, so that they seem to come from different addresses
+	list := newRateLimitedGateway(t, ratelimit.Config{
+		Default:        ratelimit.Limit{Rate: 0.001, Burst: 1},
+		TrustedProxies: []string{"127.0.0.1"},
+	})
+	if code := list("198.51.100.1"); code != http.StatusOK {
+		t.Fatalf("first request of a client: got %d, want %d", code, http.StatusOK)
+	}
+	if code := list("198.51.100.1"); code != http.StatusTooManyRequests {
+		t.Fatalf("second request of a client: got %d, want %d", code, http.StatusTooManyRequests)
+	}
+	if code := list("198.51.100.2"); code != http.StatusOK {
+		t.Errorf("request of another client: got %d, want %d", code, http.StatusOK)
+	}
+}
+
+// TestGatewayIgnoresForgedAddresses checks that a client of the gateway
+// cannot pick its bucket with an X-Forwarded-For header of its own.
+func TestGatewayIgnoresForgedAddresses(t *testing.T) {
+	list := newRateLimitedGateway(t, ratelimit.Config{
+		Default: ratelimit.Limit{Rate: 0.001, Burst: 1},
+	})
+	if code := list("198.51.100.1"); code != http.StatusOK {
+		t.Fatalf("first request: got %d, want %d", code, http.StatusOK)
+	}
+	if code := list("198.51.100.2"); code != http.StatusTooManyRequests {
+		t.Errorf("request with another forged address: got %d, want %d", code, http.StatusTooManyRequests)
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var articleTitleIdx = "article:title"
+
+type mongoStore struct {
+	collection *mongo.Collection
+}
+
+// newMongoStore keeps the blogs in collection, creating its indexes.
+func newMongoStore(ctx context.Context, collection *mongo.Collection) (*mongoStore, string, error) {
+	indexName, err := collection.Indexes().CreateOne(
+		ctx,
+		mongo.IndexModel{
+			// compound index
+			Keys: bson.M{
+				// descending order
+				"article_id": -1,
+				// ascending order
+				"title": 1,
+			},
+			// set this index unique
+			Options: options.Index().SetUnique(true).SetName(articleTitleIdx),
+		},
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	return &mongoStore{collection: collection}, indexName, nil
+}
+
+func (m *mongoStore) Insert(ctx context.Context, item *blogItem) (primitive.ObjectID, error) {
+	res, err := m.collection.InsertMany(ctx, []interface{}{item})
+	//res, err := collection.InsertOne(ctx, data)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	//oid, ok := res.InsertedID.(primitive.ObjectID)
+	oid, ok := res.InsertedIDs[0].(primitive.ObjectID)
+	if !ok {
+		return primitive.NilObjectID, errors.New("cannot convert to OID")
+	}
+	return oid, nil
+}
+
+// decode decodes the result of a single document query, telling a missing
+// document apart with errNotFound.
+func decode(res *mongo.SingleResult) (*blogItem, error) {
+	data := &blogItem{}
+	if err := res.Decode(data); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (m *mongoStore) Get(ctx context.Context, id primitive.ObjectID) (*blogItem, error) {
+	// regex example
+	// {"username" : {$regex : ".*son.*"}} // contains "son" in the username
+	return decode(m.collection.FindOne(ctx, bson.M{"_id": id}))
+}
+
+func (m *mongoStore) Update(ctx context.Context, id primitive.ObjectID, authorID string, set bson.M) (*blogItem, error) {
+	filter := bson.M{"_id": id, "author_id": authorID}
+	if len(set) == 0 {
+		// nothing to change, MongoDB rejects an empty $set
+		return decode(m.collection.FindOne(ctx, filter))
+	}
+	return decode(m.collection.FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	))
+}
+
+func (m *mongoStore) Delete(ctx context.Context, id primitive.ObjectID, authorID string) error {
+	res, err := m.collection.DeleteOne(ctx, bson.M{"_id": id, "author_id": authorID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return errNotFound
+	}
+	return nil
+}
+
+func (m *mongoStore) List(ctx context.Context, fn func(*blogItem) error) error {
+	// D is an ordered representation of a BSON document
+	// Example usage: bson.D{{"foo", "bar"}, {"hello", "world"}, {"pi", 3.14159}}
+	cur, err := m.collection.Find(ctx, primitive.D{{}})
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		data := &blogItem{}
+		if err := cur.Decode(data); err != nil {
+			return err
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+func (m *mongoStore) ListPage(ctx context.Context, skip, limit int64) ([]*blogItem, error) {
+	findOptions := options.Find()
+	findOptions.SetSkip(skip).SetLimit(limit) // skip and limit default set to 0
+	// sorts the documents first by the author_id field in descending order
+	// and then by the title field in ascending order
+	findOptions.SetSort(bson.D{
+		{Key: "author_id", Value: -1},
+		{Key: "title", Value: 1},
+	})
+	// The maximum number of documents to be included in each batch returned by the server
+	// default: 101
+	findOptions.SetBatchSize(200)
+	// select fields
+	findOptions.SetProjection(bson.M{
+		"_id":       1,
+		"author_id": 1,
+		"title":     1,
+	})
+	cur, err := m.collection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var page []*blogItem
+	for cur.Next(ctx) {
+		data := &blogItem{}
+		if err := cur.Decode(data); err != nil {
+			return nil, err
+		}
+		page = append(page, data)
+	}
+	return page, cur.Err()
+}
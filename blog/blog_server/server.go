@@ -2,31 +2,35 @@ package main
 
 import (
 	"blog/blogpb"
+	"common/auth"
+	"common/fault"
+	"common/ratelimit"
+	"common/tracing"
+	"common/webrpc"
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 
+	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
-var (
-	collection *mongo.Collection
-
-	articleTitleIdx = "article:title"
-)
-
 type server struct {
+	store store
 }
 
 type blogItem struct {
@@ -37,38 +41,35 @@ type blogItem struct {
 	Tags     []string           `bson:"tags"`
 }
 
-func (*server) CreateBlog(ctx context.Context, req *blogpb.CreateBlogRequest) (*blogpb.CreateBlogResponse, error) {
+func (s *server) CreateBlog(ctx context.Context, req *blogpb.CreateBlogRequest) (*blogpb.CreateBlogResponse, error) {
 	fmt.Println("Create blog request")
 	blog := req.GetBlog()
 
-	data := blogItem{
-		AuthorID: blog.GetAuthorId(),
+	// the author is whoever is calling, not whatever the client claims
+	authorID := blog.GetAuthorId()
+	if claims, ok := auth.FromContext(ctx); ok {
+		authorID = claims.Subject
+	}
+
+	data := &blogItem{
+		AuthorID: authorID,
 		Title:    blog.GetTitle(),
 		Content:  blog.GetContent(),
 		Tags:     blog.GetTags(),
 	}
 
-	res, err := collection.InsertMany(ctx, []interface{}{data})
-	//res, err := collection.InsertOne(ctx, data)
+	oid, err := s.store.Insert(ctx, data)
 	if err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
 			fmt.Sprintf("Internal error: %v", err),
 		)
 	}
-	//oid, ok := res.InsertedID.(primitive.ObjectID)
-	oid, ok := res.InsertedIDs[0].(primitive.ObjectID)
-	if !ok {
-		return nil, status.Errorf(
-			codes.Internal,
-			"Cannot convert to OID",
-		)
-	}
 
 	return &blogpb.CreateBlogResponse{
 		Blog: &blogpb.Blog{
 			Id:       oid.Hex(),
-			AuthorId: blog.GetAuthorId(),
+			AuthorId: authorID,
 			Title:    blog.GetTitle(),
 			Content:  blog.GetContent(),
 			Tags:     blog.GetTags(),
@@ -77,7 +78,7 @@ func (*server) CreateBlog(ctx context.Context, req *blogpb.CreateBlogRequest) (*
 
 }
 
-func (*server) ReadBlog(ctx context.Context, req *blogpb.ReadBlogRequest) (*blogpb.ReadBlogResponse, error) {
+func (s *server) ReadBlog(ctx context.Context, req *blogpb.ReadBlogRequest) (*blogpb.ReadBlogResponse, error) {
 	fmt.Println("Read blog request")
 
 	blogID := req.GetBlogId()
@@ -89,15 +90,9 @@ func (*server) ReadBlog(ctx context.Context, req *blogpb.ReadBlogRequest) (*blog
 		)
 	}
 
-	// create an empty struct
-	data := &blogItem{}
-	filter := bson.M{"_id": oid}
-	// regex example
-	// {"username" : {$regex : ".*son.*"}} // contains "son" in the username
-
-	res := collection.FindOne(ctx, filter)
-	if err := res.Decode(data); err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
+	data, err := s.store.Get(ctx, oid)
+	if err != nil {
+		if errors.Is(err, errNotFound) {
 			return nil, status.Errorf(
 				codes.NotFound,
 				fmt.Sprintf("Cannot find blog with specified ID: %v", err),
@@ -124,7 +119,42 @@ func dataToBlogPb(data *blogItem) *blogpb.Blog {
 	}
 }
 
-func (*server) UpdateBlog(ctx context.Context, req *blogpb.UpdateBlogRequest) (*blogpb.UpdateBlogResponse, error) {
+// authorizeAuthor checks that the caller may modify a blog written by authorID:
+// only the author and admins can. Without authentication there is no caller
+// identity and every call is let through.
+func authorizeAuthor(ctx context.Context, authorID string) error {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if claims.Subject == authorID || claims.HasRole(auth.RoleAdmin) {
+		return nil
+	}
+	return status.Errorf(
+		codes.PermissionDenied,
+		fmt.Sprintf("%v is not the author of this blog", claims.Subject),
+	)
+}
+
+// findAuthor returns the author of the blog with the given id.
+func (s *server) findAuthor(ctx context.Context, oid primitive.ObjectID) (string, error) {
+	data, err := s.store.Get(ctx, oid)
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			return "", status.Errorf(
+				codes.NotFound,
+				fmt.Sprintf("Cannot find blog with specified ID: %v", err),
+			)
+		}
+		return "", status.Errorf(
+			codes.Internal,
+			fmt.Sprintf("Internal error: %v", err),
+		)
+	}
+	return data.AuthorID, nil
+}
+
+func (s *server) UpdateBlog(ctx context.Context, req *blogpb.UpdateBlogRequest) (*blogpb.UpdateBlogResponse, error) {
 	fmt.Println("Update blog request")
 	blog := req.GetBlog()
 	oid, err := primitive.ObjectIDFromHex(blog.GetId())
@@ -135,45 +165,63 @@ func (*server) UpdateBlog(ctx context.Context, req *blogpb.UpdateBlogRequest) (*
 		)
 	}
 
-	// create an empty struct
-	data := &blogItem{}
-	filter := bson.M{"_id": oid}
-
-	// we update our internal struct
-	data.ID = oid
-	data.AuthorID = blog.GetAuthorId()
-	data.Content = blog.GetContent()
-	data.Title = blog.GetTitle()
-	data.Tags = blog.GetTags()
-
-	res, updateErr := collection.UpdateOne(
-		ctx,
-		filter,
-		bson.M{
-			"$set": bson.M{
-				"author_id": data.AuthorID,
-				"content":   data.Content,
-				"title":     data.Title,
-				"tags":      data.Tags,
-			},
-		},
-		options.Update().SetUpsert(true),
-	)
-	//res, updateErr := collection.ReplaceOne(ctx, filter, data)
-	if updateErr != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Cannot update object in MongoDB: %v", updateErr),
-		)
+	authorID, err := s.findAuthor(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeAuthor(ctx, authorID); err != nil {
+		return nil, err
+	}
+
+	// once callers are authenticated, the author of a blog cannot be changed
+	_, authenticated := auth.FromContext(ctx)
+	fields := bson.M{
+		"author_id": blog.GetAuthorId(),
+		"content":   blog.GetContent(),
+		"title":     blog.GetTitle(),
+		"tags":      blog.GetTags(),
+	}
+	if authenticated {
+		fields["author_id"] = authorID
+	}
+	set := fields
+	// with an update mask, as sent by the PATCH endpoint of the gateway,
+	// only the listed fields change
+	if mask := req.GetUpdateMask(); mask != nil {
+		set = bson.M{}
+		for _, path := range mask.GetPaths() {
+			value, ok := fields[path]
+			if !ok {
+				// the id is part of the URL and may be echoed in the body
+				if path == "id" {
+					continue
+				}
+				return nil, status.Errorf(
+					codes.InvalidArgument,
+					fmt.Sprintf("Cannot update field %q", path),
+				)
+			}
+			if path == "author_id" && authenticated {
+				continue
+			}
+			set[path] = value
+		}
 	}
 
-	if res.MatchedCount == 0 {
+	// match the author we checked as well, in case the blog changed hands meanwhile
+	data, err := s.store.Update(ctx, oid, authorID, set)
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, status.Errorf(
+				codes.NotFound,
+				fmt.Sprintf("Cannot find blog in MongoDB: %v", err),
+			)
+		}
 		return nil, status.Errorf(
-			codes.NotFound,
-			fmt.Sprintf("Cannot find blog in MongoDB: %v", updateErr),
+			codes.Internal,
+			fmt.Sprintf("Cannot update object in MongoDB: %v", err),
 		)
 	}
-	fmt.Printf("matched: %v, modified: %v\n", res.MatchedCount, res.ModifiedCount)
 
 	return &blogpb.UpdateBlogResponse{
 		Blog: dataToBlogPb(data),
@@ -181,7 +229,7 @@ func (*server) UpdateBlog(ctx context.Context, req *blogpb.UpdateBlogRequest) (*
 
 }
 
-func (*server) DeleteBlog(ctx context.Context, req *blogpb.DeleteBlogRequest) (*blogpb.DeleteBlogResponse, error) {
+func (s *server) DeleteBlog(ctx context.Context, req *blogpb.DeleteBlogRequest) (*blogpb.DeleteBlogResponse, error) {
 	fmt.Println("Delete blog request")
 	oid, err := primitive.ObjectIDFromHex(req.GetBlogId())
 	if err != nil {
@@ -191,53 +239,46 @@ func (*server) DeleteBlog(ctx context.Context, req *blogpb.DeleteBlogRequest) (*
 		)
 	}
 
-	filter := bson.M{"_id": oid}
-
-	res, err := collection.DeleteOne(ctx, filter)
-
+	authorID, err := s.findAuthor(ctx, oid)
 	if err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Cannot delete object in MongoDB: %v", err),
-		)
+		return nil, err
+	}
+	if err := authorizeAuthor(ctx, authorID); err != nil {
+		return nil, err
 	}
 
-	if res.DeletedCount == 0 {
+	if err := s.store.Delete(ctx, oid, authorID); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, status.Errorf(
+				codes.NotFound,
+				fmt.Sprintf("Cannot find blog in MongoDB: %v", err),
+			)
+		}
 		return nil, status.Errorf(
-			codes.NotFound,
-			fmt.Sprintf("Cannot find blog in MongoDB: %v", err),
+			codes.Internal,
+			fmt.Sprintf("Cannot delete object in MongoDB: %v", err),
 		)
 	}
 
 	return &blogpb.DeleteBlogResponse{BlogId: req.GetBlogId()}, nil
 }
 
-func (*server) ListBlog(req *blogpb.ListBlogRequest, stream blogpb.BlogService_ListBlogServer) error {
+func (s *server) ListBlog(req *blogpb.ListBlogRequest, stream blogpb.BlogService_ListBlogServer) error {
 	fmt.Println("List blog request")
 
-	// D is an ordered representation of a BSON document
-	// Example usage: bson.D{{"foo", "bar"}, {"hello", "world"}, {"pi", 3.14159}}
-	cur, err := collection.Find(context.Background(), primitive.D{{}})
-	if err != nil {
-		return status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Unknown internal error: %v", err),
-		)
-	}
-	defer cur.Close(context.Background())
-	for cur.Next(context.Background()) {
-		data := &blogItem{}
-		err := cur.Decode(data)
-		if err != nil {
-			return status.Errorf(
-				codes.Internal,
-				fmt.Sprintf("Error while decoding data from MongoDB: %v", err),
-			)
+	// the stream context carries the RPC span, so MongoDB spans become its children
+	ctx := stream.Context()
 
-		}
-		stream.Send(&blogpb.ListBlogResponse{Blog: dataToBlogPb(data)})
+	var sendErr error
+	err := s.store.List(ctx, func(data *blogItem) error {
+		sendErr = stream.Send(&blogpb.ListBlogResponse{Blog: dataToBlogPb(data)})
+		return sendErr
+	})
+	if sendErr != nil {
+		// the client is gone, and the status carries the reason
+		return sendErr
 	}
-	if err := cur.Err(); err != nil {
+	if err != nil {
 		return status.Errorf(
 			codes.Internal,
 			fmt.Sprintf("Unknown internal error: %v", err),
@@ -246,169 +287,227 @@ func (*server) ListBlog(req *blogpb.ListBlogRequest, stream blogpb.BlogService_L
 	return nil
 }
 
-func (*server) ListBlogPage(ctx context.Context, req *blogpb.ListBlogPageRequest) (*blogpb.ListBlogPageResponse, error) {
+func (s *server) ListBlogPage(ctx context.Context, req *blogpb.ListBlogPageRequest) (*blogpb.ListBlogPageResponse, error) {
 	fmt.Println("List blog page request")
-	findOptions := options.Find()
-	findOptions.SetSkip(req.GetSkip()).SetLimit(req.GetLimit()) // skip and limit default set to 0
-	// sorts the documents first by the author_id field in descending order
-	// and then by the title field in ascending order
-	findOptions.SetSort(bson.M{
-		"author_id": -1,
-		"title":     1,
-	})
-	// The maximum number of documents to be included in each batch returned by the server
-	// default: 101
-	findOptions.SetBatchSize(200)
-	// select fields
-	findOptions.SetProjection(bson.M{
-		"_id":       1,
-		"author_id": 1,
-		"title":     1,
-	})
-	/*
-		filter := primitive.D{
-			{
-				"title",
-				primitive.D{
-					{
-						"$in",
-						primitive.A{"My Title", "My First Blog (edited)", 3},
-					},
-				},
-			},
-		}
-	*/
-	/*
-		filter := bson.M{
-			"author_id": bson.M{
-				"$not": bson.M{
-					"$eq": "Stephane",
-				},
-			},
-			"title": bson.M{
-				"$in": primitive.A{"My Title", "My First Blog (edited)", 3},
-			},
-		}
-	*/
-	filter := bson.M{
-		"$or": []interface{}{
-			bson.M{
-				"author_id": bson.M{
-					"$not": bson.M{
-						"$eq": "Stephane",
-					},
-				},
-			},
-			bson.M{
-				"title": bson.M{
-					"$in": primitive.A{"My Title", "My Second Title", 3},
-				},
-			},
-		},
-	}
-	cur, err := collection.Find(ctx, filter, findOptions)
+	page, err := s.store.ListPage(ctx, req.GetSkip(), req.GetLimit())
 	if err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
 			fmt.Sprintf("Unknown internal error: %v", err),
 		)
 	}
-	defer cur.Close(ctx)
 
 	var resp blogpb.ListBlogPageResponse
-	for cur.Next(ctx) {
-		data := &blogItem{}
-		err := cur.Decode(data)
-		if err != nil {
-			return nil, status.Errorf(
-				codes.Internal,
-				fmt.Sprintf("Error while decoding data from MongoDB: %v", err),
-			)
-		}
+	for _, data := range page {
 		resp.Blogs = append(resp.Blogs, dataToBlogPb(data))
 	}
-	if err := cur.Err(); err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Unknown internal error: %v", err),
-		)
-	}
 	return &resp, nil
 }
 
 func main() {
+	traceCfg := tracing.Config{ServiceName: "blog_server"}
+	traceCfg.RegisterFlags(flag.CommandLine)
+	var authCfg auth.Config
+	authCfg.RegisterFlags(flag.CommandLine)
+	var rlCfg ratelimit.Config
+	rlCfg.RegisterFlags(flag.CommandLine)
+	var faultCfg fault.Config
+	faultCfg.RegisterFlags(flag.CommandLine)
+	enableReflection := flag.Bool("reflection", true, "register the server reflection service used by Evans and grpcurl")
+	httpAddr := flag.String("http-addr", "0.0.0.0:8080", "address serving the REST/JSON gateway; empty disables it")
+	specFile := flag.String("openapi-spec", "proto/blog.swagger.json", "OpenAPI spec served by the gateway on /openapi.json")
+	var webCfg webrpc.Config
+	webCfg.RegisterFlags(flag.CommandLine)
+	storeKind := flag.String("store", "mongo", "where the blogs are kept: mongo, or memory to run without MongoDB")
+	mongoURI := flag.String("mongo-uri", "mongodb://localhost:27017", "MongoDB connection string")
+	flag.Parse()
+	if err := rlCfg.Load(); err != nil {
+		log.Fatalf("Failed to set up rate limiting: %v", err)
+	}
+	if err := faultCfg.Load(); err != nil {
+		log.Fatalf("Failed to set up fault injection: %v", err)
+	}
+
 	// if we crash the go code, we get the file name and line number
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
-	fmt.Println("Connecting to MongoDB")
-	// connect to MongoDB
-	client, err := mongo.NewClient(options.Client().ApplyURI("mongodb://localhost:27017"))
+	tp, err := tracing.Init(context.Background(), traceCfg)
 	if err != nil {
-		log.Fatal(err)
+		log.Fatalf("Failed to set up tracing: %v", err)
 	}
-	err = client.Connect(context.TODO())
-	if err != nil {
-		log.Fatal(err)
+
+	var blogs store
+	var client *mongo.Client
+	switch *storeKind {
+	case "memory":
+		fmt.Println("Keeping the blogs in memory")
+		blogs = newMemoryStore()
+	case "mongo":
+		fmt.Println("Connecting to MongoDB")
+		// connect to MongoDB
+		// the monitor starts a child span for every command sent to MongoDB
+		client, err = mongo.NewClient(options.Client().
+			ApplyURI(*mongoURI).
+			SetMonitor(otelmongo.NewMonitor()),
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = client.Connect(context.TODO())
+		if err != nil {
+			log.Fatal(err)
+		}
+		mongoBlogs, indexName, err := newMongoStore(context.Background(), client.Database("mydb").Collection("blog"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("create index: ", indexName)
+		blogs = mongoBlogs
+	default:
+		log.Fatalf("Unknown store %q, want mongo or memory", *storeKind)
 	}
 
 	fmt.Println("Blog Service Started")
-	collection = client.Database("mydb").Collection("blog")
-	indexName, err := collection.Indexes().CreateOne(
-		context.Background(),
-		mongo.IndexModel{
-			// compound index
-			Keys: bson.M{
-				// descending order
-				"article_id": -1,
-				// ascending order
-				"title": 1,
-			},
-			// set this index unique
-			Options: options.Index().SetUnique(true).SetName(articleTitleIdx),
-		},
-	)
-	if err != nil {
-		log.Fatal(err)
-	}
-	fmt.Println("create index: ", indexName)
 	lis, err := net.Listen("tcp", "0.0.0.0:50051")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	opts := []grpc.ServerOption{}
+	recoveryFunc := func(p interface{}) (err error) {
+		return status.Errorf(codes.Unknown, "panic triggered: %v", p)
+	}
+	recoveryOpts := []grpc_recovery.Option{
+		grpc_recovery.WithRecoveryHandler(recoveryFunc),
+	}
+
+	traceUnary, traceStream := tracing.ServerInterceptors()
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		traceUnary,
+		grpc_recovery.UnaryServerInterceptor(recoveryOpts...),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		traceStream,
+		grpc_recovery.StreamServerInterceptor(recoveryOpts...),
+	}
+	if authCfg.Enabled() {
+		validator, err := auth.NewValidator(authCfg)
+		if err != nil {
+			log.Fatalf("Failed to set up authentication: %v", err)
+		}
+		unaryInterceptors = append(unaryInterceptors, validator.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, validator.StreamServerInterceptor())
+	} else {
+		fmt.Println("Authentication is disabled")
+	}
+	if rlCfg.Enabled() {
+		// after authentication, so that limits apply per authenticated caller
+		limiter := ratelimit.New(rlCfg)
+		unaryInterceptors = append(unaryInterceptors, limiter.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, limiter.StreamServerInterceptor())
+	}
+	if faultCfg.Enabled() {
+		// last, so that faults only hit calls that would have been handled,
+		// and injected panics are turned into errors by the recovery interceptor
+		fmt.Println("Fault injection is enabled")
+		injector := fault.New(faultCfg)
+		unaryInterceptors = append(unaryInterceptors, injector.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, injector.StreamServerInterceptor())
+	}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
 	s := grpc.NewServer(opts...)
-	blogpb.RegisterBlogServiceServer(s, &server{})
+	blogpb.RegisterBlogServiceServer(s, &server{store: blogs})
 	// Register reflection service on gRPC server.
-	reflection.Register(s)
+	if *enableReflection {
+		reflection.Register(s)
+	}
 
+	// with browser access, net/http owns the listener and hands native gRPC
+	// requests to s, telling them from gRPC-Web and Connect by content type
+	var webServer *http.Server
+	if webCfg.Enabled {
+		webServer = webrpc.NewServer(webrpc.NewHandler(s, webCfg), nil)
+	}
 	go func() {
 		fmt.Println("Starting Server...")
-		if err := s.Serve(lis); err != nil {
+		var err error
+		if webServer != nil {
+			err = webrpc.Serve(webServer, lis)
+		} else {
+			err = s.Serve(lis)
+		}
+		if err != nil {
 			log.Fatalf("failed to serve: %v", err)
 		}
 	}()
 
+	var httpServer *http.Server
+	if *httpAddr != "" {
+		// the gateway calls in process on behalf of its clients, whose
+		// addresses it forwards: limit them rather than the gateway
+		gatewayLis := ratelimit.NewProxyListener()
+		go func() {
+			if err := s.Serve(gatewayLis); err != nil {
+				log.Fatalf("failed to serve the gateway calls: %v", err)
+			}
+		}()
+		gateway, err := newGateway(context.Background(), gatewayLis, *specFile)
+		if err != nil {
+			log.Fatalf("Failed to set up the gateway: %v", err)
+		}
+		httpServer = &http.Server{Addr: *httpAddr, Handler: gateway}
+		go func() {
+			fmt.Printf("Starting REST gateway on %v...\n", *httpAddr)
+			if err := httpServer.ListenAndServe(); err != http.ErrServerClosed {
+				log.Fatalf("failed to serve the gateway: %v", err)
+			}
+		}()
+	}
+
 	// Wait for Control C to exit
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, os.Interrupt)
 
 	// Block until a signal is received
 	<-ch
-	// First we close the connection with MongoDB:
-	fmt.Println("Closing MongoDB Connection")
-	// client.Disconnect(context.TODO())
-	if err := client.Disconnect(context.TODO()); err != nil {
-		log.Fatalf("Error on disconnection with MongoDB : %v", err)
-	}
-	// Second step : closing the listener
-	fmt.Println("Closing the listener")
-	if err := lis.Close(); err != nil {
-		log.Fatalf("Error on closing the listener : %v", err)
-	}
-	// Finally, we stop the server
-	fmt.Println("Stopping the server")
-	// s.Stop()
-	s.GracefulStop()
+	if httpServer != nil {
+		fmt.Println("Stopping the gateway")
+		if err := httpServer.Shutdown(context.Background()); err != nil {
+			log.Printf("Error while stopping the gateway: %v", err)
+		}
+	}
+	if client != nil {
+		// First we close the connection with MongoDB:
+		fmt.Println("Closing MongoDB Connection")
+		// client.Disconnect(context.TODO())
+		if err := client.Disconnect(context.TODO()); err != nil {
+			log.Fatalf("Error on disconnection with MongoDB : %v", err)
+		}
+	}
+	if webServer != nil {
+		// closes the listener and waits for the calls in progress,
+		// then s has nothing left to stop
+		fmt.Println("Stopping the server")
+		if err := webServer.Shutdown(context.Background()); err != nil {
+			log.Printf("Error while stopping the server: %v", err)
+		}
+		s.Stop()
+	} else {
+		// Second step : closing the listener
+		fmt.Println("Closing the listener")
+		if err := lis.Close(); err != nil {
+			log.Fatalf("Error on closing the listener : %v", err)
+		}
+		// Finally, we stop the server
+		fmt.Println("Stopping the server")
+		// s.Stop()
+		s.GracefulStop()
+	}
+	// flush the spans that are still buffered
+	if err := tp.Shutdown(context.Background()); err != nil {
+		log.Printf("Error while shutting down tracing: %v", err)
+	}
 	fmt.Println("End of Program")
 }
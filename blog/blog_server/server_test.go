@@ -0,0 +1,395 @@
+package main
+
+import (
+	"blog/blogpb"
+	"common/auth"
+	"common/grpctest"
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// testCallerKey is the metadata naming the caller in tests, as
+// "subject" or "subject:role,role", in place of a JWT.
+const testCallerKey = "x-test-caller"
+
+// testAuth stands in for the token validator: it turns the test caller
+// metadata into claims.
+func testAuth(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	callers := md.Get(testCallerKey)
+	if len(callers) == 0 {
+		return ctx
+	}
+	claims := &auth.Claims{}
+	parts := strings.SplitN(callers[0], ":", 2)
+	claims.Subject = parts[0]
+	if len(parts) == 2 {
+		claims.Roles = strings.Split(parts[1], ",")
+	}
+	return auth.NewContext(ctx, claims)
+}
+
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s authStream) Context() context.Context { return s.ctx }
+
+func newClient(t *testing.T) blogpb.BlogServiceClient {
+	return newClientWithStore(t, newMemoryStore())
+}
+
+func newClientWithStore(t *testing.T, st store) blogpb.BlogServiceClient {
+	cc := grpctest.NewServer(t,
+		func(s *grpc.Server) {
+			blogpb.RegisterBlogServiceServer(s, &server{store: st})
+		},
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return handler(testAuth(ctx), req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, authStream{ss, testAuth(ss.Context())})
+		}),
+	)
+	return blogpb.NewBlogServiceClient(cc)
+}
+
+func as(caller string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), testCallerKey, caller)
+}
+
+func create(t *testing.T, c blogpb.BlogServiceClient, ctx context.Context, blog *blogpb.Blog) *blogpb.Blog {
+	t.Helper()
+	res, err := c.CreateBlog(ctx, &blogpb.CreateBlogRequest{Blog: blog})
+	if err != nil {
+		t.Fatalf("CreateBlog: %v", err)
+	}
+	return res.GetBlog()
+}
+
+func TestCreateAndReadBlog(t *testing.T) {
+	c := newClient(t)
+	blog := &blogpb.Blog{AuthorId: "Stephane", Title: "My Title", Content: "Hello", Tags: []string{"go", "grpc"}}
+	created := create(t, c, context.Background(), blog)
+	if _, err := primitive.ObjectIDFromHex(created.GetId()); err != nil {
+		t.Fatalf("CreateBlog returned id %q: %v", created.GetId(), err)
+	}
+	blog.Id = created.GetId()
+	if !proto.Equal(created, blog) {
+		t.Errorf("CreateBlog = %v, want %v", created, blog)
+	}
+
+	res, err := c.ReadBlog(context.Background(), &blogpb.ReadBlogRequest{BlogId: created.GetId()})
+	if err != nil {
+		t.Fatalf("ReadBlog: %v", err)
+	}
+	if !proto.Equal(res.GetBlog(), blog) {
+		t.Errorf("ReadBlog = %v, want %v", res.GetBlog(), blog)
+	}
+}
+
+func TestCreateBlogDuplicateTitle(t *testing.T) {
+	c := newClient(t)
+	create(t, c, context.Background(), &blogpb.Blog{Title: "Same"})
+	_, err := c.CreateBlog(context.Background(), &blogpb.CreateBlogRequest{Blog: &blogpb.Blog{Title: "Same"}})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("got %v, want Internal", err)
+	}
+}
+
+func TestReadBlogErrors(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		id   string
+		code codes.Code
+	}{
+		{"not an id", codes.InvalidArgument},
+		{"", codes.InvalidArgument},
+		{primitive.NewObjectID().Hex(), codes.NotFound},
+	}
+	for _, tt := range tests {
+		_, err := c.ReadBlog(context.Background(), &blogpb.ReadBlogRequest{BlogId: tt.id})
+		if status.Code(err) != tt.code {
+			t.Errorf("ReadBlog(%q): got %v, want %v", tt.id, err, tt.code)
+		}
+	}
+}
+
+func TestUpdateBlog(t *testing.T) {
+	original := &blogpb.Blog{AuthorId: "Lucy", Title: "Draft", Content: "Hello", Tags: []string{"go"}}
+	tests := []struct {
+		name   string
+		update *blogpb.Blog
+		mask   []string
+		want   *blogpb.Blog
+		code   codes.Code
+	}{
+		{
+			name:   "all fields",
+			update: &blogpb.Blog{AuthorId: "John", Title: "Final", Content: "Bye"},
+			want:   &blogpb.Blog{AuthorId: "John", Title: "Final", Content: "Bye"},
+		},
+		{
+			name:   "masked",
+			update: &blogpb.Blog{AuthorId: "John", Title: "Final", Content: "Bye"},
+			mask:   []string{"title", "id"},
+			want:   &blogpb.Blog{AuthorId: "Lucy", Title: "Final", Content: "Hello", Tags: []string{"go"}},
+		},
+		{
+			name:   "empty mask",
+			update: &blogpb.Blog{Title: "Final"},
+			mask:   []string{},
+			want:   original,
+		},
+		{
+			name:   "unknown field",
+			update: &blogpb.Blog{Title: "Final"},
+			mask:   []string{"likes"},
+			code:   codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(t)
+			id := create(t, c, context.Background(), proto.Clone(original).(*blogpb.Blog)).GetId()
+			update := proto.Clone(tt.update).(*blogpb.Blog)
+			update.Id = id
+			req := &blogpb.UpdateBlogRequest{Blog: update}
+			if tt.mask != nil {
+				req.UpdateMask = &fieldmaskpb.FieldMask{Paths: tt.mask}
+			}
+			res, err := c.UpdateBlog(context.Background(), req)
+			if status.Code(err) != tt.code {
+				t.Fatalf("got %v, want %v", err, tt.code)
+			}
+			if err != nil {
+				return
+			}
+			want := proto.Clone(tt.want).(*blogpb.Blog)
+			want.Id = id
+			if !proto.Equal(res.GetBlog(), want) {
+				t.Errorf("UpdateBlog = %v, want %v", res.GetBlog(), want)
+			}
+			read, err := c.ReadBlog(context.Background(), &blogpb.ReadBlogRequest{BlogId: id})
+			if err != nil {
+				t.Fatalf("ReadBlog: %v", err)
+			}
+			if !proto.Equal(read.GetBlog(), want) {
+				t.Errorf("ReadBlog after update = %v, want %v", read.GetBlog(), want)
+			}
+		})
+	}
+}
+
+func TestUpdateBlogErrors(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		id   string
+		code codes.Code
+	}{
+		{"bad", codes.InvalidArgument},
+		{primitive.NewObjectID().Hex(), codes.NotFound},
+	}
+	for _, tt := range tests {
+		_, err := c.UpdateBlog(context.Background(), &blogpb.UpdateBlogRequest{Blog: &blogpb.Blog{Id: tt.id}})
+		if status.Code(err) != tt.code {
+			t.Errorf("UpdateBlog(%q): got %v, want %v", tt.id, err, tt.code)
+		}
+	}
+}
+
+func TestDeleteBlog(t *testing.T) {
+	c := newClient(t)
+	id := create(t, c, context.Background(), &blogpb.Blog{Title: "Short lived"}).GetId()
+	res, err := c.DeleteBlog(context.Background(), &blogpb.DeleteBlogRequest{BlogId: id})
+	if err != nil {
+		t.Fatalf("DeleteBlog: %v", err)
+	}
+	if res.GetBlogId() != id {
+		t.Errorf("DeleteBlog returned %q, want %q", res.GetBlogId(), id)
+	}
+	if _, err := c.ReadBlog(context.Background(), &blogpb.ReadBlogRequest{BlogId: id}); status.Code(err) != codes.NotFound {
+		t.Errorf("ReadBlog after delete: got %v, want NotFound", err)
+	}
+	if _, err := c.DeleteBlog(context.Background(), &blogpb.DeleteBlogRequest{BlogId: id}); status.Code(err) != codes.NotFound {
+		t.Errorf("second DeleteBlog: got %v, want NotFound", err)
+	}
+	if _, err := c.DeleteBlog(context.Background(), &blogpb.DeleteBlogRequest{BlogId: "bad"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("DeleteBlog with a bad id: got %v, want InvalidArgument", err)
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	c := newClient(t)
+	// the author is the caller, whatever the request says
+	blog := create(t, c, as("alice"), &blogpb.Blog{AuthorId: "mallory", Title: "Alice's"})
+	if blog.GetAuthorId() != "alice" {
+		t.Fatalf("author = %q, want alice", blog.GetAuthorId())
+	}
+
+	tests := []struct {
+		name   string
+		caller string
+		code   codes.Code
+	}{
+		{"other caller", "bob", codes.PermissionDenied},
+		{"author", "alice", codes.OK},
+		{"admin", "carol:admin", codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := c.UpdateBlog(as(tt.caller), &blogpb.UpdateBlogRequest{
+				Blog: &blogpb.Blog{Id: blog.GetId(), AuthorId: "bob", Title: "Alice's", Content: tt.name},
+			})
+			if status.Code(err) != tt.code {
+				t.Fatalf("UpdateBlog: got %v, want %v", err, tt.code)
+			}
+			// the author cannot be changed by authenticated callers
+			if err == nil && res.GetBlog().GetAuthorId() != "alice" {
+				t.Errorf("author = %q after update, want alice", res.GetBlog().GetAuthorId())
+			}
+		})
+	}
+
+	if _, err := c.DeleteBlog(as("bob"), &blogpb.DeleteBlogRequest{BlogId: blog.GetId()}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("DeleteBlog by bob: got %v, want PermissionDenied", err)
+	}
+	if _, err := c.DeleteBlog(as("carol:admin"), &blogpb.DeleteBlogRequest{BlogId: blog.GetId()}); err != nil {
+		t.Errorf("DeleteBlog by an admin: %v", err)
+	}
+}
+
+func TestListBlog(t *testing.T) {
+	c := newClient(t)
+	var want []string
+	for i := 0; i < 5; i++ {
+		title := fmt.Sprintf("Blog %d", i)
+		create(t, c, context.Background(), &blogpb.Blog{Title: title})
+		want = append(want, title)
+	}
+	stream, err := c.ListBlog(context.Background(), &blogpb.ListBlogRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		got = append(got, res.GetBlog().GetTitle())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListBlog = %v, want %v", got, want)
+	}
+}
+
+func TestListBlogEmpty(t *testing.T) {
+	c := newClient(t)
+	stream, err := c.ListBlog(context.Background(), &blogpb.ListBlogRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("Recv: got %v, want EOF", err)
+	}
+}
+
+// stallingStore lists the first blog, then waits for the call to end.
+type stallingStore struct {
+	store
+	done chan error
+}
+
+func (s *stallingStore) List(ctx context.Context, fn func(*blogItem) error) error {
+	err := s.store.List(ctx, func(item *blogItem) error {
+		if err := fn(item); err != nil {
+			return err
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	s.done <- err
+	return err
+}
+
+func TestListBlogCanceled(t *testing.T) {
+	st := &stallingStore{store: newMemoryStore(), done: make(chan error, 1)}
+	c := newClientWithStore(t, st)
+	for i := 0; i < 3; i++ {
+		create(t, c, context.Background(), &blogpb.Blog{Title: fmt.Sprintf("Blog %d", i)})
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.ListBlog(ctx, &blogpb.ListBlogRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Errorf("Recv after cancel: got %v, want Canceled", err)
+	}
+	// the server stops listing once the client is gone
+	if err := <-st.done; err != context.Canceled {
+		t.Errorf("List ended with %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestListBlogPage(t *testing.T) {
+	c := newClient(t)
+	for _, b := range []*blogpb.Blog{
+		{AuthorId: "Stephane", Title: "My Title", Content: "listed"},
+		{AuthorId: "Stephane", Title: "Other", Content: "listed too"},
+		{AuthorId: "Lucy", Title: "B"},
+		{AuthorId: "Lucy", Title: "A"},
+		{AuthorId: "Mark", Title: "C"},
+	} {
+		create(t, c, context.Background(), b)
+	}
+	// by author descending, then by title
+	all := []string{"Stephane/My Title", "Stephane/Other", "Mark/C", "Lucy/A", "Lucy/B"}
+	tests := []struct {
+		skip, limit int64
+		want        []string
+	}{
+		{0, 0, all},
+		{1, 2, all[1:3]},
+		{3, 10, all[3:]},
+		{4, 1, all[4:]},
+		{10, 0, nil},
+	}
+	for _, tt := range tests {
+		res, err := c.ListBlogPage(context.Background(), &blogpb.ListBlogPageRequest{Skip: tt.skip, Limit: tt.limit})
+		if err != nil {
+			t.Fatalf("ListBlogPage(%d, %d): %v", tt.skip, tt.limit, err)
+		}
+		var got []string
+		for _, b := range res.GetBlogs() {
+			if b.GetContent() != "" {
+				t.Errorf("ListBlogPage returned the content of %q", b.GetTitle())
+			}
+			got = append(got, b.GetAuthorId()+"/"+b.GetTitle())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ListBlogPage(%d, %d) = %v, want %v", tt.skip, tt.limit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// errNotFound is returned by the stores when no blog matches.
+	errNotFound = errors.New("blog not found")
+	// errDuplicateTitle is how memoryStore mirrors the unique index of
+	// mongoStore: as the blogs have no article_id, titles are unique.
+	errDuplicateTitle = errors.New("a blog with this title already exists")
+)
+
+// store keeps the blogs. mongoStore keeps them in MongoDB, memoryStore in
+// memory, for tests and for running the server without MongoDB.
+type store interface {
+	// Insert saves a new blog and returns its id.
+	Insert(ctx context.Context, item *blogItem) (primitive.ObjectID, error)
+	// Get returns the blog with the given id.
+	Get(ctx context.Context, id primitive.ObjectID) (*blogItem, error)
+	// Update sets the fields in set, keyed by their BSON names, on the blog
+	// with the given id and author, and returns the updated blog.
+	Update(ctx context.Context, id primitive.ObjectID, authorID string, set bson.M) (*blogItem, error)
+	// Delete removes the blog with the given id and author.
+	Delete(ctx context.Context, id primitive.ObjectID, authorID string) error
+	// List calls fn with every blog, until fn returns an error.
+	List(ctx context.Context, fn func(*blogItem) error) error
+	// ListPage returns the blogs of one page of the listing, with only their
+	// id, author and title. A limit of 0 means no limit.
+	ListPage(ctx context.Context, skip, limit int64) ([]*blogItem, error)
+}
+
+type memoryStore struct {
+	mu    sync.Mutex
+	items []*blogItem // in insertion order, like a MongoDB collection scan
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{}
+}
+
+func (m *memoryStore) Insert(_ context.Context, item *blogItem) (primitive.ObjectID, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.titleTaken(item.Title, primitive.NilObjectID) {
+		return primitive.NilObjectID, errDuplicateTitle
+	}
+	stored := copyItem(item)
+	stored.ID = primitive.NewObjectID()
+	m.items = append(m.items, stored)
+	return stored.ID, nil
+}
+
+// titleTaken tells whether a blog other than the one with the given id has
+// the given title.
+func (m *memoryStore) titleTaken(title string, id primitive.ObjectID) bool {
+	for _, item := range m.items {
+		if item.Title == title && item.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+// find returns the index of the blog with the given id, or -1.
+func (m *memoryStore) find(id primitive.ObjectID) int {
+	for i, item := range m.items {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *memoryStore) Get(_ context.Context, id primitive.ObjectID) (*blogItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := m.find(id)
+	if i < 0 {
+		return nil, errNotFound
+	}
+	return copyItem(m.items[i]), nil
+}
+
+func (m *memoryStore) Update(_ context.Context, id primitive.ObjectID, authorID string, set bson.M) (*blogItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := m.find(id)
+	if i < 0 || m.items[i].AuthorID != authorID {
+		return nil, errNotFound
+	}
+	item := m.items[i]
+	if title, ok := set["title"]; ok && m.titleTaken(title.(string), id) {
+		return nil, errDuplicateTitle
+	}
+	for field, value := range set {
+		switch field {
+		case "author_id":
+			item.AuthorID = value.(string)
+		case "content":
+			item.Content = value.(string)
+		case "title":
+			item.Title = value.(string)
+		case "tags":
+			item.Tags = append([]string(nil), value.([]string)...)
+		}
+	}
+	return copyItem(item), nil
+}
+
+func (m *memoryStore) Delete(_ context.Context, id primitive.ObjectID, authorID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := m.find(id)
+	if i < 0 || m.items[i].AuthorID != authorID {
+		return errNotFound
+	}
+	m.items = append(m.items[:i], m.items[i+1:]...)
+	return nil
+}
+
+func (m *memoryStore) List(ctx context.Context, fn func(*blogItem) error) error {
+	m.mu.Lock()
+	items := make([]*blogItem, len(m.items))
+	for i, item := range m.items {
+		items[i] = copyItem(item)
+	}
+	m.mu.Unlock()
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) ListPage(_ context.Context, skip, limit int64) ([]*blogItem, error) {
+	m.mu.Lock()
+	var page []*blogItem
+	for _, item := range m.items {
+		page = append(page, &blogItem{ID: item.ID, AuthorID: item.AuthorID, Title: item.Title})
+	}
+	m.mu.Unlock()
+
+	// by author descending, then by title
+	sort.SliceStable(page, func(i, j int) bool {
+		if page[i].AuthorID != page[j].AuthorID {
+			return page[i].AuthorID > page[j].AuthorID
+		}
+		return page[i].Title < page[j].Title
+	})
+	if skip >= int64(len(page)) {
+		return nil, nil
+	}
+	if skip > 0 {
+		page = page[skip:]
+	}
+	if limit > 0 && limit < int64(len(page)) {
+		page = page[:limit]
+	}
+	return page, nil
+}
+
+func copyItem(item *blogItem) *blogItem {
+	c := *item
+	c.Tags = append([]string(nil), item.Tags...)
+	return &c
+}
@@ -0,0 +1,85 @@
+package blogfake_test
+
+import (
+	"blog/blogpb"
+	"blog/blogpb/blogfake"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestClient(t *testing.T) {
+	c := &blogfake.BlogServiceClient{}
+	c.OnReadBlog(&blogpb.ReadBlogResponse{Blog: &blogpb.Blog{Id: "1", Title: "First"}}, nil)
+	c.OnReadBlog(nil, status.Error(codes.NotFound, "no blog 2"))
+
+	res, err := c.ReadBlog(context.Background(), &blogpb.ReadBlogRequest{BlogId: "1"})
+	if err != nil || res.GetBlog().GetTitle() != "First" {
+		t.Errorf("first ReadBlog = %v, %v; want First", res, err)
+	}
+	if _, err := c.ReadBlog(context.Background(), &blogpb.ReadBlogRequest{BlogId: "2"}); status.Code(err) != codes.NotFound {
+		t.Errorf("second ReadBlog: got %v, want NotFound", err)
+	}
+
+	calls := c.CallsTo("/blog.BlogService/ReadBlog")
+	if len(calls) != 2 || !proto.Equal(calls[1].Request(), &blogpb.ReadBlogRequest{BlogId: "2"}) {
+		t.Errorf("recorded %v", calls)
+	}
+}
+
+func TestServer(t *testing.T) {
+	s := &blogfake.BlogServiceServer{}
+	s.OnListBlog([]*blogpb.ListBlogResponse{{Blog: &blogpb.Blog{Id: "1"}}, {Blog: &blogpb.Blog{Id: "2"}}}, nil)
+	c := s.Client(t)
+
+	stream, err := c.ListBlog(context.Background(), &blogpb.ListBlogRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		got = append(got, res.GetBlog().GetId())
+	}
+	if len(got) != 2 || got[0] != "1" || got[1] != "2" {
+		t.Errorf("got %v, want [1 2]", got)
+	}
+	if n := len(s.CallsTo("/blog.BlogService/ListBlog")); n != 1 {
+		t.Errorf("recorded %d calls, want 1", n)
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	s := &blogfake.BlogServiceServer{}
+	s.OnDeleteBlog(&blogpb.DeleteBlogResponse{BlogId: "1"}, nil)
+	c := s.Client(t)
+
+	s.SetError("/blog.BlogService/DeleteBlog", status.Error(codes.Unavailable, "flaky"), 1)
+	if _, err := c.DeleteBlog(context.Background(), &blogpb.DeleteBlogRequest{BlogId: "1"}); status.Code(err) != codes.Unavailable {
+		t.Errorf("with error injected: got %v, want Unavailable", err)
+	}
+	s.SetError("/blog.BlogService/DeleteBlog", nil, 0)
+
+	s.SetLatency("", time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.DeleteBlog(ctx, &blogpb.DeleteBlogRequest{BlogId: "1"}); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("with latency injected: got %v, want DeadlineExceeded", err)
+	}
+	s.SetLatency("", 0)
+
+	if res, err := c.DeleteBlog(context.Background(), &blogpb.DeleteBlogRequest{BlogId: "1"}); err != nil || res.GetBlogId() != "1" {
+		t.Errorf("without faults: got %v, %v", res, err)
+	}
+}
@@ -1,3 +1,3 @@
 IMAGE_TAG="v1"
 SVC_NAME=$1
-docker build . -t "$SVC_NAME:$IMAGE_TAG" --build-arg SVC_NAME=$SVC_NAME
\ No newline at end of file
+docker build .. -f Dockerfile -t "$SVC_NAME:$IMAGE_TAG" --build-arg SVC_NAME=$SVC_NAME
\ No newline at end of file
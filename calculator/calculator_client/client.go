@@ -2,28 +2,74 @@ package main
 
 import (
 	"calculator/calculatorpb"
+	"common/auth"
+	"common/protoset"
+	"common/repl"
+	"common/tracing"
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
+	"os"
+	"strconv"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 func main() {
+	traceCfg := tracing.Config{ServiceName: "calculator_client"}
+	traceCfg.RegisterFlags(flag.CommandLine)
+	var authCfg auth.ClientConfig
+	authCfg.RegisterFlags(flag.CommandLine)
+	interactive := flag.Bool("interactive", false, "drive FindMaximum from stdin instead of running the examples")
+	flag.Parse()
 
 	fmt.Println("Calculator Client")
-	cc, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
+
+	tp, err := tracing.Init(context.Background(), traceCfg)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	// flush the spans that are still buffered
+	defer tp.Shutdown(context.Background())
+
+	traceUnary, traceStream := tracing.ClientInterceptors()
+	opts := []grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithChainUnaryInterceptor(traceUnary),
+		grpc.WithChainStreamInterceptor(traceStream),
+	}
+	// the connection is plaintext, so the token has to be allowed without TLS
+	if creds := authCfg.Credentials(true); creds != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(creds))
+	}
+	cc, err := grpc.Dial("localhost:50051", opts...)
 	if err != nil {
 		log.Fatalf("could not connect: %v", err)
 	}
 	defer cc.Close()
 
+	// "client describe [file]" saves the service descriptors for tooling
+	if flag.Arg(0) == "describe" {
+		path, err := protoset.Describe(cc, "calculator.CalculatorService", flag.Arg(1))
+		if err != nil {
+			log.Fatalf("Failed to describe the service: %v", err)
+		}
+		fmt.Printf("Wrote the descriptors of calculator.CalculatorService to %v\n", path)
+		return
+	}
+
 	c := calculatorpb.NewCalculatorServiceClient(cc)
 	// fmt.Printf("Created client: %f", c)
+	if *interactive {
+		doInteractive(c)
+		return
+	}
 
 	// doUnary(c)
 
@@ -34,6 +80,8 @@ func main() {
 	doBiDiStreaming(c)
 
 	// doErrorUnary(c)
+
+	// doEvaluate(c)
 }
 
 func doUnary(c calculatorpb.CalculatorServiceClient) {
@@ -137,6 +185,38 @@ func doBiDiStreaming(c calculatorpb.CalculatorServiceClient) {
 	<-waitc
 }
 
+// doInteractive sends every number typed on stdin to FindMaximum and prints
+// the new maximums as they come.
+func doInteractive(c calculatorpb.CalculatorServiceClient) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := c.FindMaximum(ctx)
+	if err != nil {
+		log.Fatalf("Error while opening stream and calling FindMaximum: %v", err)
+	}
+	session := &repl.Session{
+		Stream:      stream,
+		Cancel:      cancel,
+		NewRequest:  func() proto.Message { return &calculatorpb.FindMaximumRequest{} },
+		NewResponse: func() proto.Message { return &calculatorpb.FindMaximumResponse{} },
+		Parse: func(line string, req proto.Message) error {
+			n, err := strconv.ParseInt(line, 10, 32)
+			if err != nil {
+				return err
+			}
+			req.(*calculatorpb.FindMaximumRequest).Number = int32(n)
+			return nil
+		},
+		Format: func(res proto.Message) string {
+			return fmt.Sprintf("new maximum %v", res.(*calculatorpb.FindMaximumResponse).GetMaximum())
+		},
+		Out: os.Stdout,
+	}
+	if err := session.Run(os.Stdin); err != nil {
+		log.Printf("FindMaximum ended with an error: %v", err)
+	}
+}
+
 func doErrorUnary(c calculatorpb.CalculatorServiceClient) {
 	fmt.Println("Starting to do a SquareRoot Unary RPC...")
 
@@ -167,3 +247,23 @@ func doErrorCall(c calculatorpb.CalculatorServiceClient, n int32) {
 	}
 	fmt.Printf("Result of square root of %v: %v\n", n, res.GetNumberRoot())
 }
+
+func doEvaluate(c calculatorpb.CalculatorServiceClient) {
+	fmt.Println("Starting to do an Evaluate Unary RPC...")
+	variables := map[string]float64{"x": 3, "y": 16}
+	for _, expr := range []string{"2 * (x + 1) ^ 2 - sqrt(y)", "2 * (x + 1"} {
+		res, err := c.Evaluate(context.Background(), &calculatorpb.EvaluateRequest{
+			Expression: expr,
+			Variables:  variables,
+		})
+		if err != nil {
+			respErr, ok := status.FromError(err)
+			if !ok {
+				log.Fatalf("Big Error calling Evaluate: %v", err)
+			}
+			fmt.Printf("Error evaluating %q: %v\n", expr, respErr.Message())
+			continue
+		}
+		fmt.Printf("%v = %v\n", expr, res.GetResult())
+	}
+}
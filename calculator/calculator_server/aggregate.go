@@ -0,0 +1,317 @@
+package main
+
+import (
+	"calculator/calculatorpb"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	maxWindowSize     = 100000
+	minWindowDuration = 10 * time.Millisecond
+	maxWindowDuration = time.Hour
+	// maxOverlap bounds the number of sliding windows a number is in, each
+	// of which is aggregated separately
+	maxOverlap = 1000
+	// maxTimedNumbers bounds the numbers kept for the windows by time
+	maxTimedNumbers = 1000000
+)
+
+// aggregate applies an aggregation to the numbers of a window, which are
+// never empty.
+func aggregate(agg calculatorpb.StreamAggregateRequest_Aggregation, numbers []float64) float64 {
+	switch agg {
+	case calculatorpb.StreamAggregateRequest_MAX:
+		v := math.Inf(-1)
+		for _, n := range numbers {
+			v = math.Max(v, n)
+		}
+		return v
+	case calculatorpb.StreamAggregateRequest_MIN:
+		v := math.Inf(1)
+		for _, n := range numbers {
+			v = math.Min(v, n)
+		}
+		return v
+	case calculatorpb.StreamAggregateRequest_SUM, calculatorpb.StreamAggregateRequest_MEAN:
+		v := 0.0
+		for _, n := range numbers {
+			v += n
+		}
+		if agg == calculatorpb.StreamAggregateRequest_MEAN {
+			v /= float64(len(numbers))
+		}
+		return v
+	default: // COUNT
+		return float64(len(numbers))
+	}
+}
+
+// windowing is the validated config of a StreamAggregate call. Windows by
+// count have a size and a slide, windows by time a length and a step.
+type windowing struct {
+	agg         calculatorpb.StreamAggregateRequest_Aggregation
+	size, slide int64
+	length      time.Duration
+	step        time.Duration
+}
+
+func (w *windowing) byTime() bool {
+	return w.length > 0
+}
+
+func newWindowing(cfg *calculatorpb.StreamAggregateRequest_Config) (*windowing, error) {
+	agg := cfg.GetAggregation()
+	if _, ok := calculatorpb.StreamAggregateRequest_Aggregation_name[int32(agg)]; !ok || agg == calculatorpb.StreamAggregateRequest_AGGREGATION_UNSPECIFIED {
+		return nil, invalidArgument("no aggregation given")
+	}
+	win := cfg.GetWindow()
+	sliding := win.GetType() == calculatorpb.Window_SLIDING
+	w := &windowing{agg: agg}
+	switch {
+	case win.GetSize() > 0 && win.GetDuration() != nil:
+		return nil, invalidArgument("a window is either by count or by time, not both")
+	case win.GetSize() > 0:
+		w.size, w.slide = int64(win.GetSize()), int64(win.GetSize())
+		if w.size > maxWindowSize {
+			return nil, invalidArgument("window size %d is over the maximum of %d", w.size, maxWindowSize)
+		}
+		if sliding {
+			w.slide = int64(win.GetSlide())
+			if w.slide <= 0 || w.slide > w.size {
+				return nil, invalidArgument("the slide of a sliding window must be between 1 and its size %d, got %d", w.size, w.slide)
+			}
+			if w.size/w.slide > maxOverlap {
+				return nil, invalidArgument("the slide of a sliding window must be at least 1/%d of its size", maxOverlap)
+			}
+		}
+	case win.GetDuration() != nil:
+		if err := win.GetDuration().CheckValid(); err != nil {
+			return nil, invalidArgument("window duration: %v", err)
+		}
+		w.length = win.GetDuration().AsDuration()
+		if w.length < minWindowDuration || w.length > maxWindowDuration {
+			return nil, invalidArgument("window duration %v is not between %v and %v", w.length, minWindowDuration, maxWindowDuration)
+		}
+		w.step = w.length
+		if sliding {
+			w.step = win.GetSlideDuration().AsDuration()
+			if w.step < minWindowDuration || w.step > w.length {
+				return nil, invalidArgument("the slide of a sliding window must be between %v and its duration %v, got %v", minWindowDuration, w.length, w.step)
+			}
+			if w.length/w.step > maxOverlap {
+				return nil, invalidArgument("the slide of a sliding window must be at least 1/%d of its duration", maxOverlap)
+			}
+		}
+	default:
+		return nil, invalidArgument("a window needs a size or a duration")
+	}
+	return w, nil
+}
+
+// received is a message read from the stream, or the error that ended it.
+type received struct {
+	req *calculatorpb.StreamAggregateRequest
+	err error
+}
+
+func (*server) StreamAggregate(stream calculatorpb.CalculatorService_StreamAggregateServer) error {
+	fmt.Println("Received StreamAggregate RPC")
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if first.GetConfig() == nil {
+		return invalidArgument("the first message must be the config")
+	}
+	w, err := newWindowing(first.GetConfig())
+	if err != nil {
+		return err
+	}
+
+	// read on another goroutine, so that windows by time can end while the
+	// client sends nothing
+	ctx := stream.Context()
+	msgs := make(chan received)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			select {
+			case msgs <- received{req, err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	if w.byTime() {
+		return aggregateByTime(stream, w, msgs)
+	}
+	return aggregateByCount(stream, w, msgs)
+}
+
+// nextNumber returns the number of a message, failing if it is not one.
+func nextNumber(r received) (float64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, ok := r.req.GetMessage().(*calculatorpb.StreamAggregateRequest_Number)
+	if !ok {
+		return 0, invalidArgument("only the first message may be a config")
+	}
+	return n.Number, nil
+}
+
+func aggregateByCount(stream calculatorpb.CalculatorService_StreamAggregateServer, w *windowing, msgs <-chan received) error {
+	ctx := stream.Context()
+	// the last size numbers; numbers[i] is the number at position base + i
+	var numbers []float64
+	var base, count int64
+	send := func(start, end int64, partial bool) error {
+		window := numbers[start-base : end-base]
+		return stream.Send(&calculatorpb.StreamAggregateResponse{
+			Value:      aggregate(w.agg, window),
+			Count:      int64(len(window)),
+			StartIndex: start,
+			EndIndex:   end,
+			Partial:    partial,
+		})
+	}
+	for {
+		var r received
+		select {
+		case r = <-msgs:
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		if r.err == io.EOF {
+			// the windows started and not full yet
+			for start := (count - w.size + w.slide) / w.slide * w.slide; start < count; start += w.slide {
+				if start < 0 {
+					continue
+				}
+				if err := send(start, count, true); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		n, err := nextNumber(r)
+		if err != nil {
+			return err
+		}
+		numbers = append(numbers, n)
+		count++
+		if start := count - w.size; start >= 0 && start%w.slide == 0 {
+			if err := send(start, count, false); err != nil {
+				return err
+			}
+		}
+		if int64(len(numbers)) > w.size {
+			drop := int64(len(numbers)) - w.size
+			numbers = append(numbers[:0], numbers[drop:]...)
+			base += drop
+		}
+	}
+}
+
+// timed is a number with the time it was received.
+type timed struct {
+	at     time.Time
+	number float64
+}
+
+func aggregateByTime(stream calculatorpb.CalculatorService_StreamAggregateServer, w *windowing, msgs <-chan received) error {
+	ctx := stream.Context()
+	origin := time.Now()
+	// the numbers of the windows not sent yet, and the index of the next
+	// window to end, which starts at origin + next * step
+	var numbers []timed
+	next := int64(0)
+	bounds := func(i int64) (time.Time, time.Time) {
+		start := origin.Add(time.Duration(i) * w.step)
+		return start, start.Add(w.length)
+	}
+	send := func(start, end time.Time, partial bool) error {
+		var window []float64
+		for _, n := range numbers {
+			if !n.at.Before(start) && n.at.Before(end) {
+				window = append(window, n.number)
+			}
+		}
+		if len(window) == 0 {
+			return nil
+		}
+		return stream.Send(&calculatorpb.StreamAggregateResponse{
+			Value:     aggregate(w.agg, window),
+			Count:     int64(len(window)),
+			StartTime: timestamppb.New(start),
+			EndTime:   timestamppb.New(end),
+			Partial:   partial,
+		})
+	}
+	_, end := bounds(next)
+	timer := time.NewTimer(time.Until(end))
+	defer timer.Stop()
+	for {
+		select {
+		case r := <-msgs:
+			if r.err == io.EOF {
+				now := time.Now()
+				for i := next; ; i++ {
+					start, end := bounds(i)
+					if start.After(now) {
+						return nil
+					}
+					if err := send(start, end, true); err != nil {
+						return err
+					}
+				}
+			}
+			n, err := nextNumber(r)
+			if err != nil {
+				return err
+			}
+			if len(numbers) >= maxTimedNumbers {
+				return status.Errorf(codes.ResourceExhausted, "more than %d numbers in the open windows", maxTimedNumbers)
+			}
+			numbers = append(numbers, timed{time.Now(), n})
+		case <-timer.C:
+			// the windows that have ended, a late timer may see several
+			now := time.Now()
+			for {
+				start, end := bounds(next)
+				if end.After(now) {
+					timer.Reset(end.Sub(now))
+					break
+				}
+				if err := send(start, end, false); err != nil {
+					return err
+				}
+				next++
+				// forget the numbers before the next window
+				start, _ = bounds(next)
+				i := 0
+				for i < len(numbers) && numbers[i].at.Before(start) {
+					i++
+				}
+				numbers = numbers[i:]
+			}
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"calculator/calculatorpb"
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxBatchOperations bounds the operations of a BatchCompute call.
+const maxBatchOperations = 10000
+
+// workers returns the number of operations of a batch performed in
+// parallel.
+func (s *server) workers() int {
+	if s.batchWorkers > 0 {
+		return s.batchWorkers
+	}
+	return runtime.NumCPU()
+}
+
+// operationRPCs are the names of the RPCs performing the operations of a
+// batch, by field of the operation oneof, which are named after them.
+var operationRPCs = func() map[protoreflect.FieldNumber]string {
+	rpcs := make(map[protoreflect.FieldNumber]string)
+	fields := (&calculatorpb.BatchComputeRequest_Operation{}).ProtoReflect().Descriptor().Oneofs().ByName("request").Fields()
+	for i := 0; i < fields.Len(); i++ {
+		var name strings.Builder
+		for _, word := range strings.Split(string(fields.Get(i).Name()), "_") {
+			name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+		rpcs[fields.Get(i).Number()] = name.String()
+	}
+	return rpcs
+}()
+
+// batchCalls tells the rate limiter that a BatchCompute call stands for
+// itself and for each of its operations, charged against the limit of the
+// RPC performing it, so that batches do not get around the limits of the
+// other RPCs.
+func batchCalls(method string, req interface{}) map[string]int {
+	batch, ok := req.(*calculatorpb.BatchComputeRequest)
+	if !ok {
+		return nil
+	}
+	service := method[:strings.LastIndex(method, "/")+1]
+	calls := map[string]int{method: 1}
+	for _, op := range batch.GetOperations() {
+		m := op.ProtoReflect()
+		if f := m.WhichOneof(m.Descriptor().Oneofs().ByName("request")); f != nil {
+			calls[service+operationRPCs[f.Number()]]++
+		}
+	}
+	return calls
+}
+
+func (s *server) BatchCompute(ctx context.Context, req *calculatorpb.BatchComputeRequest) (*calculatorpb.BatchComputeResponse, error) {
+	ops := req.GetOperations()
+	fmt.Printf("Received BatchCompute RPC: %d operations\n", len(ops))
+	if len(ops) > maxBatchOperations {
+		return nil, invalidArgument("a batch has %d operations at most, got %d", maxBatchOperations, len(ops))
+	}
+
+	results := make([]*calculatorpb.BatchComputeResponse_Result, len(ops))
+	workers := s.workers()
+	if workers > len(ops) {
+		workers = len(ops)
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = compute(ops[i])
+			}
+		}()
+	}
+	// the operations not started when the call is canceled are left out
+feed:
+	for i := range ops {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	return &calculatorpb.BatchComputeResponse{Results: results}, nil
+}
+
+// compute performs an operation of a batch as its RPC does, without logging
+// it.
+func compute(op *calculatorpb.BatchComputeRequest_Operation) *calculatorpb.BatchComputeResponse_Result {
+	var result calculatorpb.BatchComputeResponse_Result
+	var err error
+	switch r := op.GetRequest().(type) {
+	case *calculatorpb.BatchComputeRequest_Operation_Sum:
+		var res *calculatorpb.SumResponse
+		if res, err = sum(r.Sum); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_Sum{Sum: res}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_SquareRoot:
+		var res *calculatorpb.SquareRootResponse
+		if res, err = squareRoot(r.SquareRoot); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_SquareRoot{SquareRoot: res}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_Calculate:
+		var res string
+		if res, err = calculate(r.Calculate); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_Calculate{Calculate: &calculatorpb.CalculateResponse{Result: res}}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_Evaluate:
+		var res *calculatorpb.EvaluateResponse
+		if res, err = evaluate(r.Evaluate); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_Evaluate{Evaluate: res}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_MultiplyMatrices:
+		var res *calculatorpb.MultiplyMatricesResponse
+		if res, err = multiplyMatrices(r.MultiplyMatrices); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_MultiplyMatrices{MultiplyMatrices: res}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_TransposeMatrix:
+		var res *calculatorpb.MatrixResponse
+		if res, err = transposeMatrix(r.TransposeMatrix); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_TransposeMatrix{TransposeMatrix: res}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_Determinant:
+		var res *calculatorpb.DeterminantResponse
+		if res, err = determinant(r.Determinant); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_Determinant{Determinant: res}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_InvertMatrix:
+		var res *calculatorpb.MatrixResponse
+		if res, err = invertMatrix(r.InvertMatrix); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_InvertMatrix{InvertMatrix: res}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_SolveLinearSystem:
+		var res *calculatorpb.SolveLinearSystemResponse
+		if res, err = solveLinearSystem(r.SolveLinearSystem); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_SolveLinearSystem{SolveLinearSystem: res}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_ConvertUnits:
+		var res *calculatorpb.ConvertUnitsResponse
+		if res, err = convertUnits(r.ConvertUnits); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_ConvertUnits{ConvertUnits: res}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_CalculateQuantities:
+		var res *calculatorpb.CalculateQuantitiesResponse
+		if res, err = calculateQuantities(r.CalculateQuantities); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_CalculateQuantities{CalculateQuantities: res}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_GreatestCommonDivisor:
+		var res *calculatorpb.IntegerResponse
+		if res, err = greatestCommonDivisor(r.GreatestCommonDivisor); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_GreatestCommonDivisor{GreatestCommonDivisor: res}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_LeastCommonMultiple:
+		var res *calculatorpb.IntegerResponse
+		if res, err = leastCommonMultiple(r.LeastCommonMultiple); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_LeastCommonMultiple{LeastCommonMultiple: res}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_ModularExponentiation:
+		var res *calculatorpb.IntegerResponse
+		if res, err = modularExponentiation(r.ModularExponentiation); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_ModularExponentiation{ModularExponentiation: res}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_ModularInverse:
+		var res *calculatorpb.IntegerResponse
+		if res, err = modularInverse(r.ModularInverse); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_ModularInverse{ModularInverse: res}
+		}
+	case *calculatorpb.BatchComputeRequest_Operation_IsPrime:
+		var res *calculatorpb.IsPrimeResponse
+		if res, err = primality(r.IsPrime); err == nil {
+			result.Result = &calculatorpb.BatchComputeResponse_Result_IsPrime{IsPrime: res}
+		}
+	default:
+		err = invalidArgument("no operation given")
+	}
+	if err != nil {
+		result.Result = &calculatorpb.BatchComputeResponse_Result_Error{Error: status.Convert(err).Proto()}
+	}
+	return &result
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"calculator/calculatorpb"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultPrecision is the number of significant digits of FLOAT results
+	// when the request does not say, that of an IEEE 754 decimal128.
+	defaultPrecision = 34
+	maxPrecision     = 10000
+	// maxOperandLength bounds the length of an operand, in characters.
+	maxOperandLength = 100000
+	// maxResultBits bounds the size of the results of POWER, about 315,000
+	// decimal digits, so that a request cannot make the server compute
+	// numbers of gigabytes.
+	maxResultBits = 1 << 20
+)
+
+// addInt32 adds two int32s, failing with OUT_OF_RANGE when the sum does not
+// fit.
+func addInt32(a, b int32) (int32, error) {
+	sum := int64(a) + int64(b)
+	if sum > math.MaxInt32 || sum < math.MinInt32 {
+		return 0, status.Errorf(codes.OutOfRange, "%d + %d overflows int32, use Calculate for big numbers", a, b)
+	}
+	return int32(sum), nil
+}
+
+// arity returns the number of operands op takes; -1 means at least one and
+// -2 at least two.
+func arity(op calculatorpb.CalculateRequest_Operation) int {
+	switch op {
+	case calculatorpb.CalculateRequest_ADD, calculatorpb.CalculateRequest_MULTIPLY:
+		return -1
+	case calculatorpb.CalculateRequest_SUBTRACT, calculatorpb.CalculateRequest_DIVIDE:
+		return -2
+	case calculatorpb.CalculateRequest_MODULO, calculatorpb.CalculateRequest_POWER:
+		return 2
+	default: // SQUARE_ROOT
+		return 1
+	}
+}
+
+var errFloatOverflow = status.Error(codes.OutOfRange, "the result overflows the exponent range of big floats")
+
+func invalidArgument(format string, a ...interface{}) error {
+	return status.Errorf(codes.InvalidArgument, format, a...)
+}
+
+// calculate performs the operation of req.
+func calculate(req *calculatorpb.CalculateRequest) (string, error) {
+	op := req.GetOperation()
+	if op == calculatorpb.CalculateRequest_OPERATION_UNSPECIFIED {
+		return "", invalidArgument("no operation given")
+	}
+	if _, ok := calculatorpb.CalculateRequest_Operation_name[int32(op)]; !ok {
+		return "", invalidArgument("unknown operation %d", op)
+	}
+	operands := req.GetOperands()
+	switch n := arity(op); {
+	case n == -1 && len(operands) < 1,
+		n == -2 && len(operands) < 2,
+		n > 0 && len(operands) != n:
+		return "", invalidArgument("%v takes %s, got %d", op, arityText(n), len(operands))
+	}
+	for i, s := range operands {
+		if len(s) > maxOperandLength {
+			return "", invalidArgument("operand %d is longer than %d characters", i, maxOperandLength)
+		}
+	}
+
+	switch req.GetMode() {
+	case calculatorpb.CalculateRequest_INTEGER:
+		return calculateInt(op, operands)
+	case calculatorpb.CalculateRequest_RATIONAL:
+		return calculateRat(op, operands)
+	case calculatorpb.CalculateRequest_FLOAT:
+		precision := req.GetPrecision()
+		if precision == 0 {
+			precision = defaultPrecision
+		}
+		if precision > maxPrecision {
+			return "", invalidArgument("precision %d is over the maximum of %d digits", precision, maxPrecision)
+		}
+		return calculateFloat(op, operands, int(precision))
+	default:
+		return "", invalidArgument("unknown mode %d", req.GetMode())
+	}
+}
+
+func arityText(n int) string {
+	switch n {
+	case -1:
+		return "at least one operand"
+	case -2:
+		return "at least two operands"
+	case 1:
+		return "one operand"
+	default:
+		return fmt.Sprintf("%d operands", n)
+	}
+}
+
+// exponent parses the exponent of POWER, which is an integer in every mode.
+func exponent(s string) (int64, error) {
+	e, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+	// MinInt64 has no opposite
+	if !ok || !e.IsInt64() || e.Int64() == math.MinInt64 {
+		return 0, invalidArgument("exponent %q is not an integer", s)
+	}
+	return e.Int64(), nil
+}
+
+// checkPowerSize fails when base^exp would have more than maxResultBits.
+func checkPowerSize(bits int, exp int64) error {
+	if exp < 0 {
+		exp = -exp
+	}
+	if bits > 1 && exp > int64(maxResultBits/bits) {
+		return status.Errorf(codes.OutOfRange, "the result of the power would have more than %d bits", maxResultBits)
+	}
+	return nil
+}
+
+func calculateInt(op calculatorpb.CalculateRequest_Operation, operands []string) (string, error) {
+	xs := make([]*big.Int, len(operands))
+	for i, s := range operands {
+		x, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+		if !ok {
+			return "", invalidArgument("operand %d: %q is not an integer", i, s)
+		}
+		xs[i] = x
+	}
+	z := new(big.Int).Set(xs[0])
+	switch op {
+	case calculatorpb.CalculateRequest_ADD:
+		for _, x := range xs[1:] {
+			z.Add(z, x)
+		}
+	case calculatorpb.CalculateRequest_SUBTRACT:
+		for _, x := range xs[1:] {
+			z.Sub(z, x)
+		}
+	case calculatorpb.CalculateRequest_MULTIPLY:
+		for _, x := range xs[1:] {
+			z.Mul(z, x)
+		}
+	case calculatorpb.CalculateRequest_DIVIDE, calculatorpb.CalculateRequest_MODULO:
+		for _, x := range xs[1:] {
+			if x.Sign() == 0 {
+				return "", invalidArgument("division by zero")
+			}
+			if op == calculatorpb.CalculateRequest_DIVIDE {
+				z.Div(z, x)
+			} else {
+				z.Mod(z, x)
+			}
+		}
+	case calculatorpb.CalculateRequest_POWER:
+		exp, err := exponent(operands[1])
+		if err != nil {
+			return "", err
+		}
+		if exp < 0 {
+			return "", invalidArgument("negative exponent %d in INTEGER mode, use RATIONAL", exp)
+		}
+		if err := checkPowerSize(z.BitLen(), exp); err != nil {
+			return "", err
+		}
+		z.Exp(z, big.NewInt(exp), nil)
+	case calculatorpb.CalculateRequest_SQUARE_ROOT:
+		if z.Sign() < 0 {
+			return "", invalidArgument("square root of a negative number: %v", z)
+		}
+		z.Sqrt(z)
+	}
+	return z.String(), nil
+}
+
+func calculateRat(op calculatorpb.CalculateRequest_Operation, operands []string) (string, error) {
+	if op == calculatorpb.CalculateRequest_MODULO || op == calculatorpb.CalculateRequest_SQUARE_ROOT {
+		return "", invalidArgument("%v is not supported in RATIONAL mode", op)
+	}
+	xs := make([]*big.Rat, len(operands))
+	for i, s := range operands {
+		x, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+		if !ok {
+			return "", invalidArgument("operand %d: %q is not a rational number", i, s)
+		}
+		// such as 1e9000000
+		if x.Num().BitLen()+x.Denom().BitLen() > maxResultBits {
+			return "", status.Errorf(codes.OutOfRange, "operand %d has more than %d bits", i, maxResultBits)
+		}
+		xs[i] = x
+	}
+	z := new(big.Rat).Set(xs[0])
+	switch op {
+	case calculatorpb.CalculateRequest_ADD:
+		for _, x := range xs[1:] {
+			z.Add(z, x)
+		}
+	case calculatorpb.CalculateRequest_SUBTRACT:
+		for _, x := range xs[1:] {
+			z.Sub(z, x)
+		}
+	case calculatorpb.CalculateRequest_MULTIPLY:
+		for _, x := range xs[1:] {
+			z.Mul(z, x)
+		}
+	case calculatorpb.CalculateRequest_DIVIDE:
+		for _, x := range xs[1:] {
+			if x.Sign() == 0 {
+				return "", invalidArgument("division by zero")
+			}
+			z.Quo(z, x)
+		}
+	case calculatorpb.CalculateRequest_POWER:
+		exp, err := exponent(operands[1])
+		if err != nil {
+			return "", err
+		}
+		if exp < 0 {
+			if z.Sign() == 0 {
+				return "", invalidArgument("division by zero")
+			}
+			z.Inv(z)
+		}
+		num, denom := z.Num(), z.Denom()
+		if err := checkPowerSize(num.BitLen()+denom.BitLen(), exp); err != nil {
+			return "", err
+		}
+		e := big.NewInt(exp)
+		e.Abs(e)
+		z.SetFrac(new(big.Int).Exp(num, e, nil), new(big.Int).Exp(denom, e, nil))
+	}
+	return z.RatString(), nil
+}
+
+// floatBits returns the mantissa bits needed for digits decimal digits, with
+// a few more as guard bits.
+func floatBits(digits int) uint {
+	return uint(math.Ceil(float64(digits)*math.Log2(10))) + 8
+}
+
+func calculateFloat(op calculatorpb.CalculateRequest_Operation, operands []string, digits int) (string, error) {
+	if op == calculatorpb.CalculateRequest_MODULO {
+		return "", invalidArgument("%v is not supported in FLOAT mode", op)
+	}
+	prec := floatBits(digits)
+	xs := make([]*big.Float, len(operands))
+	for i, s := range operands {
+		x, _, err := big.ParseFloat(strings.TrimSpace(s), 10, prec, big.ToNearestEven)
+		if err != nil {
+			return "", invalidArgument("operand %d: %q is not a number", i, s)
+		}
+		if x.IsInf() {
+			return "", invalidArgument("operand %d: %q is not finite", i, s)
+		}
+		xs[i] = x
+	}
+	z := new(big.Float).SetPrec(prec).Set(xs[0])
+	// an infinite intermediate result would make the next operation panic
+	// on Inf - Inf or Inf * 0, so each step is checked
+	fold := func(f func(z, x *big.Float) *big.Float) error {
+		for _, x := range xs[1:] {
+			if f(z, x); z.IsInf() {
+				return errFloatOverflow
+			}
+		}
+		return nil
+	}
+	var err error
+	switch op {
+	case calculatorpb.CalculateRequest_ADD:
+		err = fold(func(z, x *big.Float) *big.Float { return z.Add(z, x) })
+	case calculatorpb.CalculateRequest_SUBTRACT:
+		err = fold(func(z, x *big.Float) *big.Float { return z.Sub(z, x) })
+	case calculatorpb.CalculateRequest_MULTIPLY:
+		err = fold(func(z, x *big.Float) *big.Float { return z.Mul(z, x) })
+	case calculatorpb.CalculateRequest_DIVIDE:
+		for _, x := range xs[1:] {
+			if x.Sign() == 0 {
+				return "", invalidArgument("division by zero")
+			}
+		}
+		err = fold(func(z, x *big.Float) *big.Float { return z.Quo(z, x) })
+	case calculatorpb.CalculateRequest_POWER:
+		exp, expErr := exponent(operands[1])
+		if expErr != nil {
+			return "", expErr
+		}
+		if exp < 0 && z.Sign() == 0 {
+			return "", invalidArgument("division by zero")
+		}
+		z = powFloat(z, exp)
+	case calculatorpb.CalculateRequest_SQUARE_ROOT:
+		if z.Sign() < 0 {
+			return "", invalidArgument("square root of a negative number: %v", operands[0])
+		}
+		z.Sqrt(z)
+	}
+	if err != nil {
+		return "", err
+	}
+	if z.IsInf() {
+		return "", errFloatOverflow
+	}
+	return z.Text('g', digits), nil
+}
+
+// powFloat raises x to an integer power by repeated squaring, at the
+// precision of x.
+func powFloat(x *big.Float, exp int64) *big.Float {
+	prec := x.Prec()
+	neg := exp < 0
+	if neg {
+		exp = -exp
+	}
+	z := new(big.Float).SetPrec(prec).SetInt64(1)
+	sq := new(big.Float).SetPrec(prec).Set(x)
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			z.Mul(z, sq)
+		}
+		if sq.Mul(sq, sq); sq.IsInf() {
+			if exp > 1 {
+				// the remaining factors are all infinite
+				z.SetInf(false)
+			}
+			break
+		}
+	}
+	if neg {
+		z.Quo(new(big.Float).SetPrec(prec).SetInt64(1), z)
+	}
+	return z
+}
@@ -0,0 +1,444 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// exprError is an error in an expression, at a position counted in
+// characters from 1.
+type exprError struct {
+	pos int
+	msg string
+}
+
+func (e *exprError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.pos, e.msg)
+}
+
+func errorAt(pos int, format string, a ...interface{}) error {
+	return &exprError{pos: pos, msg: fmt.Sprintf(format, a...)}
+}
+
+// tokens
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp // + - * / % ^ ( ) ,
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	rs := []rune(s)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case unicode.IsDigit(r) || r == '.':
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || rs[i] == '.') {
+				i++
+			}
+			// exponent, as in 1.5e-3
+			if i < len(rs) && (rs[i] == 'e' || rs[i] == 'E') {
+				j := i + 1
+				if j < len(rs) && (rs[j] == '+' || rs[j] == '-') {
+					j++
+				}
+				if j < len(rs) && unicode.IsDigit(rs[j]) {
+					for i = j; i < len(rs) && unicode.IsDigit(rs[i]); i++ {
+					}
+				}
+			}
+			tokens = append(tokens, token{tokNumber, string(rs[start:i]), start + 1})
+		case unicode.IsLetter(r) || r == '_':
+			for i < len(rs) && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{tokIdent, string(rs[start:i]), start + 1})
+		case strings.ContainsRune("+-*/%^(),", r):
+			i++
+			tokens = append(tokens, token{tokOp, string(r), start + 1})
+		default:
+			return nil, errorAt(start+1, "unexpected character %q", r)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(rs) + 1}), nil
+}
+
+// syntax tree
+
+type node interface {
+	eval(vars map[string]float64) (float64, error)
+}
+
+type numberNode float64
+
+func (n numberNode) eval(map[string]float64) (float64, error) {
+	return float64(n), nil
+}
+
+type variableNode struct {
+	name string
+	pos  int
+}
+
+func (n *variableNode) eval(vars map[string]float64) (float64, error) {
+	if v, ok := vars[n.name]; ok {
+		return v, nil
+	}
+	if v, ok := constants[n.name]; ok {
+		return v, nil
+	}
+	return 0, errorAt(n.pos, "undefined variable %q", n.name)
+}
+
+type unaryNode struct {
+	op rune
+	x  node
+}
+
+func (n *unaryNode) eval(vars map[string]float64) (float64, error) {
+	x, err := n.x.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	if n.op == '-' {
+		return -x, nil
+	}
+	return x, nil
+}
+
+type binaryNode struct {
+	op   rune
+	pos  int
+	x, y node
+}
+
+func (n *binaryNode) eval(vars map[string]float64) (float64, error) {
+	x, err := n.x.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	y, err := n.y.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case '+':
+		return x + y, nil
+	case '-':
+		return x - y, nil
+	case '*':
+		return x * y, nil
+	case '/':
+		if y == 0 {
+			return 0, errorAt(n.pos, "division by zero")
+		}
+		return x / y, nil
+	case '%':
+		if y == 0 {
+			return 0, errorAt(n.pos, "division by zero")
+		}
+		return math.Mod(x, y), nil
+	default: // '^'
+		return checkDomain(n.pos, "^", math.Pow(x, y))
+	}
+}
+
+type callNode struct {
+	name string
+	pos  int
+	fn   function
+	args []node
+}
+
+func (n *callNode) eval(vars map[string]float64) (float64, error) {
+	args := make([]float64, len(n.args))
+	for i, a := range n.args {
+		v, err := a.eval(vars)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = v
+	}
+	return checkDomain(n.pos, n.name, n.fn.call(args))
+}
+
+// checkDomain turns the NaN produced by an argument out of the domain of a
+// function, such as sqrt(-1), into an error.
+func checkDomain(pos int, name string, v float64) (float64, error) {
+	if math.IsNaN(v) {
+		return 0, errorAt(pos, "%s: argument out of domain", name)
+	}
+	return v, nil
+}
+
+var constants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+type function struct {
+	arity int
+	call  func(args []float64) float64
+}
+
+func unary(f func(float64) float64) function {
+	return function{1, func(a []float64) float64 { return f(a[0]) }}
+}
+
+func binary(f func(float64, float64) float64) function {
+	return function{2, func(a []float64) float64 { return f(a[0], a[1]) }}
+}
+
+var functions = map[string]function{
+	"sqrt":  unary(math.Sqrt),
+	"cbrt":  unary(math.Cbrt),
+	"abs":   unary(math.Abs),
+	"exp":   unary(math.Exp),
+	"log":   unary(math.Log),
+	"log2":  unary(math.Log2),
+	"log10": unary(math.Log10),
+	"sin":   unary(math.Sin),
+	"cos":   unary(math.Cos),
+	"tan":   unary(math.Tan),
+	"asin":  unary(math.Asin),
+	"acos":  unary(math.Acos),
+	"atan":  unary(math.Atan),
+	"sinh":  unary(math.Sinh),
+	"cosh":  unary(math.Cosh),
+	"tanh":  unary(math.Tanh),
+	"floor": unary(math.Floor),
+	"ceil":  unary(math.Ceil),
+	"round": unary(math.Round),
+	"pow":   binary(math.Pow),
+	"atan2": binary(math.Atan2),
+	"min":   binary(math.Min),
+	"max":   binary(math.Max),
+}
+
+// parser
+
+const (
+	// maxExpressionLength bounds the expressions, in characters, and so the
+	// size of their syntax trees, which are evaluated recursively.
+	maxExpressionLength = 10000
+	// maxExpressionDepth bounds the nesting of parentheses, signs and powers,
+	// each of which the parser handles with a recursive call.
+	maxExpressionDepth = 256
+)
+
+// parser is a recursive descent parser of the grammar
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/" | "%") unary }
+//	unary   = ("+" | "-") unary | power
+//	power   = primary [ "^" unary ]
+//	primary = number | name | name "(" [ expr { "," expr } ] ")" | "(" expr ")"
+//
+// so that ^ binds tighter than a leading minus and is right associative:
+// -2^2 is -4 and 2^3^2 is 512.
+type parser struct {
+	tokens []token
+	next   int
+	depth  int
+}
+
+// parseExpr parses an expression.
+func parseExpr(s string) (node, error) {
+	if utf8.RuneCountInString(s) > maxExpressionLength {
+		return nil, errorAt(maxExpressionLength+1, "the expression is longer than %d characters", maxExpressionLength)
+	}
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	n, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, errorAt(t.pos, "unexpected %v", t)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.next]
+	if t.kind != tokEOF {
+		p.next++
+	}
+	return t
+}
+
+// accept consumes the next token if it is one of the operators in ops.
+func (p *parser) accept(ops string) (token, bool) {
+	t := p.peek()
+	if t.kind == tokOp && strings.Contains(ops, t.text) {
+		return p.advance(), true
+	}
+	return t, false
+}
+
+func (p *parser) expect(op string) error {
+	if t, ok := p.accept(op); !ok {
+		return errorAt(t.pos, "expected %q, found %v", op, t)
+	}
+	return nil
+}
+
+func (p *parser) expr() (node, error) {
+	x, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.accept("+-")
+		if !ok {
+			return x, nil
+		}
+		y, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryNode{op: rune(t.text[0]), pos: t.pos, x: x, y: y}
+	}
+}
+
+func (p *parser) term() (node, error) {
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.accept("*/%")
+		if !ok {
+			return x, nil
+		}
+		y, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryNode{op: rune(t.text[0]), pos: t.pos, x: x, y: y}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	// every level of nesting goes through here, the expression itself
+	// being the first one
+	if p.depth++; p.depth > maxExpressionDepth+1 {
+		// at the token that opened the last level
+		return nil, errorAt(p.tokens[p.next-1].pos, "the expression is nested more than %d levels deep", maxExpressionDepth)
+	}
+	defer func() { p.depth-- }()
+	if t, ok := p.accept("+-"); ok {
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: rune(t.text[0]), x: x}, nil
+	}
+	return p.power()
+}
+
+func (p *parser) power() (node, error) {
+	x, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	t, ok := p.accept("^")
+	if !ok {
+		return x, nil
+	}
+	y, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	return &binaryNode{op: '^', pos: t.pos, x: x, y: y}, nil
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.advance()
+	switch {
+	case t.kind == tokNumber:
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, errorAt(t.pos, "invalid number %q", t.text)
+		}
+		return numberNode(v), nil
+	case t.kind == tokIdent:
+		if _, ok := p.accept("("); !ok {
+			return &variableNode{name: t.text, pos: t.pos}, nil
+		}
+		return p.call(t)
+	case t.kind == tokOp && t.text == "(":
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return x, nil
+	default:
+		return nil, errorAt(t.pos, "unexpected %v", t)
+	}
+}
+
+// call parses the arguments of a call to the function named by t, whose
+// opening parenthesis has been consumed.
+func (p *parser) call(t token) (node, error) {
+	fn, ok := functions[t.text]
+	if !ok {
+		return nil, errorAt(t.pos, "unknown function %q", t.text)
+	}
+	var args []node
+	if _, ok := p.accept(")"); !ok {
+		for {
+			a, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, a)
+			if _, ok := p.accept(","); !ok {
+				break
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+	if len(args) != fn.arity {
+		return nil, errorAt(t.pos, "%s takes %d argument(s), got %d", t.text, fn.arity, len(args))
+	}
+	return &callNode{name: t.text, pos: t.pos, fn: fn, args: args}, nil
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"calculator/calculatorpb"
+	"context"
+	"fmt"
+	"math"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxMatrixDimension bounds the rows and columns of a matrix, so that the
+// cubic algorithms stay under a second.
+const maxMatrixDimension = 500
+
+// singularTolerance is the size of a pivot of the equilibrated matrix, whose
+// rows and columns have their largest values between 1/2 and 1, under which
+// the matrix is taken as singular: rounding errors leave tiny pivots where
+// exact arithmetic would find zeros.
+const singularTolerance = 1e-12
+
+// matrix is a dense matrix stored row after row.
+type matrix struct {
+	rows, cols int
+	data       []float64
+}
+
+func newMatrix(rows, cols int) *matrix {
+	return &matrix{rows: rows, cols: cols, data: make([]float64, rows*cols)}
+}
+
+func (m *matrix) at(i, j int) float64 {
+	return m.data[i*m.cols+j]
+}
+
+func (m *matrix) set(i, j int, v float64) {
+	m.data[i*m.cols+j] = v
+}
+
+func (m *matrix) proto() *calculatorpb.Matrix {
+	return &calculatorpb.Matrix{Rows: uint32(m.rows), Columns: uint32(m.cols), Values: m.data}
+}
+
+// matrixFromProto checks a matrix of a request; name says which in errors.
+func matrixFromProto(name string, pb *calculatorpb.Matrix) (*matrix, error) {
+	if pb == nil {
+		return nil, invalidArgument("%s: missing", name)
+	}
+	rows, cols := int(pb.GetRows()), int(pb.GetColumns())
+	if rows == 0 || cols == 0 {
+		return nil, invalidArgument("%s: a matrix has at least one row and one column, got %d x %d", name, rows, cols)
+	}
+	if rows > maxMatrixDimension || cols > maxMatrixDimension {
+		return nil, invalidArgument("%s: %d x %d is larger than %d x %d", name, rows, cols, maxMatrixDimension, maxMatrixDimension)
+	}
+	if len(pb.GetValues()) != rows*cols {
+		return nil, invalidArgument("%s: a %d x %d matrix has %d values, got %d", name, rows, cols, rows*cols, len(pb.GetValues()))
+	}
+	if err := checkFinite(name, pb.GetValues()); err != nil {
+		return nil, err
+	}
+	return &matrix{rows: rows, cols: cols, data: append([]float64(nil), pb.GetValues()...)}, nil
+}
+
+func checkFinite(name string, values []float64) error {
+	for i, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return invalidArgument("%s: value %d is %v", name, i, v)
+		}
+	}
+	return nil
+}
+
+func squareFromProto(name string, pb *calculatorpb.Matrix) (*matrix, error) {
+	m, err := matrixFromProto(name, pb)
+	if err != nil {
+		return nil, err
+	}
+	if m.rows != m.cols {
+		return nil, invalidArgument("%s: a %d x %d matrix is not square", name, m.rows, m.cols)
+	}
+	return m, nil
+}
+
+func multiply(a, b *matrix) *matrix {
+	p := newMatrix(a.rows, b.cols)
+	for i := 0; i < a.rows; i++ {
+		for k := 0; k < a.cols; k++ {
+			aik := a.at(i, k)
+			for j := 0; j < b.cols; j++ {
+				p.data[i*p.cols+j] += aik * b.at(k, j)
+			}
+		}
+	}
+	return p
+}
+
+func transpose(m *matrix) *matrix {
+	t := newMatrix(m.cols, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			t.set(j, i, m.at(i, j))
+		}
+	}
+	return t
+}
+
+// lu is the LU decomposition with partial pivoting of a square matrix A,
+// equilibrated first so that the size of its pivots does not depend on the
+// scale of its rows and columns: B = R·A·C, where R and C are diagonal
+// matrices of powers of two, which scale without rounding, and P·B = L·U,
+// stored in a single matrix with the unit diagonal of L left out.
+type lu struct {
+	m    *matrix
+	perm []int
+	// rowExp and colExp are the exponents of the diagonals of R and C
+	rowExp, colExp []int
+	// sign is the sign of the permutation, -1 for an odd number of swaps
+	sign float64
+	// singular is set when a pivot is under singularTolerance
+	singular bool
+}
+
+// maxExp returns the binary exponent of the largest absolute value of vs:
+// it is between 2^(e-1) and 2^e.
+func maxExp(vs ...float64) int {
+	max := 0.0
+	for _, v := range vs {
+		max = math.Max(max, math.Abs(v))
+	}
+	_, e := math.Frexp(max)
+	return e
+}
+
+func luDecompose(a *matrix) *lu {
+	n := a.rows
+	m := &matrix{rows: n, cols: n, data: append([]float64(nil), a.data...)}
+	d := &lu{m: m, perm: make([]int, n), rowExp: make([]int, n), colExp: make([]int, n), sign: 1}
+	for i := 0; i < n; i++ {
+		d.perm[i] = i
+		row := m.data[i*n : (i+1)*n]
+		d.rowExp[i] = -maxExp(row...)
+		for j := range row {
+			row[j] = math.Ldexp(row[j], d.rowExp[i])
+		}
+	}
+	col := make([]float64, n)
+	for j := 0; j < n; j++ {
+		for i := range col {
+			col[i] = m.at(i, j)
+		}
+		d.colExp[j] = -maxExp(col...)
+		for i := range col {
+			m.set(i, j, math.Ldexp(col[i], d.colExp[j]))
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		// the largest pivot of the column limits the growth of errors
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(m.at(i, k)) > math.Abs(m.at(p, k)) {
+				p = i
+			}
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				a, b := m.at(k, j), m.at(p, j)
+				m.set(k, j, b)
+				m.set(p, j, a)
+			}
+			d.perm[k], d.perm[p] = d.perm[p], d.perm[k]
+			d.sign = -d.sign
+		}
+		pivot := m.at(k, k)
+		if math.Abs(pivot) <= singularTolerance {
+			d.singular = true
+		}
+		if pivot == 0 {
+			// the rest of the column is zero already
+			continue
+		}
+		for i := k + 1; i < n; i++ {
+			f := m.at(i, k) / pivot
+			m.set(i, k, f)
+			for j := k + 1; j < n; j++ {
+				m.set(i, j, m.at(i, j)-f*m.at(k, j))
+			}
+		}
+	}
+	return d
+}
+
+// determinant returns the product of the pivots, unscaled. It is 0 only when
+// a pivot is.
+func (d *lu) determinant() float64 {
+	// the exponent is kept apart so that the product of the pivots does not
+	// underflow or overflow before it is unscaled
+	frac, exp := d.sign, 0
+	for i := 0; i < d.m.rows; i++ {
+		f, e := math.Frexp(frac * d.m.at(i, i))
+		frac, exp = f, exp+e-d.rowExp[i]-d.colExp[i]
+	}
+	return math.Ldexp(frac, exp)
+}
+
+// solve solves A·x = b, that is B·y = R·b with x = C·y, by forward then back
+// substitution.
+func (d *lu) solve(b []float64) []float64 {
+	n := d.m.rows
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		v := math.Ldexp(b[d.perm[i]], d.rowExp[d.perm[i]])
+		for j := 0; j < i; j++ {
+			v -= d.m.at(i, j) * x[j]
+		}
+		x[i] = v
+	}
+	for i := n - 1; i >= 0; i-- {
+		v := x[i]
+		for j := i + 1; j < n; j++ {
+			v -= d.m.at(i, j) * x[j]
+		}
+		x[i] = v / d.m.at(i, i)
+	}
+	for i := range x {
+		x[i] = math.Ldexp(x[i], d.colExp[i])
+	}
+	return x
+}
+
+var errSingular = status.Error(codes.FailedPrecondition, "the matrix is singular")
+
+func (*server) MultiplyMatrices(ctx context.Context, req *calculatorpb.MultiplyMatricesRequest) (*calculatorpb.MultiplyMatricesResponse, error) {
+	fmt.Println("Received MultiplyMatrices RPC")
+	return multiplyMatrices(req)
+}
+
+func multiplyMatrices(req *calculatorpb.MultiplyMatricesRequest) (*calculatorpb.MultiplyMatricesResponse, error) {
+	left, err := matrixFromProto("left", req.GetLeft())
+	if err != nil {
+		return nil, err
+	}
+	switch right := req.GetRight().(type) {
+	case *calculatorpb.MultiplyMatricesRequest_Matrix:
+		m, err := matrixFromProto("right", right.Matrix)
+		if err != nil {
+			return nil, err
+		}
+		if left.cols != m.rows {
+			return nil, invalidArgument("cannot multiply a %d x %d matrix by a %d x %d one", left.rows, left.cols, m.rows, m.cols)
+		}
+		return &calculatorpb.MultiplyMatricesResponse{
+			Result: &calculatorpb.MultiplyMatricesResponse_Matrix{Matrix: multiply(left, m).proto()},
+		}, nil
+	case *calculatorpb.MultiplyMatricesRequest_Vector:
+		values := right.Vector.GetValues()
+		if err := checkFinite("vector", values); err != nil {
+			return nil, err
+		}
+		if left.cols != len(values) {
+			return nil, invalidArgument("cannot multiply a %d x %d matrix by a vector of %d values", left.rows, left.cols, len(values))
+		}
+		column := &matrix{rows: len(values), cols: 1, data: values}
+		return &calculatorpb.MultiplyMatricesResponse{
+			Result: &calculatorpb.MultiplyMatricesResponse_Vector{Vector: &calculatorpb.Vector{Values: multiply(left, column).data}},
+		}, nil
+	default:
+		return nil, invalidArgument("right: missing")
+	}
+}
+
+func (*server) TransposeMatrix(ctx context.Context, req *calculatorpb.MatrixRequest) (*calculatorpb.MatrixResponse, error) {
+	fmt.Println("Received TransposeMatrix RPC")
+	return transposeMatrix(req)
+}
+
+func transposeMatrix(req *calculatorpb.MatrixRequest) (*calculatorpb.MatrixResponse, error) {
+	m, err := matrixFromProto("matrix", req.GetMatrix())
+	if err != nil {
+		return nil, err
+	}
+	return &calculatorpb.MatrixResponse{Matrix: transpose(m).proto()}, nil
+}
+
+func (*server) Determinant(ctx context.Context, req *calculatorpb.MatrixRequest) (*calculatorpb.DeterminantResponse, error) {
+	fmt.Println("Received Determinant RPC")
+	return determinant(req)
+}
+
+func determinant(req *calculatorpb.MatrixRequest) (*calculatorpb.DeterminantResponse, error) {
+	m, err := squareFromProto("matrix", req.GetMatrix())
+	if err != nil {
+		return nil, err
+	}
+	return &calculatorpb.DeterminantResponse{Determinant: luDecompose(m).determinant()}, nil
+}
+
+func (*server) InvertMatrix(ctx context.Context, req *calculatorpb.MatrixRequest) (*calculatorpb.MatrixResponse, error) {
+	fmt.Println("Received InvertMatrix RPC")
+	return invertMatrix(req)
+}
+
+func invertMatrix(req *calculatorpb.MatrixRequest) (*calculatorpb.MatrixResponse, error) {
+	m, err := squareFromProto("matrix", req.GetMatrix())
+	if err != nil {
+		return nil, err
+	}
+	d := luDecompose(m)
+	if d.singular {
+		return nil, errSingular
+	}
+	// the columns of the inverse solve A·x = e_j
+	n := m.rows
+	inv := newMatrix(n, n)
+	e := make([]float64, n)
+	for j := 0; j < n; j++ {
+		e[j] = 1
+		for i, v := range d.solve(e) {
+			inv.set(i, j, v)
+		}
+		e[j] = 0
+	}
+	return &calculatorpb.MatrixResponse{Matrix: inv.proto()}, nil
+}
+
+func (*server) SolveLinearSystem(ctx context.Context, req *calculatorpb.SolveLinearSystemRequest) (*calculatorpb.SolveLinearSystemResponse, error) {
+	fmt.Println("Received SolveLinearSystem RPC")
+	return solveLinearSystem(req)
+}
+
+func solveLinearSystem(req *calculatorpb.SolveLinearSystemRequest) (*calculatorpb.SolveLinearSystemResponse, error) {
+	m, err := squareFromProto("coefficients", req.GetCoefficients())
+	if err != nil {
+		return nil, err
+	}
+	b := req.GetConstants().GetValues()
+	if err := checkFinite("constants", b); err != nil {
+		return nil, err
+	}
+	if len(b) != m.rows {
+		return nil, invalidArgument("a system of %d equations needs %d constants, got %d", m.rows, m.rows, len(b))
+	}
+	d := luDecompose(m)
+	if d.singular {
+		return nil, status.Error(codes.FailedPrecondition, "the coefficient matrix is singular: the system has no solution or infinitely many")
+	}
+	return &calculatorpb.SolveLinearSystemResponse{Solution: &calculatorpb.Vector{Values: d.solve(b)}}, nil
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"calculator/calculatorpb"
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxIntegerDigits bounds the integers of the number theory RPCs: a
+	// modular exponentiation or a primality test of that size takes
+	// milliseconds.
+	maxIntegerDigits = 1000
+	// maxIntegers bounds the integers of GreatestCommonDivisor and
+	// LeastCommonMultiple.
+	maxIntegers = 1000
+	// maxPrimesLimit bounds the range of GeneratePrimes, so that the primes
+	// sieving it, up to its square root, are few.
+	maxPrimesLimit = 1000000000000
+	// sieveSegment is the number of integers sieved at once, whose primes
+	// make a message of GeneratePrimes.
+	sieveSegment = 1 << 16
+)
+
+// parseInteger parses a decimal integer of a request; name says which in
+// errors.
+func parseInteger(name, s string) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+	if len(strings.TrimLeft(s, "+-")) > maxIntegerDigits {
+		return nil, invalidArgument("%s has more than %d digits", name, maxIntegerDigits)
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, invalidArgument("%s: %q is not an integer", name, s)
+	}
+	return n, nil
+}
+
+func parseIntegers(numbers []string) ([]*big.Int, error) {
+	if len(numbers) == 0 {
+		return nil, invalidArgument("no numbers given")
+	}
+	if len(numbers) > maxIntegers {
+		return nil, invalidArgument("%d numbers at most, got %d", maxIntegers, len(numbers))
+	}
+	xs := make([]*big.Int, len(numbers))
+	for i, s := range numbers {
+		x, err := parseInteger(fmt.Sprintf("number %d", i), s)
+		if err != nil {
+			return nil, err
+		}
+		xs[i] = x
+	}
+	return xs, nil
+}
+
+func (*server) GreatestCommonDivisor(ctx context.Context, req *calculatorpb.IntegersRequest) (*calculatorpb.IntegerResponse, error) {
+	fmt.Printf("Received GreatestCommonDivisor RPC: %v\n", req)
+	return greatestCommonDivisor(req)
+}
+
+func greatestCommonDivisor(req *calculatorpb.IntegersRequest) (*calculatorpb.IntegerResponse, error) {
+	xs, err := parseIntegers(req.GetNumbers())
+	if err != nil {
+		return nil, err
+	}
+	z := new(big.Int).Abs(xs[0])
+	for _, x := range xs[1:] {
+		z.GCD(nil, nil, z, x)
+	}
+	return &calculatorpb.IntegerResponse{Result: z.String()}, nil
+}
+
+func (*server) LeastCommonMultiple(ctx context.Context, req *calculatorpb.IntegersRequest) (*calculatorpb.IntegerResponse, error) {
+	fmt.Printf("Received LeastCommonMultiple RPC: %v\n", req)
+	return leastCommonMultiple(req)
+}
+
+func leastCommonMultiple(req *calculatorpb.IntegersRequest) (*calculatorpb.IntegerResponse, error) {
+	xs, err := parseIntegers(req.GetNumbers())
+	if err != nil {
+		return nil, err
+	}
+	z := new(big.Int).Abs(xs[0])
+	g := new(big.Int)
+	for _, x := range xs[1:] {
+		if z.Sign() == 0 || x.Sign() == 0 {
+			z.SetInt64(0)
+			break
+		}
+		// lcm(z, x) = z / gcd(z, x) * |x|
+		g.GCD(nil, nil, z, x)
+		z.Quo(z, g)
+		z.Mul(z, g.Abs(x))
+		if z.BitLen() > maxResultBits {
+			return nil, status.Errorf(codes.OutOfRange, "the least common multiple has more than %d bits", maxResultBits)
+		}
+	}
+	return &calculatorpb.IntegerResponse{Result: z.String()}, nil
+}
+
+// parseModulus parses a modulus, which is positive.
+func parseModulus(s string) (*big.Int, error) {
+	m, err := parseInteger("modulus", s)
+	if err != nil {
+		return nil, err
+	}
+	if m.Sign() <= 0 {
+		return nil, invalidArgument("the modulus must be positive, got %v", m)
+	}
+	return m, nil
+}
+
+func (*server) ModularExponentiation(ctx context.Context, req *calculatorpb.ModularExponentiationRequest) (*calculatorpb.IntegerResponse, error) {
+	fmt.Printf("Received ModularExponentiation RPC: %v\n", req)
+	return modularExponentiation(req)
+}
+
+func modularExponentiation(req *calculatorpb.ModularExponentiationRequest) (*calculatorpb.IntegerResponse, error) {
+	base, err := parseInteger("base", req.GetBase())
+	if err != nil {
+		return nil, err
+	}
+	exp, err := parseInteger("exponent", req.GetExponent())
+	if err != nil {
+		return nil, err
+	}
+	m, err := parseModulus(req.GetModulus())
+	if err != nil {
+		return nil, err
+	}
+	// a negative exponent is that of the modular inverse of the base
+	z := new(big.Int).Exp(base, exp, m)
+	if z == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v has no inverse modulo %v, so no negative power", base, m)
+	}
+	return &calculatorpb.IntegerResponse{Result: z.String()}, nil
+}
+
+func (*server) ModularInverse(ctx context.Context, req *calculatorpb.ModularInverseRequest) (*calculatorpb.IntegerResponse, error) {
+	fmt.Printf("Received ModularInverse RPC: %v\n", req)
+	return modularInverse(req)
+}
+
+func modularInverse(req *calculatorpb.ModularInverseRequest) (*calculatorpb.IntegerResponse, error) {
+	n, err := parseInteger("number", req.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+	m, err := parseModulus(req.GetModulus())
+	if err != nil {
+		return nil, err
+	}
+	if m.Cmp(bigOne) == 0 {
+		return &calculatorpb.IntegerResponse{Result: "0"}, nil
+	}
+	z := new(big.Int).ModInverse(n, m)
+	if z == nil {
+		g := new(big.Int).GCD(nil, nil, n, m)
+		return nil, status.Errorf(codes.FailedPrecondition, "%v has no inverse modulo %v: their greatest common divisor is %v", n, m, g)
+	}
+	return &calculatorpb.IntegerResponse{Result: z.String()}, nil
+}
+
+// certainPrimeLimit is the bound under which ProbablyPrime is exact.
+var certainPrimeLimit = new(big.Int).Lsh(bigOne, 64)
+
+func (*server) IsPrime(ctx context.Context, req *calculatorpb.IsPrimeRequest) (*calculatorpb.IsPrimeResponse, error) {
+	fmt.Printf("Received IsPrime RPC: %v\n", req)
+	return primality(req)
+}
+
+func primality(req *calculatorpb.IsPrimeRequest) (*calculatorpb.IsPrimeResponse, error) {
+	n, err := parseInteger("number", req.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+	if n.Sign() < 0 {
+		return &calculatorpb.IsPrimeResponse{Prime: false, Certain: true}, nil
+	}
+	return &calculatorpb.IsPrimeResponse{
+		Prime:   isPrime(n),
+		Certain: n.Cmp(certainPrimeLimit) < 0,
+	}, nil
+}
+
+// sievePrimes calls emit with the primes between from and to, both
+// included, one segment of the segmented sieve of Eratosthenes after the
+// other. It stops with the context's error when ctx is done.
+func sievePrimes(ctx context.Context, from, to uint64, emit func([]uint64) error) error {
+	if from < 2 {
+		from = 2
+	}
+	base := primesUpTo(int(math.Sqrt(float64(to))) + 1)
+	composite := make([]bool, sieveSegment)
+	for lo := from; lo <= to; lo += sieveSegment {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hi := lo + sieveSegment - 1
+		if hi > to {
+			hi = to
+		}
+		seg := composite[:hi-lo+1]
+		for i := range seg {
+			seg[i] = false
+		}
+		for _, bp := range base {
+			p := uint64(bp)
+			if p*p > hi {
+				break
+			}
+			// the multiples of p below p² have a smaller factor
+			start := (lo + p - 1) / p * p
+			if start < p*p {
+				start = p * p
+			}
+			for j := start; j <= hi; j += p {
+				seg[j-lo] = true
+			}
+		}
+		var primes []uint64
+		for i, c := range seg {
+			if !c {
+				primes = append(primes, lo+uint64(i))
+			}
+		}
+		if len(primes) > 0 {
+			if err := emit(primes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (*server) GeneratePrimes(req *calculatorpb.GeneratePrimesRequest, stream calculatorpb.CalculatorService_GeneratePrimesServer) error {
+	fmt.Printf("Received GeneratePrimes RPC: %v\n", req)
+	from, to := req.GetFrom(), req.GetTo()
+	if from > to {
+		return invalidArgument("the range %d to %d is empty", from, to)
+	}
+	if to > maxPrimesLimit {
+		return invalidArgument("the range must end by %d, got %d", maxPrimesLimit, to)
+	}
+	ctx := stream.Context()
+	// Send blocks while the client does not read, which holds the sieve back
+	err := sievePrimes(ctx, from, to, func(primes []uint64) error {
+		return stream.Send(&calculatorpb.GeneratePrimesResponse{Primes: primes})
+	})
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	return err
+}
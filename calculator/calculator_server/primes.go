@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+)
+
+const (
+	// maxFactorDigits bounds the numbers given to PrimeNumberDecomposition.
+	// Pollard's rho finds small factors of much larger numbers quickly, but
+	// the hard cases of this size would already run for ages; the server's
+	// time budget, or an earlier client deadline, is what stops them.
+	maxFactorDigits = 1000
+	// defaultFactorTimeout is the time budget of a PrimeNumberDecomposition
+	// call when the server sets none.
+	defaultFactorTimeout = 10 * time.Second
+	// millerRabinRounds is the number of random bases tried by
+	// ProbablyPrime, on top of its Baillie-PSW test.
+	millerRabinRounds = 20
+	// rhoBatch is the number of steps of Pollard's rho between two gcds,
+	// and between two checks of the context.
+	rhoBatch = 128
+)
+
+var (
+	bigOne = big.NewInt(1)
+	// smallPrimes are divided out before Pollard's rho, which is slow to
+	// find them in comparison.
+	smallPrimes = primesUpTo(1000)
+)
+
+// primesUpTo returns the primes up to n with the sieve of Eratosthenes.
+func primesUpTo(n int) []int64 {
+	composite := make([]bool, n+1)
+	var primes []int64
+	for i := 2; i <= n; i++ {
+		if composite[i] {
+			continue
+		}
+		primes = append(primes, int64(i))
+		for j := i * i; j <= n; j += i {
+			composite[j] = true
+		}
+	}
+	return primes
+}
+
+// isPrime tests n with Miller-Rabin.
+func isPrime(n *big.Int) bool {
+	return n.ProbablyPrime(millerRabinRounds)
+}
+
+// factorize returns the prime factors of n > 0 in increasing order, with
+// multiplicity. It gives up with the context's error when ctx is done.
+func factorize(ctx context.Context, n *big.Int) ([]*big.Int, error) {
+	n = new(big.Int).Set(n)
+	var factors []*big.Int
+	q, r := new(big.Int), new(big.Int)
+	for _, p := range smallPrimes {
+		bp := big.NewInt(p)
+		for {
+			q.QuoRem(n, bp, r)
+			if r.Sign() != 0 {
+				break
+			}
+			factors = append(factors, bp)
+			n.Set(q)
+		}
+	}
+	// what is left has no factor below 1000
+	rest, err := split(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	factors = append(factors, rest...)
+	sort.Slice(factors, func(i, j int) bool { return factors[i].Cmp(factors[j]) < 0 })
+	return factors, nil
+}
+
+// split returns the prime factors of n, in no particular order.
+func split(ctx context.Context, n *big.Int) ([]*big.Int, error) {
+	if n.Cmp(bigOne) == 0 {
+		return nil, nil
+	}
+	if isPrime(n) {
+		return []*big.Int{n}, nil
+	}
+	d, err := rho(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	left, err := split(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	right, err := split(ctx, new(big.Int).Quo(n, d))
+	if err != nil {
+		return nil, err
+	}
+	return append(left, right...), nil
+}
+
+// rho returns a non-trivial divisor of the composite n, with Brent's
+// variant of Pollard's rho: the sequence x -> x² + c mod n cycles modulo
+// every prime factor p of n after about √p steps, and the gcd of n with the
+// differences of its terms reveals the cycle.
+func rho(ctx context.Context, n *big.Int) (*big.Int, error) {
+	x, y, ys, q, diff, d := new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+	step := func(z, c *big.Int) {
+		z.Mul(z, z)
+		z.Add(z, c)
+		z.Mod(z, n)
+	}
+	// a sequence that cycles modulo n itself finds nothing; try another
+	for c := int64(1); ; c++ {
+		bc := big.NewInt(c)
+		y.SetInt64(2)
+		q.SetInt64(1)
+		d.SetInt64(1)
+		for r := 1; d.Cmp(bigOne) == 0; r *= 2 {
+			x.Set(y)
+			for i := 0; i < r; i++ {
+				if i%rhoBatch == 0 {
+					if err := ctx.Err(); err != nil {
+						return nil, err
+					}
+				}
+				step(y, bc)
+			}
+			for k := 0; k < r && d.Cmp(bigOne) == 0; k += rhoBatch {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+				ys.Set(y)
+				for i := 0; i < rhoBatch && i < r-k; i++ {
+					step(y, bc)
+					diff.Sub(x, y)
+					q.Mul(q, diff.Abs(diff))
+					q.Mod(q, n)
+				}
+				d.GCD(nil, nil, q, n)
+			}
+		}
+		if d.Cmp(n) == 0 {
+			// the batch overshot: redo its steps one gcd at a time
+			for {
+				step(ys, bc)
+				diff.Sub(x, ys)
+				d.GCD(nil, nil, diff.Abs(diff), n)
+				if d.Cmp(bigOne) != 0 {
+					break
+				}
+			}
+		}
+		if d.Cmp(n) != 0 {
+			return new(big.Int).Set(d), nil
+		}
+	}
+}
@@ -2,47 +2,100 @@ package main
 
 import (
 	"calculator/calculatorpb"
+	"common/auth"
+	"common/cache"
+	"common/fault"
+	"common/ratelimit"
+	"common/tracing"
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"math"
+	"math/big"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"time"
 
+	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
-type server struct{}
+type server struct {
+	// batchWorkers is the number of operations of a BatchCompute call
+	// performed in parallel, the number of CPUs when zero
+	batchWorkers int
+	// factorTimeout bounds the time PrimeNumberDecomposition spends
+	// factoring, defaultFactorTimeout when zero
+	factorTimeout time.Duration
+}
 
 func (*server) Sum(ctx context.Context, req *calculatorpb.SumRequest) (*calculatorpb.SumResponse, error) {
 	fmt.Printf("Received Sum RPC: %v\n", req)
+	return sum(req)
+}
+
+func sum(req *calculatorpb.SumRequest) (*calculatorpb.SumResponse, error) {
 	firstNumber := req.FirstNumber
 	secondNumber := req.SecondNumber
-	sum := firstNumber + secondNumber
+	sum, err := addInt32(firstNumber, secondNumber)
+	if err != nil {
+		return nil, err
+	}
 	res := &calculatorpb.SumResponse{
 		SumResult: sum,
 	}
 	return res, nil
 }
 
-func (*server) PrimeNumberDecomposition(req *calculatorpb.PrimeNumberDecompositionRequest, stream calculatorpb.CalculatorService_PrimeNumberDecompositionServer) error {
+func (s *server) PrimeNumberDecomposition(req *calculatorpb.PrimeNumberDecompositionRequest, stream calculatorpb.CalculatorService_PrimeNumberDecompositionServer) error {
 	fmt.Printf("Received PrimeNumberDecomposition RPC: %v\n", req)
 
-	number := req.GetNumber()
-	divisor := int64(2)
+	number := big.NewInt(req.GetNumber())
+	if s := req.GetBigNumber(); s != "" {
+		if len(s) > maxFactorDigits {
+			return status.Errorf(codes.InvalidArgument, "big_number has more than %d digits", maxFactorDigits)
+		}
+		if _, ok := number.SetString(s, 10); !ok {
+			return status.Errorf(codes.InvalidArgument, "big_number %q is not an integer", s)
+		}
+	}
+	if number.Sign() <= 0 {
+		return status.Errorf(codes.InvalidArgument, "only positive numbers have a prime decomposition, got %v", number)
+	}
 
-	for number > 1 {
-		if number%divisor == 0 {
-			stream.Send(&calculatorpb.PrimeNumberDecompositionResponse{
-				PrimeFactor: divisor,
-			})
-			number = number / divisor
-		} else {
-			divisor++
-			fmt.Printf("Divisor has increased to %v\n", divisor)
+	timeout := s.factorTimeout
+	if timeout <= 0 {
+		timeout = defaultFactorTimeout
+	}
+	ctx, cancel := context.WithTimeout(stream.Context(), timeout)
+	defer cancel()
+	factors, err := factorize(ctx, number)
+	if err == context.DeadlineExceeded && stream.Context().Err() == nil {
+		return status.Errorf(codes.DeadlineExceeded, "the number could not be factored within %v", timeout)
+	}
+	if err != nil {
+		return status.FromContextError(err).Err()
+	}
+	for _, f := range factors {
+		res := &calculatorpb.PrimeNumberDecompositionResponse{
+			BigPrimeFactor: f.String(),
+		}
+		if f.IsInt64() {
+			res.PrimeFactor = f.Int64()
+		}
+		if err := stream.Send(res); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -51,29 +104,75 @@ func (*server) PrimeNumberDecomposition(req *calculatorpb.PrimeNumberDecompositi
 func (*server) ComputeAverage(stream calculatorpb.CalculatorService_ComputeAverageServer) error {
 	fmt.Printf("Received ComputeAverage RPC\n")
 
-	sum := int32(0)
+	// an int64 sum cannot overflow before 2^32 numbers have been received
+	sum := int64(0)
 	count := 0
 
 	for {
 		req, err := stream.Recv()
 		if err == io.EOF {
+			if count == 0 {
+				return status.Error(codes.InvalidArgument, "the average of no numbers is undefined")
+			}
 			average := float64(sum) / float64(count)
 			return stream.SendAndClose(&calculatorpb.ComputeAverageResponse{
 				Average: average,
 			})
 		}
 		if err != nil {
-			log.Fatalf("Error while reading client stream: %v", err)
+			log.Printf("Error while reading client stream: %v", err)
+			return err
 		}
-		sum += req.GetNumber()
+		sum += int64(req.GetNumber())
 		count++
 	}
+}
 
+func (*server) ComputeStatistics(stream calculatorpb.CalculatorService_ComputeStatisticsServer) error {
+	fmt.Printf("Received ComputeStatistics RPC\n")
+
+	s := newSummary()
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Printf("Error while reading client stream: %v", err)
+			return err
+		}
+		number := req.GetNumber()
+		if math.IsNaN(number) || math.IsInf(number, 0) {
+			return status.Errorf(codes.InvalidArgument, "number %d is %v", s.count+1, number)
+		}
+		s.add(number)
+	}
+	if s.count == 0 {
+		return status.Error(codes.InvalidArgument, "the statistics of no numbers are undefined")
+	}
+
+	res := &calculatorpb.ComputeStatisticsResponse{
+		Count:    s.count,
+		Sum:      s.sum,
+		Mean:     s.mean,
+		Variance: s.variance(),
+		Stddev:   math.Sqrt(s.variance()),
+		Min:      s.min,
+		Max:      s.max,
+	}
+	for i, v := range s.percentiles(reportedPercentiles) {
+		res.Percentiles = append(res.Percentiles, &calculatorpb.ComputeStatisticsResponse_Percentile{
+			Rank:  reportedPercentiles[i],
+			Value: v,
+		})
+	}
+	return stream.SendAndClose(res)
 }
 
 func (*server) FindMaximum(stream calculatorpb.CalculatorService_FindMaximumServer) error {
 	fmt.Println("Received FindMaximum RPC")
-	maximum := int32(0)
+	// the first number is the first maximum, whatever its sign
+	maximum, found := int32(0), false
 
 	for {
 		req, err := stream.Recv()
@@ -81,17 +180,17 @@ func (*server) FindMaximum(stream calculatorpb.CalculatorService_FindMaximumServ
 			return nil
 		}
 		if err != nil {
-			log.Fatalf("Error while reading client stream: %v", err)
+			log.Printf("Error while reading client stream: %v", err)
 			return err
 		}
 		number := req.GetNumber()
-		if number > maximum {
-			maximum = number
+		if !found || number > maximum {
+			maximum, found = number, true
 			sendErr := stream.Send(&calculatorpb.FindMaximumResponse{
 				Maximum: maximum,
 			})
 			if sendErr != nil {
-				log.Fatalf("Error while sending data to client: %v", sendErr)
+				log.Printf("Error while sending data to client: %v", sendErr)
 				return sendErr
 			}
 		}
@@ -100,6 +199,10 @@ func (*server) FindMaximum(stream calculatorpb.CalculatorService_FindMaximumServ
 
 func (*server) SquareRoot(ctx context.Context, req *calculatorpb.SquareRootRequest) (*calculatorpb.SquareRootResponse, error) {
 	fmt.Println("Received SquareRoot RPC")
+	return squareRoot(req)
+}
+
+func squareRoot(req *calculatorpb.SquareRootRequest) (*calculatorpb.SquareRootResponse, error) {
 	number := req.GetNumber()
 	if number < 0 {
 		return nil, status.Errorf(
@@ -112,21 +215,180 @@ func (*server) SquareRoot(ctx context.Context, req *calculatorpb.SquareRootReque
 	}, nil
 }
 
+func (*server) Evaluate(ctx context.Context, req *calculatorpb.EvaluateRequest) (*calculatorpb.EvaluateResponse, error) {
+	fmt.Printf("Received Evaluate RPC: %v\n", req)
+	return evaluate(req)
+}
+
+func evaluate(req *calculatorpb.EvaluateRequest) (*calculatorpb.EvaluateResponse, error) {
+	expr, err := parseExpr(req.GetExpression())
+	if err != nil {
+		return nil, invalidExpression(err)
+	}
+	result, err := expr.eval(req.GetVariables())
+	if err != nil {
+		return nil, invalidExpression(err)
+	}
+	return &calculatorpb.EvaluateResponse{
+		Result: result,
+	}, nil
+}
+
+func (*server) Calculate(ctx context.Context, req *calculatorpb.CalculateRequest) (*calculatorpb.CalculateResponse, error) {
+	fmt.Printf("Received Calculate RPC: %v %v\n", req.GetMode(), req.GetOperation())
+	result, err := calculate(req)
+	if err != nil {
+		return nil, err
+	}
+	return &calculatorpb.CalculateResponse{
+		Result: result,
+	}, nil
+}
+
+// invalidExpression reports an error in the expression of an Evaluate
+// request, with a BadRequest detail pointing at the expression field.
+func invalidExpression(err error) error {
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("invalid expression: %v", err))
+	detailed, detailsErr := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "expression", Description: err.Error()},
+		},
+	})
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
 func main() {
+	traceCfg := tracing.Config{ServiceName: "calculator_server"}
+	traceCfg.RegisterFlags(flag.CommandLine)
+	var authCfg auth.Config
+	authCfg.RegisterFlags(flag.CommandLine)
+	var rlCfg ratelimit.Config
+	rlCfg.RegisterFlags(flag.CommandLine)
+	var faultCfg fault.Config
+	faultCfg.RegisterFlags(flag.CommandLine)
+	// every RPC of the service only depends on its request
+	cacheCfg := cache.Config{Methods: []string{"/calculator.CalculatorService/*"}, BypassField: "no_cache"}
+	cacheCfg.RegisterFlags(flag.CommandLine)
+	metricsAddr := flag.String("metrics-addr", "", "address serving Prometheus metrics on /metrics, such as 0.0.0.0:50052; none when empty")
+	enableReflection := flag.Bool("reflection", true, "register the server reflection service used by Evans and grpcurl")
+	batchWorkers := flag.Int("batch-workers", runtime.NumCPU(), "number of operations of a BatchCompute call performed in parallel")
+	factorTimeout := flag.Duration("factor-timeout", defaultFactorTimeout, "time a PrimeNumberDecomposition call may spend factoring")
+	flag.Parse()
+	if *batchWorkers < 1 {
+		log.Fatalf("-batch-workers must be at least 1, got %d", *batchWorkers)
+	}
+	if *factorTimeout <= 0 {
+		log.Fatalf("-factor-timeout must be positive, got %v", *factorTimeout)
+	}
+	if err := rlCfg.Load(); err != nil {
+		log.Fatalf("Failed to set up rate limiting: %v", err)
+	}
+	rlCfg.Calls = batchCalls
+	if err := faultCfg.Load(); err != nil {
+		log.Fatalf("Failed to set up fault injection: %v", err)
+	}
+
 	fmt.Println("Calculator Server")
 
+	tp, err := tracing.Init(context.Background(), traceCfg)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+
 	lis, err := net.Listen("tcp", "0.0.0.0:50051")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
-	calculatorpb.RegisterCalculatorServiceServer(s, &server{})
+	recoveryFunc := func(p interface{}) (err error) {
+		return status.Errorf(codes.Unknown, "panic triggered: %v", p)
+	}
+	recoveryOpts := []grpc_recovery.Option{
+		grpc_recovery.WithRecoveryHandler(recoveryFunc),
+	}
+
+	traceUnary, traceStream := tracing.ServerInterceptors()
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		traceUnary,
+		grpc_recovery.UnaryServerInterceptor(recoveryOpts...),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		traceStream,
+		grpc_recovery.StreamServerInterceptor(recoveryOpts...),
+	}
+	if authCfg.Enabled() {
+		validator, err := auth.NewValidator(authCfg)
+		if err != nil {
+			log.Fatalf("Failed to set up authentication: %v", err)
+		}
+		unaryInterceptors = append(unaryInterceptors, validator.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, validator.StreamServerInterceptor())
+	} else {
+		fmt.Println("Authentication is disabled")
+	}
+	if rlCfg.Enabled() {
+		// after authentication, so that limits apply per authenticated caller
+		limiter := ratelimit.New(rlCfg)
+		unaryInterceptors = append(unaryInterceptors, limiter.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, limiter.StreamServerInterceptor())
+	}
+	var responses *cache.Cache
+	if cacheCfg.Enabled() {
+		// after authentication and rate limiting, which hits must not skip
+		responses = cache.New(cacheCfg)
+		prometheus.MustRegister(responses.Collector())
+		unaryInterceptors = append(unaryInterceptors, responses.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, responses.StreamServerInterceptor())
+	}
+	if faultCfg.Enabled() {
+		// last, so that faults only hit calls that would have been handled,
+		// and injected panics are turned into errors by the recovery interceptor
+		fmt.Println("Fault injection is enabled")
+		injector := fault.New(faultCfg)
+		unaryInterceptors = append(unaryInterceptors, injector.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, injector.StreamServerInterceptor())
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+	calculatorpb.RegisterCalculatorServiceServer(s, &server{batchWorkers: *batchWorkers, factorTimeout: *factorTimeout})
 
 	// Register reflection service on gRPC server.
-	reflection.Register(s)
+	if *enableReflection {
+		reflection.Register(s)
+	}
+
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+	}()
 
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	if *metricsAddr != "" {
+		go func() {
+			if err := http.ListenAndServe(*metricsAddr, promhttp.Handler()); err != nil {
+				log.Printf("Failed to serve metrics: %v", err)
+			}
+		}()
+	}
+
+	// Wait for Control C to exit
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt)
+	<-ch
+
+	fmt.Println("Stopping the server")
+	s.GracefulStop()
+	if responses != nil {
+		st := responses.Stats()
+		fmt.Printf("Cache: %d hits, %d misses, hit rate %.1f%%\n", st.Hits, st.Misses, 100*st.HitRate())
+	}
+	// flush the spans that are still buffered
+	if err := tp.Shutdown(context.Background()); err != nil {
+		log.Printf("Error while shutting down tracing: %v", err)
 	}
 }
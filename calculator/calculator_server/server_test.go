@@ -0,0 +1,1542 @@
+package main
+
+import (
+	"calculator/calculatorpb"
+	"common/cache"
+	"common/grpctest"
+	"common/ratelimit"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func newClient(t *testing.T) calculatorpb.CalculatorServiceClient {
+	cc := grpctest.NewServer(t, func(s *grpc.Server) {
+		calculatorpb.RegisterCalculatorServiceServer(s, &server{})
+	})
+	return calculatorpb.NewCalculatorServiceClient(cc)
+}
+
+func TestSum(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		first, second, want int32
+	}{
+		{3, 10, 13},
+		{-5, 5, 0},
+		{0, 0, 0},
+	}
+	for _, tt := range tests {
+		res, err := c.Sum(context.Background(), &calculatorpb.SumRequest{FirstNumber: tt.first, SecondNumber: tt.second})
+		if err != nil {
+			t.Fatalf("Sum(%d, %d): %v", tt.first, tt.second, err)
+		}
+		if res.GetSumResult() != tt.want {
+			t.Errorf("Sum(%d, %d) = %d, want %d", tt.first, tt.second, res.GetSumResult(), tt.want)
+		}
+	}
+}
+
+func TestSumOverflow(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		first, second int32
+	}{
+		{math.MaxInt32, 1},
+		{math.MinInt32, -1},
+		{math.MaxInt32, math.MaxInt32},
+	}
+	for _, tt := range tests {
+		_, err := c.Sum(context.Background(), &calculatorpb.SumRequest{FirstNumber: tt.first, SecondNumber: tt.second})
+		if status.Code(err) != codes.OutOfRange {
+			t.Errorf("Sum(%d, %d): got %v, want OutOfRange", tt.first, tt.second, err)
+		}
+	}
+}
+
+func TestSumDeadlineExceeded(t *testing.T) {
+	c := newClient(t)
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, err := c.Sum(ctx, &calculatorpb.SumRequest{FirstNumber: 1, SecondNumber: 2})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("got %v, want DeadlineExceeded", err)
+	}
+}
+
+// decompose returns the factors streamed by PrimeNumberDecomposition, in
+// decimal.
+func decompose(ctx context.Context, c calculatorpb.CalculatorServiceClient, req *calculatorpb.PrimeNumberDecompositionRequest) ([]string, error) {
+	stream, err := c.PrimeNumberDecomposition(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var got []string
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return got, nil
+		}
+		if err != nil {
+			return got, err
+		}
+		if res.GetPrimeFactor() != 0 && strconv.FormatInt(res.GetPrimeFactor(), 10) != res.GetBigPrimeFactor() {
+			return got, fmt.Errorf("prime_factor %d differs from big_prime_factor %s", res.GetPrimeFactor(), res.GetBigPrimeFactor())
+		}
+		got = append(got, res.GetBigPrimeFactor())
+	}
+}
+
+func TestPrimeNumberDecomposition(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		number int64
+		want   []string
+	}{
+		{120, []string{"2", "2", "2", "3", "5"}},
+		{97, []string{"97"}},
+		{1, nil},
+		{210, []string{"2", "3", "5", "7"}},
+		{600851475143, []string{"71", "839", "1471", "6857"}},
+		// the largest int64 prime, and the square of a 31 bit prime
+		{9223372036854775783, []string{"9223372036854775783"}},
+		{4611686014132420609, []string{"2147483647", "2147483647"}},
+	}
+	for _, tt := range tests {
+		got, err := decompose(context.Background(), c, &calculatorpb.PrimeNumberDecompositionRequest{Number: tt.number})
+		if err != nil {
+			t.Fatalf("PrimeNumberDecomposition(%d): %v", tt.number, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PrimeNumberDecomposition(%d) = %v, want %v", tt.number, got, tt.want)
+		}
+	}
+}
+
+func TestPrimeNumberDecompositionBig(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		number string
+		want   []string
+	}{
+		// (2^61 - 1)(2^31 - 1)(10^9 + 7)
+		{"4951760189497999172085065914647235079", []string{"1000000007", "2147483647", "2305843009213693951"}},
+		// 2^127 - 1 is prime
+		{"170141183460469231731687303715884105727", []string{"170141183460469231731687303715884105727"}},
+		{"1000000000000000000000", []string{"2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2", "2",
+			"5", "5", "5", "5", "5", "5", "5", "5", "5", "5", "5", "5", "5", "5", "5", "5", "5", "5", "5", "5", "5"}},
+	}
+	for _, tt := range tests {
+		got, err := decompose(context.Background(), c, &calculatorpb.PrimeNumberDecompositionRequest{BigNumber: tt.number})
+		if err != nil {
+			t.Fatalf("PrimeNumberDecomposition(%s): %v", tt.number, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PrimeNumberDecomposition(%s) = %v, want %v", tt.number, got, tt.want)
+		}
+	}
+}
+
+func TestPrimeNumberDecompositionInvalid(t *testing.T) {
+	c := newClient(t)
+	tests := []*calculatorpb.PrimeNumberDecompositionRequest{
+		{Number: 0},
+		{Number: -12},
+		{BigNumber: "-5"},
+		{BigNumber: "12abc"},
+		{BigNumber: strings.Repeat("9", 1001)},
+	}
+	for _, req := range tests {
+		if _, err := decompose(context.Background(), c, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("PrimeNumberDecomposition(%v): got %v, want InvalidArgument", req, err)
+		}
+	}
+}
+
+func TestPrimeNumberDecompositionDeadline(t *testing.T) {
+	c := newClient(t)
+	// a product of two large primes is out of reach of Pollard's rho
+	p := new(big.Int).Lsh(big.NewInt(1), 127)
+	p.Sub(p, big.NewInt(1))
+	q := new(big.Int).Lsh(big.NewInt(1), 89)
+	q.Sub(q, big.NewInt(1))
+	n := new(big.Int).Mul(p, q)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := decompose(ctx, c, &calculatorpb.PrimeNumberDecompositionRequest{BigNumber: n.String()})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("got %v, want DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("took %v", d)
+	}
+}
+
+func TestPrimeNumberDecompositionTimeout(t *testing.T) {
+	cc := grpctest.NewServer(t, func(s *grpc.Server) {
+		calculatorpb.RegisterCalculatorServiceServer(s, &server{factorTimeout: 100 * time.Millisecond})
+	})
+	c := calculatorpb.NewCalculatorServiceClient(cc)
+	// (2^127 - 1)(2^89 - 1), out of reach of Pollard's rho
+	p := new(big.Int).Lsh(big.NewInt(1), 127)
+	p.Sub(p, big.NewInt(1))
+	q := new(big.Int).Lsh(big.NewInt(1), 89)
+	q.Sub(q, big.NewInt(1))
+	n := new(big.Int).Mul(p, q)
+
+	// without a deadline of the client, the server's budget stops the call
+	start := time.Now()
+	_, err := decompose(context.Background(), c, &calculatorpb.PrimeNumberDecompositionRequest{BigNumber: n.String()})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("got %v, want DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("took %v", d)
+	}
+	// numbers that factor within the budget are not affected
+	got, err := decompose(context.Background(), c, &calculatorpb.PrimeNumberDecompositionRequest{Number: 600851475143})
+	if want := []string{"71", "839", "1471", "6857"}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("PrimeNumberDecomposition(600851475143) = %v, %v; want %v", got, err, want)
+	}
+}
+
+func TestComputeAverage(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		numbers []int32
+		want    float64
+	}{
+		{[]int32{1, 2, 3, 4}, 2.5},
+		{[]int32{3, 5, 9, 54, 23}, 18.8},
+		{[]int32{-4}, -4},
+	}
+	for _, tt := range tests {
+		stream, err := c.ComputeAverage(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, n := range tt.numbers {
+			if err := stream.Send(&calculatorpb.ComputeAverageRequest{Number: n}); err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+		}
+		res, err := stream.CloseAndRecv()
+		if err != nil {
+			t.Fatalf("ComputeAverage(%v): %v", tt.numbers, err)
+		}
+		if math.Abs(res.GetAverage()-tt.want) > 1e-9 {
+			t.Errorf("ComputeAverage(%v) = %v, want %v", tt.numbers, res.GetAverage(), tt.want)
+		}
+	}
+}
+
+func TestComputeAverageEmpty(t *testing.T) {
+	c := newClient(t)
+	stream, err := c.ComputeAverage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.CloseAndRecv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("got %v, want InvalidArgument", err)
+	}
+}
+
+func TestComputeAverageCanceled(t *testing.T) {
+	c := newClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.ComputeAverage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&calculatorpb.ComputeAverageRequest{Number: 3}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	cancel()
+	if _, err := stream.CloseAndRecv(); status.Code(err) != codes.Canceled {
+		t.Errorf("got %v, want Canceled", err)
+	}
+	// the server is still up
+	if _, err := c.Sum(context.Background(), &calculatorpb.SumRequest{FirstNumber: 1, SecondNumber: 2}); err != nil {
+		t.Errorf("Sum after cancel: %v", err)
+	}
+}
+
+// computeStatistics streams numbers to ComputeStatistics.
+func computeStatistics(c calculatorpb.CalculatorServiceClient, numbers []float64) (*calculatorpb.ComputeStatisticsResponse, error) {
+	stream, err := c.ComputeStatistics(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range numbers {
+		if err := stream.Send(&calculatorpb.ComputeStatisticsRequest{Number: n}); err != nil {
+			// the server has failed the call, CloseAndRecv says why
+			break
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+func TestComputeStatistics(t *testing.T) {
+	c := newClient(t)
+	res, err := computeStatistics(c, []float64{2, 4, 4, 4, 5, 5, 7, 9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []float64{float64(res.GetCount()), res.GetSum(), res.GetMean(), res.GetVariance(), res.GetStddev(), res.GetMin(), res.GetMax()}
+	want := []float64{8, 40, 5, 32.0 / 7, math.Sqrt(32.0 / 7), 2, 9}
+	names := []string{"count", "sum", "mean", "variance", "stddev", "min", "max"}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("%s = %v, want %v", names[i], got[i], want[i])
+		}
+	}
+	wantPercentiles := map[float64]float64{25: 4, 50: 4.5, 75: 5.5, 90: 7.6, 95: 8.3, 99: 8.86}
+	if len(res.GetPercentiles()) != len(wantPercentiles) {
+		t.Errorf("got %d percentiles, want %d", len(res.GetPercentiles()), len(wantPercentiles))
+	}
+	for _, p := range res.GetPercentiles() {
+		if want := wantPercentiles[p.GetRank()]; math.Abs(p.GetValue()-want) > 1e-9 {
+			t.Errorf("percentile %v = %v, want %v", p.GetRank(), p.GetValue(), want)
+		}
+	}
+}
+
+func TestComputeStatisticsLargeStream(t *testing.T) {
+	c := newClient(t)
+	// 0, 1, ..., n - 1 in a shuffled order, more than the reservoir holds
+	const n = 20000
+	numbers := make([]float64, n)
+	for i, j := range rand.Perm(n) {
+		numbers[i] = float64(j)
+	}
+	res, err := computeStatistics(c, numbers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetCount() != n || res.GetMin() != 0 || res.GetMax() != n-1 || math.Abs(res.GetMean()-(n-1)/2.0) > 1e-6 {
+		t.Errorf("count %d, min %v, max %v, mean %v", res.GetCount(), res.GetMin(), res.GetMax(), res.GetMean())
+	}
+	// the percentiles are estimated from a sample of 4096 numbers
+	for _, p := range res.GetPercentiles() {
+		want := p.GetRank() / 100 * (n - 1)
+		if math.Abs(p.GetValue()-want) > 0.05*n {
+			t.Errorf("percentile %v = %v, want about %v", p.GetRank(), p.GetValue(), want)
+		}
+	}
+}
+
+func TestComputeStatisticsInvalid(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		name    string
+		numbers []float64
+	}{
+		{"empty stream", nil},
+		{"NaN", []float64{1, math.NaN()}},
+		{"infinite", []float64{math.Inf(-1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := computeStatistics(c, tt.numbers); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("got %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestFindMaximum(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		numbers []int32
+		// the maximums sent back, one per new maximum
+		want []int32
+	}{
+		{[]int32{4, 7, 2, 19, 4, 6, 32}, []int32{4, 7, 19, 32}},
+		{[]int32{5, 5, 5}, []int32{5}},
+		{[]int32{-7, -9, -3, 0}, []int32{-7, -3, 0}},
+		{nil, nil},
+	}
+	for _, tt := range tests {
+		stream, err := c.FindMaximum(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, n := range tt.numbers {
+			if err := stream.Send(&calculatorpb.FindMaximumRequest{Number: n}); err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+		}
+		if err := stream.CloseSend(); err != nil {
+			t.Fatalf("CloseSend: %v", err)
+		}
+		var got []int32
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("FindMaximum(%v): %v", tt.numbers, err)
+			}
+			got = append(got, res.GetMaximum())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FindMaximum(%v) = %v, want %v", tt.numbers, got, tt.want)
+		}
+	}
+}
+
+// aggregateStream runs StreamAggregate with cfg over numbers, sending them
+// at interval, and returns the results.
+func aggregateStream(t *testing.T, c calculatorpb.CalculatorServiceClient, cfg *calculatorpb.StreamAggregateRequest_Config, numbers []float64, interval time.Duration) ([]*calculatorpb.StreamAggregateResponse, error) {
+	t.Helper()
+	stream, err := c.StreamAggregate(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		stream.Send(&calculatorpb.StreamAggregateRequest{Message: &calculatorpb.StreamAggregateRequest_Config_{Config: cfg}})
+		for _, n := range numbers {
+			time.Sleep(interval)
+			if err := stream.Send(&calculatorpb.StreamAggregateRequest{Message: &calculatorpb.StreamAggregateRequest_Number{Number: n}}); err != nil {
+				return
+			}
+		}
+		stream.CloseSend()
+	}()
+	var results []*calculatorpb.StreamAggregateResponse
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return results, err
+		}
+		results = append(results, res)
+	}
+}
+
+func TestStreamAggregateByCount(t *testing.T) {
+	c := newClient(t)
+	numbers := []float64{3, 1, 4, 1, 5, 9, 2}
+	type window struct {
+		value      float64
+		start, end int64
+		partial    bool
+	}
+	tests := []struct {
+		name   string
+		agg    calculatorpb.StreamAggregateRequest_Aggregation
+		window *calculatorpb.Window
+		want   []window
+	}{
+		{"tumbling sum", calculatorpb.StreamAggregateRequest_SUM, &calculatorpb.Window{Size: 3},
+			[]window{{8, 0, 3, false}, {15, 3, 6, false}, {2, 6, 7, true}}},
+		{"tumbling max", calculatorpb.StreamAggregateRequest_MAX, &calculatorpb.Window{Size: 7},
+			[]window{{9, 0, 7, false}}},
+		{"sliding min", calculatorpb.StreamAggregateRequest_MIN, &calculatorpb.Window{Type: calculatorpb.Window_SLIDING, Size: 3, Slide: 2},
+			[]window{{1, 0, 3, false}, {1, 2, 5, false}, {2, 4, 7, false}, {2, 6, 7, true}}},
+		{"sliding mean", calculatorpb.StreamAggregateRequest_MEAN, &calculatorpb.Window{Type: calculatorpb.Window_SLIDING, Size: 2, Slide: 1},
+			[]window{{2, 0, 2, false}, {2.5, 1, 3, false}, {2.5, 2, 4, false}, {3, 3, 5, false}, {7, 4, 6, false}, {5.5, 5, 7, false}, {2, 6, 7, true}}},
+		{"count", calculatorpb.StreamAggregateRequest_COUNT, &calculatorpb.Window{Size: 10},
+			[]window{{7, 0, 7, true}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &calculatorpb.StreamAggregateRequest_Config{Aggregation: tt.agg, Window: tt.window}
+			results, err := aggregateStream(t, c, cfg, numbers, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []window
+			for _, r := range results {
+				got = append(got, window{r.GetValue(), r.GetStartIndex(), r.GetEndIndex(), r.GetPartial()})
+				if r.GetCount() != r.GetEndIndex()-r.GetStartIndex() {
+					t.Errorf("count %d of window [%d, %d)", r.GetCount(), r.GetStartIndex(), r.GetEndIndex())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamAggregateByTime(t *testing.T) {
+	c := newClient(t)
+	cfg := &calculatorpb.StreamAggregateRequest_Config{
+		Aggregation: calculatorpb.StreamAggregateRequest_COUNT,
+		Window:      &calculatorpb.Window{Duration: durationpb.New(100 * time.Millisecond)},
+	}
+	// about 5 numbers per window, over 3 windows or so
+	results, err := aggregateStream(t, c, cfg, make([]float64, 15), 20*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total int64
+	for i, r := range results {
+		total += r.GetCount()
+		if d := r.GetEndTime().AsTime().Sub(r.GetStartTime().AsTime()); d != 100*time.Millisecond {
+			t.Errorf("window %d lasts %v", i, d)
+		}
+		if i > 0 && !r.GetStartTime().AsTime().Equal(results[i-1].GetEndTime().AsTime()) {
+			t.Errorf("window %d does not follow the previous one", i)
+		}
+		if r.GetPartial() != (i == len(results)-1) {
+			t.Errorf("window %d partial = %v", i, r.GetPartial())
+		}
+	}
+	if total != 15 || len(results) < 2 {
+		t.Errorf("%d numbers in %d windows, want 15 in several", total, len(results))
+	}
+}
+
+func TestStreamAggregateInvalid(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		name string
+		cfg  *calculatorpb.StreamAggregateRequest_Config
+	}{
+		{"no aggregation", &calculatorpb.StreamAggregateRequest_Config{Window: &calculatorpb.Window{Size: 3}}},
+		{"no window", &calculatorpb.StreamAggregateRequest_Config{Aggregation: calculatorpb.StreamAggregateRequest_SUM}},
+		{"count and time", &calculatorpb.StreamAggregateRequest_Config{Aggregation: calculatorpb.StreamAggregateRequest_SUM,
+			Window: &calculatorpb.Window{Size: 3, Duration: durationpb.New(time.Second)}}},
+		{"slide over size", &calculatorpb.StreamAggregateRequest_Config{Aggregation: calculatorpb.StreamAggregateRequest_SUM,
+			Window: &calculatorpb.Window{Type: calculatorpb.Window_SLIDING, Size: 3, Slide: 4}}},
+		{"too short", &calculatorpb.StreamAggregateRequest_Config{Aggregation: calculatorpb.StreamAggregateRequest_SUM,
+			Window: &calculatorpb.Window{Duration: durationpb.New(time.Millisecond)}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := aggregateStream(t, c, tt.cfg, []float64{1}, 0); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("got %v, want InvalidArgument", err)
+			}
+		})
+	}
+
+	// numbers before the config
+	stream, err := c.StreamAggregate(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Send(&calculatorpb.StreamAggregateRequest{Message: &calculatorpb.StreamAggregateRequest_Number{Number: 1}})
+	if _, err := stream.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("number first: got %v, want InvalidArgument", err)
+	}
+}
+
+func TestStreamAggregateCanceled(t *testing.T) {
+	c := newClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.StreamAggregate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &calculatorpb.StreamAggregateRequest_Config{
+		Aggregation: calculatorpb.StreamAggregateRequest_SUM,
+		Window:      &calculatorpb.Window{Duration: durationpb.New(time.Hour)},
+	}
+	stream.Send(&calculatorpb.StreamAggregateRequest{Message: &calculatorpb.StreamAggregateRequest_Config_{Config: cfg}})
+	stream.Send(&calculatorpb.StreamAggregateRequest{Message: &calculatorpb.StreamAggregateRequest_Number{Number: 1}})
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Errorf("got %v, want Canceled", err)
+	}
+	// the server is still up
+	if _, err := c.Sum(context.Background(), &calculatorpb.SumRequest{FirstNumber: 1, SecondNumber: 2}); err != nil {
+		t.Errorf("Sum after cancel: %v", err)
+	}
+}
+
+func mat(rows, cols uint32, values ...float64) *calculatorpb.Matrix {
+	return &calculatorpb.Matrix{Rows: rows, Columns: cols, Values: values}
+}
+
+// approxEqual compares slices of numbers up to rounding errors.
+func approxEqual(got, want []float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMatrices(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+	a := mat(2, 3, 1, 2, 3, 4, 5, 6)
+
+	product, err := c.MultiplyMatrices(ctx, &calculatorpb.MultiplyMatricesRequest{
+		Left:  a,
+		Right: &calculatorpb.MultiplyMatricesRequest_Matrix{Matrix: mat(3, 2, 7, 8, 9, 10, 11, 12)},
+	})
+	if err != nil {
+		t.Fatalf("MultiplyMatrices: %v", err)
+	}
+	if m := product.GetMatrix(); m.GetRows() != 2 || m.GetColumns() != 2 || !approxEqual(m.GetValues(), []float64{58, 64, 139, 154}) {
+		t.Errorf("MultiplyMatrices = %v", m)
+	}
+
+	product, err = c.MultiplyMatrices(ctx, &calculatorpb.MultiplyMatricesRequest{
+		Left:  a,
+		Right: &calculatorpb.MultiplyMatricesRequest_Vector{Vector: &calculatorpb.Vector{Values: []float64{1, 0, -1}}},
+	})
+	if err != nil {
+		t.Fatalf("MultiplyMatrices by a vector: %v", err)
+	}
+	if v := product.GetVector().GetValues(); !approxEqual(v, []float64{-2, -2}) {
+		t.Errorf("MultiplyMatrices by a vector = %v", v)
+	}
+
+	transposed, err := c.TransposeMatrix(ctx, &calculatorpb.MatrixRequest{Matrix: a})
+	if err != nil {
+		t.Fatalf("TransposeMatrix: %v", err)
+	}
+	if m := transposed.GetMatrix(); m.GetRows() != 3 || m.GetColumns() != 2 || !approxEqual(m.GetValues(), []float64{1, 4, 2, 5, 3, 6}) {
+		t.Errorf("TransposeMatrix = %v", m)
+	}
+
+	dets := []struct {
+		m    *calculatorpb.Matrix
+		want float64
+	}{
+		{mat(1, 1, -3), -3},
+		{mat(2, 2, 1, 2, 3, 4), -2},
+		// a row swap is needed for the first pivot
+		{mat(3, 3, 0, 2, 1, 1, 1, 1, 2, 1, 3), -3},
+		{mat(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9), 0},
+	}
+	for _, tt := range dets {
+		res, err := c.Determinant(ctx, &calculatorpb.MatrixRequest{Matrix: tt.m})
+		if err != nil {
+			t.Fatalf("Determinant(%v): %v", tt.m.GetValues(), err)
+		}
+		if math.Abs(res.GetDeterminant()-tt.want) > 1e-9 {
+			t.Errorf("Determinant(%v) = %v, want %v", tt.m.GetValues(), res.GetDeterminant(), tt.want)
+		}
+	}
+
+	inv, err := c.InvertMatrix(ctx, &calculatorpb.MatrixRequest{Matrix: mat(2, 2, 4, 7, 2, 6)})
+	if err != nil {
+		t.Fatalf("InvertMatrix: %v", err)
+	}
+	if v := inv.GetMatrix().GetValues(); !approxEqual(v, []float64{0.6, -0.7, -0.2, 0.4}) {
+		t.Errorf("InvertMatrix = %v", v)
+	}
+
+	// 2x + y - z = 8, -3x - y + 2z = -11, -2x + y + 2z = -3
+	sol, err := c.SolveLinearSystem(ctx, &calculatorpb.SolveLinearSystemRequest{
+		Coefficients: mat(3, 3, 2, 1, -1, -3, -1, 2, -2, 1, 2),
+		Constants:    &calculatorpb.Vector{Values: []float64{8, -11, -3}},
+	})
+	if err != nil {
+		t.Fatalf("SolveLinearSystem: %v", err)
+	}
+	if v := sol.GetSolution().GetValues(); !approxEqual(v, []float64{2, 3, -1}) {
+		t.Errorf("SolveLinearSystem = %v, want [2 3 -1]", v)
+	}
+}
+
+// relativelyEqual compares slices of numbers of any size up to rounding
+// errors.
+func relativelyEqual(got, want []float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > 1e-9*math.Abs(want[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBadlyScaledMatrices(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		m       *calculatorpb.Matrix
+		det     float64
+		inverse []float64
+		// m·solution = constants
+		constants, solution []float64
+	}{
+		{"large diagonal", mat(2, 2, 1e13, 0, 0, 1), 1e13, []float64{1e-13, 0, 0, 1}, []float64{1e13, 2}, []float64{1, 2}},
+		{"small diagonal", mat(2, 2, 1e-13, 0, 0, 1), 1e-13, []float64{1e13, 0, 0, 1}, []float64{1e-13, 2}, []float64{1, 2}},
+		{"tiny values", mat(2, 2, 1e-150, 2e-150, 3e-150, 4e-150), -2e-300, []float64{-2e150, 1e150, 1.5e150, -0.5e150}, []float64{5e-150, 11e-150}, []float64{1, 2}},
+		{"small row", mat(2, 2, 1e-13, 2e-13, 3, 4), -2e-13, []float64{-2e13, 1, 1.5e13, -0.5}, []float64{5e-13, 11}, []float64{1, 2}},
+		{"small column", mat(2, 2, 1, 1e-13, 1, 2e-13), 1e-13, []float64{2, -1, -1e13, 1e13}, []float64{3, 5}, []float64{1, 2e13}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			det, err := c.Determinant(ctx, &calculatorpb.MatrixRequest{Matrix: tt.m})
+			if err != nil {
+				t.Fatalf("Determinant: %v", err)
+			}
+			if got := det.GetDeterminant(); !relativelyEqual([]float64{got}, []float64{tt.det}) {
+				t.Errorf("Determinant = %v, want %v", got, tt.det)
+			}
+			if tt.inverse != nil {
+				inv, err := c.InvertMatrix(ctx, &calculatorpb.MatrixRequest{Matrix: tt.m})
+				if err != nil {
+					t.Fatalf("InvertMatrix: %v", err)
+				}
+				if got := inv.GetMatrix().GetValues(); !relativelyEqual(got, tt.inverse) {
+					t.Errorf("InvertMatrix = %v, want %v", got, tt.inverse)
+				}
+			}
+			sol, err := c.SolveLinearSystem(ctx, &calculatorpb.SolveLinearSystemRequest{
+				Coefficients: tt.m,
+				Constants:    &calculatorpb.Vector{Values: tt.constants},
+			})
+			if err != nil {
+				t.Fatalf("SolveLinearSystem: %v", err)
+			}
+			if got := sol.GetSolution().GetValues(); !relativelyEqual(got, tt.solution) {
+				t.Errorf("SolveLinearSystem = %v, want %v", got, tt.solution)
+			}
+		})
+	}
+}
+
+func TestMatrixErrors(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+	singular := mat(2, 2, 1, 2, 2, 4)
+	tests := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"missing matrix", func() error {
+			_, err := c.TransposeMatrix(ctx, &calculatorpb.MatrixRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"wrong number of values", func() error {
+			_, err := c.TransposeMatrix(ctx, &calculatorpb.MatrixRequest{Matrix: mat(2, 2, 1, 2, 3)})
+			return err
+		}, codes.InvalidArgument},
+		{"too large", func() error {
+			_, err := c.TransposeMatrix(ctx, &calculatorpb.MatrixRequest{Matrix: mat(1, 501, make([]float64, 501)...)})
+			return err
+		}, codes.InvalidArgument},
+		{"NaN", func() error {
+			_, err := c.TransposeMatrix(ctx, &calculatorpb.MatrixRequest{Matrix: mat(1, 1, math.NaN())})
+			return err
+		}, codes.InvalidArgument},
+		{"inner dimensions", func() error {
+			_, err := c.MultiplyMatrices(ctx, &calculatorpb.MultiplyMatricesRequest{
+				Left:  mat(2, 3, 1, 2, 3, 4, 5, 6),
+				Right: &calculatorpb.MultiplyMatricesRequest_Matrix{Matrix: mat(2, 2, 1, 2, 3, 4)},
+			})
+			return err
+		}, codes.InvalidArgument},
+		{"vector length", func() error {
+			_, err := c.MultiplyMatrices(ctx, &calculatorpb.MultiplyMatricesRequest{
+				Left:  mat(2, 2, 1, 2, 3, 4),
+				Right: &calculatorpb.MultiplyMatricesRequest_Vector{Vector: &calculatorpb.Vector{Values: []float64{1}}},
+			})
+			return err
+		}, codes.InvalidArgument},
+		{"determinant of a non square matrix", func() error {
+			_, err := c.Determinant(ctx, &calculatorpb.MatrixRequest{Matrix: mat(1, 2, 1, 2)})
+			return err
+		}, codes.InvalidArgument},
+		{"singular inverse", func() error {
+			_, err := c.InvertMatrix(ctx, &calculatorpb.MatrixRequest{Matrix: singular})
+			return err
+		}, codes.FailedPrecondition},
+		{"nearly singular inverse", func() error {
+			_, err := c.InvertMatrix(ctx, &calculatorpb.MatrixRequest{Matrix: mat(2, 2, 0.1, 0.3, 0.2, 0.6)})
+			return err
+		}, codes.FailedPrecondition},
+		{"singular system", func() error {
+			_, err := c.SolveLinearSystem(ctx, &calculatorpb.SolveLinearSystemRequest{
+				Coefficients: singular,
+				Constants:    &calculatorpb.Vector{Values: []float64{1, 2}},
+			})
+			return err
+		}, codes.FailedPrecondition},
+		{"constants length", func() error {
+			_, err := c.SolveLinearSystem(ctx, &calculatorpb.SolveLinearSystemRequest{
+				Coefficients: mat(2, 2, 1, 0, 0, 1),
+				Constants:    &calculatorpb.Vector{Values: []float64{1, 2, 3}},
+			})
+			return err
+		}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); status.Code(err) != tt.code {
+				t.Errorf("got %v, want %v", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestSquareRoot(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		number int32
+		want   float64
+		code   codes.Code
+	}{
+		{16, 4, codes.OK},
+		{0, 0, codes.OK},
+		{2, math.Sqrt2, codes.OK},
+		{-2, 0, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		res, err := c.SquareRoot(context.Background(), &calculatorpb.SquareRootRequest{Number: tt.number})
+		if status.Code(err) != tt.code {
+			t.Fatalf("SquareRoot(%d): got %v, want %v", tt.number, err, tt.code)
+		}
+		if err == nil && res.GetNumberRoot() != tt.want {
+			t.Errorf("SquareRoot(%d) = %v, want %v", tt.number, res.GetNumberRoot(), tt.want)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		expr string
+		vars map[string]float64
+		want float64
+	}{
+		{"1 + 2 * 3", nil, 7},
+		{"(1 + 2) * 3", nil, 9},
+		{"10 - 4 - 3", nil, 3},
+		{"2 ^ 3 ^ 2", nil, 512},
+		{"-2 ^ 2", nil, -4},
+		{"7 % 4 + 1.5e1", nil, 18},
+		{"2 * (x + 1) ^ 2 - sqrt(y)", map[string]float64{"x": 2, "y": 16}, 14},
+		{"pow(2, 10) + log(e) + cos(0)", nil, 1026},
+		{"max(sin(pi / 2), abs(-3))", nil, 3},
+		// variables shadow the constants
+		{"e * 2", map[string]float64{"e": 4}, 8},
+	}
+	for _, tt := range tests {
+		res, err := c.Evaluate(context.Background(), &calculatorpb.EvaluateRequest{Expression: tt.expr, Variables: tt.vars})
+		if err != nil {
+			t.Fatalf("Evaluate(%q): %v", tt.expr, err)
+		}
+		if math.Abs(res.GetResult()-tt.want) > 1e-9 {
+			t.Errorf("Evaluate(%q) = %v, want %v", tt.expr, res.GetResult(), tt.want)
+		}
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"", "position 1: unexpected end of expression"},
+		{"1 +", "position 4: unexpected end of expression"},
+		{"(1 + 2", "position 7: expected \")\", found end of expression"},
+		{"1 + 2)", "position 6: unexpected \")\""},
+		{"2 $ 3", "position 3: unexpected character '$'"},
+		{"1 + x", "position 5: undefined variable \"x\""},
+		{"foo(1)", "position 1: unknown function \"foo\""},
+		{"pow(1)", "position 1: pow takes 2 argument(s), got 1"},
+		{"1 / (2 - 2)", "position 3: division by zero"},
+		{"3 + sqrt(-1)", "position 5: sqrt: argument out of domain"},
+		{"1.2.3", "position 1: invalid number \"1.2.3\""},
+		{strings.Repeat("(", 257) + "1" + strings.Repeat(")", 257), "position 257: the expression is nested more than 256 levels deep"},
+		{strings.Repeat("-", 300) + "1", "position 257: the expression is nested more than 256 levels deep"},
+		{"2" + strings.Repeat("^2", 300), "position 514: the expression is nested more than 256 levels deep"},
+		{strings.Repeat("1+", 5000) + "1", "position 10001: the expression is longer than 10000 characters"},
+		{strings.Repeat("(", 100000), "position 10001: the expression is longer than 10000 characters"},
+	}
+	for _, tt := range tests {
+		_, err := c.Evaluate(context.Background(), &calculatorpb.EvaluateRequest{Expression: tt.expr})
+		st := status.Convert(err)
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("Evaluate(%q): got %v, want InvalidArgument", tt.expr, err)
+			continue
+		}
+		var got string
+		for _, d := range st.Details() {
+			if br, ok := d.(*errdetails.BadRequest); ok && len(br.GetFieldViolations()) == 1 {
+				got = br.GetFieldViolations()[0].GetDescription()
+			}
+		}
+		if got != tt.want {
+			t.Errorf("Evaluate(%q) error = %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateNesting(t *testing.T) {
+	c := newClient(t)
+	expr := strings.Repeat("(", 256) + "1" + strings.Repeat(")", 256)
+	if res, err := c.Evaluate(context.Background(), &calculatorpb.EvaluateRequest{Expression: expr}); err != nil || res.GetResult() != 1 {
+		t.Errorf("Evaluate of 256 nested parentheses = %v, %v, want 1", res.GetResult(), err)
+	}
+	// deeper than the length limit lets through: the parser must not
+	// overflow its stack either
+	_, err := parseExpr(strings.Repeat("(", 4<<20))
+	if e, ok := err.(*exprError); !ok || e.pos != maxExpressionLength+1 {
+		t.Errorf("parseExpr of 4Mi parentheses: got %v, want an error at position %d", err, maxExpressionLength+1)
+	}
+	p := &parser{tokens: make([]token, 0, 1<<20)}
+	for i := 0; i < 1<<20; i++ {
+		p.tokens = append(p.tokens, token{kind: tokOp, text: "(", pos: i + 1})
+	}
+	p.tokens = append(p.tokens, token{kind: tokEOF, pos: 1<<20 + 1})
+	if _, err := p.expr(); err == nil || !strings.Contains(err.Error(), "nested more than 256 levels") {
+		t.Errorf("parsing 1Mi nested parentheses: got %v, want a nesting error", err)
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	c := newClient(t)
+	const (
+		integer  = calculatorpb.CalculateRequest_INTEGER
+		rational = calculatorpb.CalculateRequest_RATIONAL
+		float    = calculatorpb.CalculateRequest_FLOAT
+	)
+	tests := []struct {
+		mode      calculatorpb.CalculateRequest_Mode
+		op        calculatorpb.CalculateRequest_Operation
+		operands  []string
+		precision uint32
+		want      string
+	}{
+		{integer, calculatorpb.CalculateRequest_ADD, []string{"2147483647", "1"}, 0, "2147483648"},
+		{integer, calculatorpb.CalculateRequest_MULTIPLY, []string{"123456789012345678901234567890", "-10"}, 0, "-1234567890123456789012345678900"},
+		{integer, calculatorpb.CalculateRequest_SUBTRACT, []string{"10", "3", "4"}, 0, "3"},
+		{integer, calculatorpb.CalculateRequest_DIVIDE, []string{"-7", "2"}, 0, "-4"},
+		{integer, calculatorpb.CalculateRequest_MODULO, []string{"-7", "2"}, 0, "1"},
+		{integer, calculatorpb.CalculateRequest_POWER, []string{"2", "100"}, 0, "1267650600228229401496703205376"},
+		{integer, calculatorpb.CalculateRequest_SQUARE_ROOT, []string{"99"}, 0, "9"},
+		{rational, calculatorpb.CalculateRequest_ADD, []string{"1/3", "1/6"}, 0, "1/2"},
+		{rational, calculatorpb.CalculateRequest_DIVIDE, []string{"0.25", "-3"}, 0, "-1/12"},
+		{rational, calculatorpb.CalculateRequest_POWER, []string{"2/3", "-2"}, 0, "9/4"},
+		{rational, calculatorpb.CalculateRequest_MULTIPLY, []string{"1e-3", "4"}, 0, "1/250"},
+		{float, calculatorpb.CalculateRequest_SQUARE_ROOT, []string{"2"}, 40, "1.41421356237309504880168872420969807857"},
+		{float, calculatorpb.CalculateRequest_DIVIDE, []string{"10", "3"}, 0, "3.333333333333333333333333333333333"},
+		{float, calculatorpb.CalculateRequest_ADD, []string{"0.1", "0.2"}, 5, "0.3"},
+		{float, calculatorpb.CalculateRequest_POWER, []string{"1.5", "-2"}, 10, "0.4444444444"},
+	}
+	for _, tt := range tests {
+		req := &calculatorpb.CalculateRequest{Mode: tt.mode, Operation: tt.op, Operands: tt.operands, Precision: tt.precision}
+		res, err := c.Calculate(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Calculate(%v %v %v): %v", tt.mode, tt.op, tt.operands, err)
+		}
+		if res.GetResult() != tt.want {
+			t.Errorf("Calculate(%v %v %v) = %s, want %s", tt.mode, tt.op, tt.operands, res.GetResult(), tt.want)
+		}
+	}
+}
+
+func TestCalculateErrors(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		name string
+		req  *calculatorpb.CalculateRequest
+		code codes.Code
+	}{
+		{"no operation", &calculatorpb.CalculateRequest{Operands: []string{"1"}}, codes.InvalidArgument},
+		{"no operands", &calculatorpb.CalculateRequest{Operation: calculatorpb.CalculateRequest_ADD}, codes.InvalidArgument},
+		{"one operand to subtract", &calculatorpb.CalculateRequest{Operation: calculatorpb.CalculateRequest_SUBTRACT, Operands: []string{"1"}}, codes.InvalidArgument},
+		{"not an integer", &calculatorpb.CalculateRequest{Operation: calculatorpb.CalculateRequest_ADD, Operands: []string{"1.5"}}, codes.InvalidArgument},
+		{"division by zero", &calculatorpb.CalculateRequest{Operation: calculatorpb.CalculateRequest_DIVIDE, Operands: []string{"1", "0"}}, codes.InvalidArgument},
+		{"rational division by zero", &calculatorpb.CalculateRequest{Mode: calculatorpb.CalculateRequest_RATIONAL, Operation: calculatorpb.CalculateRequest_POWER, Operands: []string{"0", "-1"}}, codes.InvalidArgument},
+		{"negative square root", &calculatorpb.CalculateRequest{Mode: calculatorpb.CalculateRequest_FLOAT, Operation: calculatorpb.CalculateRequest_SQUARE_ROOT, Operands: []string{"-2"}}, codes.InvalidArgument},
+		{"fractional exponent", &calculatorpb.CalculateRequest{Mode: calculatorpb.CalculateRequest_FLOAT, Operation: calculatorpb.CalculateRequest_POWER, Operands: []string{"2", "0.5"}}, codes.InvalidArgument},
+		{"precision", &calculatorpb.CalculateRequest{Mode: calculatorpb.CalculateRequest_FLOAT, Operation: calculatorpb.CalculateRequest_ADD, Operands: []string{"1"}, Precision: 100000}, codes.InvalidArgument},
+		{"huge power", &calculatorpb.CalculateRequest{Operation: calculatorpb.CalculateRequest_POWER, Operands: []string{"10", "1000000000"}}, codes.OutOfRange},
+		{"float overflow", &calculatorpb.CalculateRequest{Mode: calculatorpb.CalculateRequest_FLOAT, Operation: calculatorpb.CalculateRequest_MULTIPLY, Operands: []string{"1e600000000", "1e600000000", "1e600000000", "0"}}, codes.OutOfRange},
+		{"float power overflow", &calculatorpb.CalculateRequest{Mode: calculatorpb.CalculateRequest_FLOAT, Operation: calculatorpb.CalculateRequest_POWER, Operands: []string{"10", "9000000000000000000"}}, codes.OutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.Calculate(context.Background(), tt.req)
+			if status.Code(err) != tt.code {
+				t.Errorf("got %v, want %v", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestBatchCompute(t *testing.T) {
+	c := newClient(t)
+	type op = calculatorpb.BatchComputeRequest_Operation
+	res, err := c.BatchCompute(context.Background(), &calculatorpb.BatchComputeRequest{
+		Operations: []*op{
+			{Request: &calculatorpb.BatchComputeRequest_Operation_Sum{Sum: &calculatorpb.SumRequest{FirstNumber: 3, SecondNumber: 10}}},
+			{Request: &calculatorpb.BatchComputeRequest_Operation_SquareRoot{SquareRoot: &calculatorpb.SquareRootRequest{Number: -4}}},
+			{Request: &calculatorpb.BatchComputeRequest_Operation_SquareRoot{SquareRoot: &calculatorpb.SquareRootRequest{Number: 16}}},
+			{Request: &calculatorpb.BatchComputeRequest_Operation_Sum{Sum: &calculatorpb.SumRequest{FirstNumber: math.MaxInt32, SecondNumber: 1}}},
+			{Request: &calculatorpb.BatchComputeRequest_Operation_Evaluate{Evaluate: &calculatorpb.EvaluateRequest{Expression: "2 * x", Variables: map[string]float64{"x": 21}}}},
+			{Request: &calculatorpb.BatchComputeRequest_Operation_Determinant{Determinant: &calculatorpb.MatrixRequest{Matrix: mat(2, 2, 1, 2, 3, 4)}}},
+			{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchCompute: %v", err)
+	}
+	results := res.GetResults()
+	if len(results) != 7 {
+		t.Fatalf("got %d results, want 7", len(results))
+	}
+	if got := results[0].GetSum().GetSumResult(); got != 13 {
+		t.Errorf("result 0 = %v, want 13", results[0])
+	}
+	if got := results[2].GetSquareRoot().GetNumberRoot(); got != 4 {
+		t.Errorf("result 2 = %v, want 4", results[2])
+	}
+	if got := results[4].GetEvaluate().GetResult(); got != 42 {
+		t.Errorf("result 4 = %v, want 42", results[4])
+	}
+	if got := results[5].GetDeterminant().GetDeterminant(); math.Abs(got+2) > 1e-9 {
+		t.Errorf("result 5 = %v, want -2", results[5])
+	}
+	for i, want := range map[int]codes.Code{1: codes.InvalidArgument, 3: codes.OutOfRange, 6: codes.InvalidArgument} {
+		if got := codes.Code(results[i].GetError().GetCode()); got != want {
+			t.Errorf("result %d = %v, want an error %v", i, results[i], want)
+		}
+	}
+}
+
+func TestBatchComputeWorkers(t *testing.T) {
+	cc := grpctest.NewServer(t, func(s *grpc.Server) {
+		calculatorpb.RegisterCalculatorServiceServer(s, &server{batchWorkers: 3})
+	})
+	c := calculatorpb.NewCalculatorServiceClient(cc)
+
+	ops := make([]*calculatorpb.BatchComputeRequest_Operation, 1000)
+	for i := range ops {
+		ops[i] = &calculatorpb.BatchComputeRequest_Operation{
+			Request: &calculatorpb.BatchComputeRequest_Operation_Sum{Sum: &calculatorpb.SumRequest{FirstNumber: int32(i), SecondNumber: int32(i)}},
+		}
+	}
+	res, err := c.BatchCompute(context.Background(), &calculatorpb.BatchComputeRequest{Operations: ops})
+	if err != nil {
+		t.Fatalf("BatchCompute: %v", err)
+	}
+	if len(res.GetResults()) != len(ops) {
+		t.Fatalf("got %d results, want %d", len(res.GetResults()), len(ops))
+	}
+	for i, r := range res.GetResults() {
+		if got := r.GetSum().GetSumResult(); got != int32(2*i) {
+			t.Fatalf("result %d = %v, want %d", i, r, 2*i)
+		}
+	}
+
+	if _, err := c.BatchCompute(context.Background(), &calculatorpb.BatchComputeRequest{}); err != nil {
+		t.Errorf("BatchCompute of no operations: %v", err)
+	}
+	ops = make([]*calculatorpb.BatchComputeRequest_Operation, maxBatchOperations+1)
+	for i := range ops {
+		ops[i] = &calculatorpb.BatchComputeRequest_Operation{}
+	}
+	_, err = c.BatchCompute(context.Background(), &calculatorpb.BatchComputeRequest{Operations: ops})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("BatchCompute of %d operations: got %v, want InvalidArgument", len(ops), err)
+	}
+}
+
+func TestBatchOperationRPCs(t *testing.T) {
+	methods := calculatorpb.File_proto_calculator_proto.Services().ByName("CalculatorService").Methods()
+	fields := (&calculatorpb.BatchComputeRequest_Operation{}).ProtoReflect().Descriptor().Oneofs().ByName("request").Fields()
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.Get(i)
+		if name := operationRPCs[f.Number()]; methods.ByName(protoreflect.Name(name)) == nil {
+			t.Errorf("operation %s: no RPC named %q", f.Name(), name)
+		}
+	}
+
+	const service = "/calculator.CalculatorService/"
+	isPrime := &calculatorpb.BatchComputeRequest_Operation{
+		Request: &calculatorpb.BatchComputeRequest_Operation_IsPrime{IsPrime: &calculatorpb.IsPrimeRequest{Number: "7"}},
+	}
+	gcd := &calculatorpb.BatchComputeRequest_Operation{
+		Request: &calculatorpb.BatchComputeRequest_Operation_GreatestCommonDivisor{GreatestCommonDivisor: &calculatorpb.IntegersRequest{Numbers: []string{"4", "6"}}},
+	}
+	req := &calculatorpb.BatchComputeRequest{Operations: []*calculatorpb.BatchComputeRequest_Operation{isPrime, gcd, isPrime, {}}}
+	want := map[string]int{service + "BatchCompute": 1, service + "IsPrime": 2, service + "GreatestCommonDivisor": 1}
+	if got := batchCalls(service+"BatchCompute", req); !reflect.DeepEqual(got, want) {
+		t.Errorf("batchCalls = %v, want %v", got, want)
+	}
+	if got := batchCalls(service+"IsPrime", isPrime.GetIsPrime()); got != nil {
+		t.Errorf("batchCalls of IsPrime = %v, want nil", got)
+	}
+}
+
+func TestBatchComputeRateLimits(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.Config{
+		Methods: map[string]ratelimit.Limit{"/calculator.CalculatorService/IsPrime": {Rate: 0.001, Burst: 3}},
+		Calls:   batchCalls,
+	})
+	cc := grpctest.NewServer(t, func(s *grpc.Server) {
+		calculatorpb.RegisterCalculatorServiceServer(s, &server{})
+	}, grpc.UnaryInterceptor(limiter.UnaryServerInterceptor()))
+	c := calculatorpb.NewCalculatorServiceClient(cc)
+	ctx := context.Background()
+
+	batch := func(n int) error {
+		ops := make([]*calculatorpb.BatchComputeRequest_Operation, n)
+		for i := range ops {
+			ops[i] = &calculatorpb.BatchComputeRequest_Operation{
+				Request: &calculatorpb.BatchComputeRequest_Operation_IsPrime{IsPrime: &calculatorpb.IsPrimeRequest{Number: strconv.Itoa(i)}},
+			}
+		}
+		_, err := c.BatchCompute(ctx, &calculatorpb.BatchComputeRequest{Operations: ops})
+		return err
+	}
+	if err := batch(4); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("batch of 4 IsPrime operations: got %v, want ResourceExhausted", err)
+	}
+	if err := batch(2); err != nil {
+		t.Fatalf("batch of 2 IsPrime operations: %v", err)
+	}
+	if _, err := c.IsPrime(ctx, &calculatorpb.IsPrimeRequest{Number: "7"}); err != nil {
+		t.Fatalf("IsPrime after the batch: %v", err)
+	}
+	if _, err := c.IsPrime(ctx, &calculatorpb.IsPrimeRequest{Number: "7"}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("IsPrime beyond the limit: got %v, want ResourceExhausted", err)
+	}
+}
+
+func TestConvertUnits(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		value    float64
+		from, to string
+		want     float64
+	}{
+		{1, "km", "m", 1000},
+		{1, "mi", "ft", 5280},
+		{1, "lb", "oz", 16},
+		{90, "min", "h", 1.5},
+		{100, "degC", "degF", 212},
+		{-40, "°F", "degC", -40},
+		{0, "degC", "K", 273.15},
+		{1, "GiB", "MiB", 1024},
+		{1, "MB", "Mbit", 8},
+		{100, "Mbps", "MB/s", 12.5},
+		{36, "km/h", "m/s", 10},
+		{1, "kg*m/s^2", "g*cm/s^2", 1e5},
+		{2, "", "", 2},
+	}
+	for _, tt := range tests {
+		res, err := c.ConvertUnits(context.Background(), &calculatorpb.ConvertUnitsRequest{
+			Quantity: &calculatorpb.Quantity{Value: tt.value, Unit: tt.from},
+			Unit:     tt.to,
+		})
+		if err != nil {
+			t.Errorf("ConvertUnits(%v %s to %s): %v", tt.value, tt.from, tt.to, err)
+			continue
+		}
+		if q := res.GetQuantity(); math.Abs(q.GetValue()-tt.want) > 1e-9*math.Max(1, math.Abs(tt.want)) || q.GetUnit() != tt.to {
+			t.Errorf("ConvertUnits(%v %s to %s) = %v, want %v %s", tt.value, tt.from, tt.to, q, tt.want, tt.to)
+		}
+	}
+}
+
+func TestCalculateQuantities(t *testing.T) {
+	c := newClient(t)
+	q := func(v float64, unit string) *calculatorpb.Quantity {
+		return &calculatorpb.Quantity{Value: v, Unit: unit}
+	}
+	tests := []struct {
+		op          calculatorpb.CalculateQuantitiesRequest_Operation
+		left, right *calculatorpb.Quantity
+		unit        string
+		want        *calculatorpb.Quantity
+	}{
+		{calculatorpb.CalculateQuantitiesRequest_ADD, q(1, "km"), q(500, "m"), "", q(1.5, "km")},
+		{calculatorpb.CalculateQuantitiesRequest_ADD, q(1, "km"), q(500, "m"), "m", q(1500, "m")},
+		{calculatorpb.CalculateQuantitiesRequest_SUBTRACT, q(1, "h"), q(30, "min"), "", q(0.5, "h")},
+		{calculatorpb.CalculateQuantitiesRequest_ADD, q(20, "degC"), q(5, "degC"), "", q(25, "degC")},
+		{calculatorpb.CalculateQuantitiesRequest_ADD, q(20, "degC"), q(5, "K"), "", q(25, "degC")},
+		{calculatorpb.CalculateQuantitiesRequest_ADD, q(20, "degC"), q(9, "degF"), "", q(25, "degC")},
+		{calculatorpb.CalculateQuantitiesRequest_ADD, q(300, "K"), q(5, "degC"), "", q(305, "K")},
+		{calculatorpb.CalculateQuantitiesRequest_SUBTRACT, q(68, "degF"), q(5, "K"), "", q(59, "degF")},
+		{calculatorpb.CalculateQuantitiesRequest_ADD, q(20, "degC"), q(5, "K"), "K", q(298.15, "K")},
+		{calculatorpb.CalculateQuantitiesRequest_MULTIPLY, q(2, "m"), q(3, "m"), "", q(6, "m^2")},
+		{calculatorpb.CalculateQuantitiesRequest_DIVIDE, q(100, "km"), q(2, "h"), "", q(100000.0/7200, "m/s")},
+		{calculatorpb.CalculateQuantitiesRequest_DIVIDE, q(100, "km"), q(2, "h"), "km/h", q(50, "km/h")},
+		{calculatorpb.CalculateQuantitiesRequest_DIVIDE, q(1, "GB"), q(8, "s"), "Mbps", q(1000, "Mbps")},
+		{calculatorpb.CalculateQuantitiesRequest_DIVIDE, q(3, "m"), q(1, "ft"), "", q(3/0.3048, "")},
+		{calculatorpb.CalculateQuantitiesRequest_MULTIPLY, q(2, ""), q(4, "s"), "", q(8, "s")},
+	}
+	for _, tt := range tests {
+		res, err := c.CalculateQuantities(context.Background(), &calculatorpb.CalculateQuantitiesRequest{
+			Operation: tt.op, Left: tt.left, Right: tt.right, Unit: tt.unit,
+		})
+		if err != nil {
+			t.Errorf("CalculateQuantities(%v %v %v): %v", tt.left, tt.op, tt.right, err)
+			continue
+		}
+		got := res.GetResult()
+		if math.Abs(got.GetValue()-tt.want.GetValue()) > 1e-9*math.Abs(tt.want.GetValue()) || got.GetUnit() != tt.want.GetUnit() {
+			t.Errorf("CalculateQuantities(%v %v %v) = %v, want %v", tt.left, tt.op, tt.right, got, tt.want)
+		}
+	}
+}
+
+func TestUnitErrors(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+	convert := func(from, to string) error {
+		_, err := c.ConvertUnits(ctx, &calculatorpb.ConvertUnitsRequest{
+			Quantity: &calculatorpb.Quantity{Value: 1, Unit: from},
+			Unit:     to,
+		})
+		return err
+	}
+	calc := func(op calculatorpb.CalculateQuantitiesRequest_Operation, left, right, unit string) error {
+		_, err := c.CalculateQuantities(ctx, &calculatorpb.CalculateQuantitiesRequest{
+			Operation: op,
+			Left:      &calculatorpb.Quantity{Value: 1, Unit: left},
+			Right:     &calculatorpb.Quantity{Value: 2, Unit: right},
+			Unit:      unit,
+		})
+		return err
+	}
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"unknown unit", convert("furlong", "m")},
+		{"different dimensions", convert("m", "s")},
+		{"bad exponent", convert("m^x", "m")},
+		{"zero exponent", convert("m^0", "")},
+		{"empty term", convert("m/", "m")},
+		{"offset in a compound unit", convert("degC/s", "K/s")},
+		{"missing quantity", func() error {
+			_, err := c.ConvertUnits(ctx, &calculatorpb.ConvertUnitsRequest{Unit: "m"})
+			return err
+		}()},
+		{"meters plus seconds", calc(calculatorpb.CalculateQuantitiesRequest_ADD, "m", "s", "")},
+		{"kilograms minus bytes", calc(calculatorpb.CalculateQuantitiesRequest_SUBTRACT, "kg", "B", "")},
+		{"wrong result unit", calc(calculatorpb.CalculateQuantitiesRequest_DIVIDE, "m", "s", "km")},
+		{"temperature product", calc(calculatorpb.CalculateQuantitiesRequest_MULTIPLY, "degC", "", "")},
+		{"no operation", calc(calculatorpb.CalculateQuantitiesRequest_OPERATION_UNSPECIFIED, "m", "m", "")},
+	}
+	for _, tt := range tests {
+		if status.Code(tt.err) != codes.InvalidArgument {
+			t.Errorf("%s: got %v, want InvalidArgument", tt.name, tt.err)
+		}
+	}
+}
+
+func TestNumberTheory(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+	integers := func(numbers ...string) *calculatorpb.IntegersRequest {
+		return &calculatorpb.IntegersRequest{Numbers: numbers}
+	}
+	tests := []struct {
+		name string
+		call func() (*calculatorpb.IntegerResponse, error)
+		want string
+	}{
+		{"gcd", func() (*calculatorpb.IntegerResponse, error) {
+			return c.GreatestCommonDivisor(ctx, integers("84", "-36", "120"))
+		}, "12"},
+		{"gcd of one number", func() (*calculatorpb.IntegerResponse, error) {
+			return c.GreatestCommonDivisor(ctx, integers("-7"))
+		}, "7"},
+		{"gcd of zeros", func() (*calculatorpb.IntegerResponse, error) {
+			return c.GreatestCommonDivisor(ctx, integers("0", "0"))
+		}, "0"},
+		{"big gcd", func() (*calculatorpb.IntegerResponse, error) {
+			// (2^61 - 1) * 3 and (2^61 - 1) * 5
+			return c.GreatestCommonDivisor(ctx, integers("6917529027641081853", "11529215046068469755"))
+		}, "2305843009213693951"},
+		{"lcm", func() (*calculatorpb.IntegerResponse, error) {
+			return c.LeastCommonMultiple(ctx, integers("4", "6", "-10"))
+		}, "60"},
+		{"lcm with zero", func() (*calculatorpb.IntegerResponse, error) {
+			return c.LeastCommonMultiple(ctx, integers("4", "0"))
+		}, "0"},
+		{"modular exponentiation", func() (*calculatorpb.IntegerResponse, error) {
+			return c.ModularExponentiation(ctx, &calculatorpb.ModularExponentiationRequest{Base: "4", Exponent: "13", Modulus: "497"})
+		}, "445"},
+		{"negative base", func() (*calculatorpb.IntegerResponse, error) {
+			return c.ModularExponentiation(ctx, &calculatorpb.ModularExponentiationRequest{Base: "-2", Exponent: "3", Modulus: "5"})
+		}, "2"},
+		{"negative exponent", func() (*calculatorpb.IntegerResponse, error) {
+			return c.ModularExponentiation(ctx, &calculatorpb.ModularExponentiationRequest{Base: "3", Exponent: "-1", Modulus: "11"})
+		}, "4"},
+		{"Fermat", func() (*calculatorpb.IntegerResponse, error) {
+			// a^(p-1) = 1 mod p for the prime 2^127 - 1
+			return c.ModularExponentiation(ctx, &calculatorpb.ModularExponentiationRequest{
+				Base:     "123456789",
+				Exponent: "170141183460469231731687303715884105726",
+				Modulus:  "170141183460469231731687303715884105727",
+			})
+		}, "1"},
+		{"modular inverse", func() (*calculatorpb.IntegerResponse, error) {
+			return c.ModularInverse(ctx, &calculatorpb.ModularInverseRequest{Number: "17", Modulus: "3120"})
+		}, "2753"},
+		{"inverse of a negative number", func() (*calculatorpb.IntegerResponse, error) {
+			return c.ModularInverse(ctx, &calculatorpb.ModularInverseRequest{Number: "-3", Modulus: "7"})
+		}, "2"},
+	}
+	for _, tt := range tests {
+		res, err := tt.call()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if res.GetResult() != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, res.GetResult(), tt.want)
+		}
+	}
+}
+
+func TestNumberTheoryErrors(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"no numbers", func() error {
+			_, err := c.GreatestCommonDivisor(ctx, &calculatorpb.IntegersRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"not an integer", func() error {
+			_, err := c.LeastCommonMultiple(ctx, &calculatorpb.IntegersRequest{Numbers: []string{"4", "1.5"}})
+			return err
+		}, codes.InvalidArgument},
+		{"too many digits", func() error {
+			_, err := c.IsPrime(ctx, &calculatorpb.IsPrimeRequest{Number: strings.Repeat("9", 1001)})
+			return err
+		}, codes.InvalidArgument},
+		{"zero modulus", func() error {
+			_, err := c.ModularExponentiation(ctx, &calculatorpb.ModularExponentiationRequest{Base: "2", Exponent: "3", Modulus: "0"})
+			return err
+		}, codes.InvalidArgument},
+		{"no inverse", func() error {
+			_, err := c.ModularInverse(ctx, &calculatorpb.ModularInverseRequest{Number: "6", Modulus: "9"})
+			return err
+		}, codes.FailedPrecondition},
+		{"negative power without inverse", func() error {
+			_, err := c.ModularExponentiation(ctx, &calculatorpb.ModularExponentiationRequest{Base: "2", Exponent: "-1", Modulus: "4"})
+			return err
+		}, codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		if err := tt.call(); status.Code(err) != tt.code {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.code)
+		}
+	}
+}
+
+func TestIsPrime(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		number         string
+		prime, certain bool
+	}{
+		{"-7", false, true},
+		{"0", false, true},
+		{"1", false, true},
+		{"2", true, true},
+		{"561", false, true}, // a Carmichael number
+		{"2305843009213693951", true, true},
+		{"170141183460469231731687303715884105727", true, false},
+		{"170141183460469231731687303715884105729", false, false},
+	}
+	for _, tt := range tests {
+		res, err := c.IsPrime(context.Background(), &calculatorpb.IsPrimeRequest{Number: tt.number})
+		if err != nil {
+			t.Errorf("IsPrime(%s): %v", tt.number, err)
+			continue
+		}
+		if res.GetPrime() != tt.prime || res.GetCertain() != tt.certain {
+			t.Errorf("IsPrime(%s) = %v, want prime %v, certain %v", tt.number, res, tt.prime, tt.certain)
+		}
+	}
+}
+
+func generatePrimes(ctx context.Context, c calculatorpb.CalculatorServiceClient, from, to uint64) ([]uint64, error) {
+	stream, err := c.GeneratePrimes(ctx, &calculatorpb.GeneratePrimesRequest{From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+	var primes []uint64
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return primes, nil
+		}
+		if err != nil {
+			return primes, err
+		}
+		primes = append(primes, res.GetPrimes()...)
+	}
+}
+
+func TestGeneratePrimes(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	primes, err := generatePrimes(ctx, c, 0, 100)
+	if err != nil {
+		t.Fatalf("GeneratePrimes: %v", err)
+	}
+	if len(primes) != 25 || primes[0] != 2 || primes[24] != 97 {
+		t.Errorf("GeneratePrimes(0, 100) = %v", primes)
+	}
+
+	// several segments, compared with Miller-Rabin
+	from, to := uint64(maxPrimesLimit-200000), uint64(maxPrimesLimit)
+	primes, err = generatePrimes(ctx, c, from, to)
+	if err != nil {
+		t.Fatalf("GeneratePrimes: %v", err)
+	}
+	var want []uint64
+	for n := from; n <= to; n++ {
+		if new(big.Int).SetUint64(n).ProbablyPrime(0) {
+			want = append(want, n)
+		}
+	}
+	if !reflect.DeepEqual(primes, want) {
+		t.Errorf("GeneratePrimes(%d, %d) returned %d primes, want %d", from, to, len(primes), len(want))
+	}
+
+	for _, r := range [][2]uint64{{10, 9}, {0, maxPrimesLimit + 1}} {
+		if _, err := generatePrimes(ctx, c, r[0], r[1]); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GeneratePrimes(%d, %d): got %v, want InvalidArgument", r[0], r[1], err)
+		}
+	}
+}
+
+func TestGeneratePrimesCanceled(t *testing.T) {
+	c := newClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := c.GeneratePrimes(ctx, &calculatorpb.GeneratePrimesRequest{To: maxPrimesLimit})
+	if err != nil {
+		t.Fatalf("GeneratePrimes: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	cancel()
+	for {
+		if _, err = stream.Recv(); err != nil {
+			break
+		}
+	}
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("got %v, want Canceled", err)
+	}
+}
+
+func TestCache(t *testing.T) {
+	responses := cache.New(cache.Config{MaxEntries: 100, MaxBytes: 1 << 20, TTL: time.Minute, BypassField: "no_cache"})
+	cc := grpctest.NewServer(t, func(s *grpc.Server) {
+		calculatorpb.RegisterCalculatorServiceServer(s, &server{})
+	},
+		grpc.ChainUnaryInterceptor(responses.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(responses.StreamServerInterceptor()),
+	)
+	c := calculatorpb.NewCalculatorServiceClient(cc)
+	ctx := context.Background()
+	bypass := cache.Bypass(ctx)
+	from := func(md metadata.MD) string {
+		if v := md.Get(cache.HeaderKey); len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+
+	// (2^61 - 1)(2^31 - 1)(10^9 + 7)
+	req := &calculatorpb.PrimeNumberDecompositionRequest{BigNumber: "4951760189497999172085065914647235079"}
+	noCache := &calculatorpb.PrimeNumberDecompositionRequest{BigNumber: req.BigNumber, NoCache: true}
+	want := []string{"1000000007", "2147483647", "2305843009213693951"}
+	for _, tt := range []struct {
+		ctx  context.Context
+		req  *calculatorpb.PrimeNumberDecompositionRequest
+		want string
+	}{{ctx, req, "miss"}, {ctx, req, "hit"}, {bypass, req, "bypass"}, {ctx, noCache, "bypass"}} {
+		stream, err := c.PrimeNumberDecomposition(tt.ctx, tt.req)
+		if err != nil {
+			t.Fatalf("PrimeNumberDecomposition: %v", err)
+		}
+		var got []string
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Recv: %v", err)
+			}
+			got = append(got, res.GetBigPrimeFactor())
+		}
+		md, _ := stream.Header()
+		if !reflect.DeepEqual(got, want) || from(md) != tt.want {
+			t.Errorf("PrimeNumberDecomposition = %v from %q, want %v from %q", got, from(md), want, tt.want)
+		}
+	}
+
+	// the variables of the map are in a canonical order in the key, which
+	// leaves no_cache out
+	for i, want := range []string{"miss", "hit", "bypass", "hit"} {
+		vars := map[string]float64{"x": 1, "y": 2, "z": 3}
+		var md metadata.MD
+		res, err := c.Evaluate(ctx, &calculatorpb.EvaluateRequest{Expression: "x + y * z", Variables: vars, NoCache: want == "bypass"}, grpc.Header(&md))
+		if err != nil {
+			t.Fatalf("Evaluate: %v", err)
+		}
+		if res.GetResult() != 7 || from(md) != want {
+			t.Errorf("Evaluate %d = %v from %q, want 7 from %q", i, res.GetResult(), from(md), want)
+		}
+	}
+
+	// client streams are not cached
+	stream, err := c.ComputeAverage(ctx)
+	if err != nil {
+		t.Fatalf("ComputeAverage: %v", err)
+	}
+	if err := stream.Send(&calculatorpb.ComputeAverageRequest{Number: 1}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		t.Fatalf("CloseAndRecv: %v", err)
+	}
+	if md, _ := stream.Header(); from(md) != "" {
+		t.Errorf("ComputeAverage came from %q", from(md))
+	}
+
+	if s := responses.Stats(); s.Hits != 3 || s.Misses != 2 || s.Bypasses != 3 {
+		t.Errorf("Stats() = %+v, want 3 hits, 2 misses and 3 bypasses", s)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// reservoirSize is the number of values kept to estimate the percentiles:
+// they are exact up to that many values, estimated from a uniform sample of
+// them beyond.
+const reservoirSize = 4096
+
+// reportedPercentiles are the percentiles returned by ComputeStatistics.
+var reportedPercentiles = []float64{25, 50, 75, 90, 95, 99}
+
+// summary accumulates the statistics of a stream of numbers in constant
+// memory.
+type summary struct {
+	count    int64
+	sum      float64
+	min, max float64
+	// mean and m2, the sum of the squared differences to the mean, are
+	// updated with Welford's algorithm, which does not lose precision the
+	// way the sum of the squares does
+	mean, m2 float64
+
+	// sample is a uniform sample of the numbers, by reservoir sampling
+	sample []float64
+	rnd    *rand.Rand
+}
+
+func newSummary() *summary {
+	return &summary{rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+func (s *summary) add(x float64) {
+	s.count++
+	s.sum += x
+	if s.count == 1 || x < s.min {
+		s.min = x
+	}
+	if s.count == 1 || x > s.max {
+		s.max = x
+	}
+	delta := x - s.mean
+	s.mean += delta / float64(s.count)
+	s.m2 += delta * (x - s.mean)
+
+	if len(s.sample) < reservoirSize {
+		s.sample = append(s.sample, x)
+	} else if i := s.rnd.Int63n(s.count); i < reservoirSize {
+		s.sample[i] = x
+	}
+}
+
+// variance returns the sample variance, with n - 1 as denominator; it is 0
+// for a single number.
+func (s *summary) variance() float64 {
+	if s.count < 2 {
+		return 0
+	}
+	return s.m2 / float64(s.count-1)
+}
+
+// percentiles returns the given percentiles of the sample, interpolating
+// linearly between the closest ranks.
+func (s *summary) percentiles(ps []float64) []float64 {
+	sorted := append([]float64(nil), s.sample...)
+	sort.Float64s(sorted)
+	values := make([]float64, len(ps))
+	for i, p := range ps {
+		rank := p / 100 * float64(len(sorted)-1)
+		lo := int(math.Floor(rank))
+		hi := int(math.Ceil(rank))
+		values[i] = sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+	}
+	return values
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"calculator/calculatorpb"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dimension holds the exponents of the base quantities of a unit: length,
+// mass, time, temperature and data size.
+type dimension [5]int
+
+// baseUnits are the units of the base quantities, in which values are
+// converted.
+var baseUnits = [len(dimension{})]string{"m", "kg", "s", "K", "B"}
+
+var (
+	lengthDim      = dimension{1, 0, 0, 0, 0}
+	massDim        = dimension{0, 1, 0, 0, 0}
+	timeDim        = dimension{0, 0, 1, 0, 0}
+	temperatureDim = dimension{0, 0, 0, 1, 0}
+	dataSizeDim    = dimension{0, 0, 0, 0, 1}
+	dataRateDim    = dimension{0, 0, -1, 0, 1}
+	speedDim       = dimension{1, 0, -1, 0, 0}
+)
+
+var dimensionNames = map[dimension]string{
+	{}:             "dimensionless",
+	lengthDim:      "length",
+	massDim:        "mass",
+	timeDim:        "time",
+	temperatureDim: "temperature",
+	dataSizeDim:    "data size",
+	dataRateDim:    "data rate",
+	speedDim:       "speed",
+}
+
+func (d dimension) add(e dimension, sign int) dimension {
+	for i := range d {
+		d[i] += sign * e[i]
+	}
+	return d
+}
+
+func (d dimension) scale(n int) dimension {
+	for i := range d {
+		d[i] *= n
+	}
+	return d
+}
+
+// baseUnit returns the unit of d made of base units, such as "kg*m/s^2".
+func (d dimension) baseUnit() string {
+	var num, den []string
+	for i, e := range d {
+		term := baseUnits[i]
+		if e > 1 || e < -1 {
+			term += "^" + strconv.Itoa(abs(e))
+		}
+		switch {
+		case e > 0:
+			num = append(num, term)
+		case e < 0:
+			den = append(den, term)
+		}
+	}
+	s := strings.Join(num, "*")
+	if len(den) > 0 {
+		if s == "" {
+			s = "1"
+		}
+		s += "/" + strings.Join(den, "/")
+	}
+	return s
+}
+
+func (d dimension) String() string {
+	if name, ok := dimensionNames[d]; ok {
+		return name
+	}
+	return d.baseUnit()
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// unit converts values to the base units: base = value * scale + offset.
+// Only temperatures in degrees Celsius or Fahrenheit have an offset.
+type unit struct {
+	scale, offset float64
+	dim           dimension
+}
+
+// units are the named units; compound ones such as "km/h" or "MiB/s" are
+// made of them.
+var units = map[string]unit{
+	"m":   {scale: 1, dim: lengthDim},
+	"km":  {scale: 1e3, dim: lengthDim},
+	"cm":  {scale: 1e-2, dim: lengthDim},
+	"mm":  {scale: 1e-3, dim: lengthDim},
+	"um":  {scale: 1e-6, dim: lengthDim},
+	"nm":  {scale: 1e-9, dim: lengthDim},
+	"in":  {scale: 0.0254, dim: lengthDim},
+	"ft":  {scale: 0.3048, dim: lengthDim},
+	"yd":  {scale: 0.9144, dim: lengthDim},
+	"mi":  {scale: 1609.344, dim: lengthDim},
+	"nmi": {scale: 1852, dim: lengthDim},
+
+	"kg": {scale: 1, dim: massDim},
+	"g":  {scale: 1e-3, dim: massDim},
+	"mg": {scale: 1e-6, dim: massDim},
+	"t":  {scale: 1e3, dim: massDim},
+	"lb": {scale: 0.45359237, dim: massDim},
+	"oz": {scale: 0.45359237 / 16, dim: massDim},
+
+	"s":   {scale: 1, dim: timeDim},
+	"ms":  {scale: 1e-3, dim: timeDim},
+	"us":  {scale: 1e-6, dim: timeDim},
+	"ns":  {scale: 1e-9, dim: timeDim},
+	"min": {scale: 60, dim: timeDim},
+	"h":   {scale: 3600, dim: timeDim},
+	"d":   {scale: 86400, dim: timeDim},
+	"wk":  {scale: 7 * 86400, dim: timeDim},
+
+	"K":    {scale: 1, dim: temperatureDim},
+	"degC": {scale: 1, offset: 273.15, dim: temperatureDim},
+	"degF": {scale: 5.0 / 9, offset: 273.15 - 32*5.0/9, dim: temperatureDim},
+	"degR": {scale: 5.0 / 9, dim: temperatureDim},
+
+	"B":    {scale: 1, dim: dataSizeDim},
+	"kB":   {scale: 1e3, dim: dataSizeDim},
+	"MB":   {scale: 1e6, dim: dataSizeDim},
+	"GB":   {scale: 1e9, dim: dataSizeDim},
+	"TB":   {scale: 1e12, dim: dataSizeDim},
+	"KiB":  {scale: 1 << 10, dim: dataSizeDim},
+	"MiB":  {scale: 1 << 20, dim: dataSizeDim},
+	"GiB":  {scale: 1 << 30, dim: dataSizeDim},
+	"TiB":  {scale: 1 << 40, dim: dataSizeDim},
+	"bit":  {scale: 1.0 / 8, dim: dataSizeDim},
+	"kbit": {scale: 1e3 / 8, dim: dataSizeDim},
+	"Mbit": {scale: 1e6 / 8, dim: dataSizeDim},
+	"Gbit": {scale: 1e9 / 8, dim: dataSizeDim},
+
+	"bps":  {scale: 1.0 / 8, dim: dataRateDim},
+	"kbps": {scale: 1e3 / 8, dim: dataRateDim},
+	"Mbps": {scale: 1e6 / 8, dim: dataRateDim},
+	"Gbps": {scale: 1e9 / 8, dim: dataRateDim},
+}
+
+func init() {
+	units["°C"] = units["degC"]
+	units["°F"] = units["degF"]
+	units["µm"] = units["um"]
+	units["µs"] = units["us"]
+}
+
+// maxUnitExponent bounds the exponents of the units of a compound unit.
+const maxUnitExponent = 9
+
+// parseUnit parses a named unit, or a compound unit whose terms, named
+// units with an optional integer exponent, are multiplied with '*' and
+// divided with '/' from left to right: "kg*m/s^2" or "Mbit/s". The empty
+// unit is that of plain numbers.
+func parseUnit(s string) (unit, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return unit{scale: 1}, nil
+	}
+	if u, ok := units[s]; ok {
+		return u, nil
+	}
+	u := unit{scale: 1}
+	sign := 1
+	rest := s
+	for {
+		i := strings.IndexAny(rest, "*/")
+		if i < 0 {
+			i = len(rest)
+		}
+		term := strings.TrimSpace(rest[:i])
+		name, exp := term, 1
+		if j := strings.IndexByte(term, '^'); j >= 0 {
+			name = strings.TrimSpace(term[:j])
+			e, err := strconv.Atoi(strings.TrimSpace(term[j+1:]))
+			if err != nil || e == 0 || abs(e) > maxUnitExponent {
+				return unit{}, invalidArgument("unit %q: the exponent of %q is not a non-zero integer between -%d and %d", s, name, maxUnitExponent, maxUnitExponent)
+			}
+			exp = e
+		}
+		t, ok := units[name]
+		if name == "1" {
+			t = unit{scale: 1}
+		} else if !ok {
+			return unit{}, invalidArgument("unknown unit %q", name)
+		}
+		if t.offset != 0 {
+			return unit{}, invalidArgument("%s is not allowed in a compound unit, use K", name)
+		}
+		u.scale *= math.Pow(t.scale, float64(sign*exp))
+		u.dim = u.dim.add(t.dim.scale(exp), sign)
+		if i == len(rest) {
+			return u, nil
+		}
+		sign = 1
+		if rest[i] == '/' {
+			sign = -1
+		}
+		rest = rest[i+1:]
+	}
+}
+
+// quantity is a value with its unit, as written by the client.
+type quantity struct {
+	value float64
+	name  string
+	unit  unit
+}
+
+// quantityFromProto checks a quantity of a request; name says which in
+// errors.
+func quantityFromProto(name string, pb *calculatorpb.Quantity) (quantity, error) {
+	if pb == nil {
+		return quantity{}, invalidArgument("%s: missing", name)
+	}
+	if v := pb.GetValue(); math.IsNaN(v) || math.IsInf(v, 0) {
+		return quantity{}, invalidArgument("%s: value is %v", name, v)
+	}
+	u, err := parseUnit(pb.GetUnit())
+	if err != nil {
+		return quantity{}, err
+	}
+	return quantity{value: pb.GetValue(), name: strings.TrimSpace(pb.GetUnit()), unit: u}, nil
+}
+
+func (q quantity) base() float64 {
+	return q.value*q.unit.scale + q.unit.offset
+}
+
+// convert expresses the base value of a quantity of dimension dim in the
+// unit named to.
+func convert(base float64, dim dimension, to string) (*calculatorpb.Quantity, error) {
+	u, err := parseUnit(to)
+	if err != nil {
+		return nil, err
+	}
+	if u.dim != dim {
+		return nil, invalidArgument("cannot express a %v in %q, a unit of %v", dim, to, u.dim)
+	}
+	v := (base - u.offset) / u.scale
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil, status.Errorf(codes.OutOfRange, "the result overflows float64")
+	}
+	return &calculatorpb.Quantity{Value: v, Unit: strings.TrimSpace(to)}, nil
+}
+
+func (*server) ConvertUnits(ctx context.Context, req *calculatorpb.ConvertUnitsRequest) (*calculatorpb.ConvertUnitsResponse, error) {
+	fmt.Printf("Received ConvertUnits RPC: %v\n", req)
+	return convertUnits(req)
+}
+
+func convertUnits(req *calculatorpb.ConvertUnitsRequest) (*calculatorpb.ConvertUnitsResponse, error) {
+	q, err := quantityFromProto("quantity", req.GetQuantity())
+	if err != nil {
+		return nil, err
+	}
+	res, err := convert(q.base(), q.unit.dim, req.GetUnit())
+	if err != nil {
+		return nil, err
+	}
+	return &calculatorpb.ConvertUnitsResponse{Quantity: res}, nil
+}
+
+func (*server) CalculateQuantities(ctx context.Context, req *calculatorpb.CalculateQuantitiesRequest) (*calculatorpb.CalculateQuantitiesResponse, error) {
+	fmt.Printf("Received CalculateQuantities RPC: %v\n", req)
+	return calculateQuantities(req)
+}
+
+func calculateQuantities(req *calculatorpb.CalculateQuantitiesRequest) (*calculatorpb.CalculateQuantitiesResponse, error) {
+	op := req.GetOperation()
+	if op == calculatorpb.CalculateQuantitiesRequest_OPERATION_UNSPECIFIED {
+		return nil, invalidArgument("no operation given")
+	}
+	if _, ok := calculatorpb.CalculateQuantitiesRequest_Operation_name[int32(op)]; !ok {
+		return nil, invalidArgument("unknown operation %d", op)
+	}
+	left, err := quantityFromProto("left", req.GetLeft())
+	if err != nil {
+		return nil, err
+	}
+	right, err := quantityFromProto("right", req.GetRight())
+	if err != nil {
+		return nil, err
+	}
+
+	var base float64
+	var dim dimension
+	to := req.GetUnit()
+	switch op {
+	case calculatorpb.CalculateQuantitiesRequest_ADD, calculatorpb.CalculateQuantitiesRequest_SUBTRACT:
+		if left.unit.dim != right.unit.dim {
+			return nil, invalidArgument("cannot %s a %v (%s) and a %v (%s)", strings.ToLower(op.String()), left.unit.dim, left.name, right.unit.dim, right.name)
+		}
+		// in the unit of the left operand, so that 20 degC + 5 degC is 25 degC;
+		// the right operand is a difference, whose offset does not count, so
+		// that 20 degC + 5 K is 25 degC as well
+		r := right.value * right.unit.scale / left.unit.scale
+		v := left.value + r
+		if op == calculatorpb.CalculateQuantitiesRequest_SUBTRACT {
+			v = left.value - r
+		}
+		base, dim = quantity{value: v, unit: left.unit}.base(), left.unit.dim
+		if strings.TrimSpace(to) == "" {
+			to = left.name
+		}
+	default: // MULTIPLY, DIVIDE
+		for _, q := range []quantity{left, right} {
+			if q.unit.offset != 0 {
+				return nil, invalidArgument("cannot %s temperatures in %s, use K", strings.ToLower(op.String()), q.name)
+			}
+		}
+		if op == calculatorpb.CalculateQuantitiesRequest_MULTIPLY {
+			base, dim = left.base()*right.base(), left.unit.dim.add(right.unit.dim, 1)
+		} else {
+			if right.value == 0 {
+				return nil, invalidArgument("division by zero")
+			}
+			base, dim = left.base()/right.base(), left.unit.dim.add(right.unit.dim, -1)
+		}
+		if strings.TrimSpace(to) == "" {
+			to = dim.baseUnit()
+		}
+	}
+	res, err := convert(base, dim, to)
+	if err != nil {
+		return nil, err
+	}
+	return &calculatorpb.CalculateQuantitiesResponse{Result: res}, nil
+}
@@ -8,9 +8,12 @@ package calculatorpb
 
 import (
 	context "context"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	status "google.golang.org/genproto/googleapis/rpc/status"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
+	status1 "google.golang.org/grpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -24,6 +27,292 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Window_Type int32
+
+const (
+	Window_TUMBLING Window_Type = 0
+	Window_SLIDING  Window_Type = 1
+)
+
+// Enum value maps for Window_Type.
+var (
+	Window_Type_name = map[int32]string{
+		0: "TUMBLING",
+		1: "SLIDING",
+	}
+	Window_Type_value = map[string]int32{
+		"TUMBLING": 0,
+		"SLIDING":  1,
+	}
+)
+
+func (x Window_Type) Enum() *Window_Type {
+	p := new(Window_Type)
+	*p = x
+	return p
+}
+
+func (x Window_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Window_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_calculator_proto_enumTypes[0].Descriptor()
+}
+
+func (Window_Type) Type() protoreflect.EnumType {
+	return &file_proto_calculator_proto_enumTypes[0]
+}
+
+func (x Window_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Window_Type.Descriptor instead.
+func (Window_Type) EnumDescriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{10, 0}
+}
+
+type StreamAggregateRequest_Aggregation int32
+
+const (
+	StreamAggregateRequest_AGGREGATION_UNSPECIFIED StreamAggregateRequest_Aggregation = 0
+	StreamAggregateRequest_MAX                     StreamAggregateRequest_Aggregation = 1
+	StreamAggregateRequest_MIN                     StreamAggregateRequest_Aggregation = 2
+	StreamAggregateRequest_SUM                     StreamAggregateRequest_Aggregation = 3
+	StreamAggregateRequest_MEAN                    StreamAggregateRequest_Aggregation = 4
+	StreamAggregateRequest_COUNT                   StreamAggregateRequest_Aggregation = 5
+)
+
+// Enum value maps for StreamAggregateRequest_Aggregation.
+var (
+	StreamAggregateRequest_Aggregation_name = map[int32]string{
+		0: "AGGREGATION_UNSPECIFIED",
+		1: "MAX",
+		2: "MIN",
+		3: "SUM",
+		4: "MEAN",
+		5: "COUNT",
+	}
+	StreamAggregateRequest_Aggregation_value = map[string]int32{
+		"AGGREGATION_UNSPECIFIED": 0,
+		"MAX":                     1,
+		"MIN":                     2,
+		"SUM":                     3,
+		"MEAN":                    4,
+		"COUNT":                   5,
+	}
+)
+
+func (x StreamAggregateRequest_Aggregation) Enum() *StreamAggregateRequest_Aggregation {
+	p := new(StreamAggregateRequest_Aggregation)
+	*p = x
+	return p
+}
+
+func (x StreamAggregateRequest_Aggregation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StreamAggregateRequest_Aggregation) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_calculator_proto_enumTypes[1].Descriptor()
+}
+
+func (StreamAggregateRequest_Aggregation) Type() protoreflect.EnumType {
+	return &file_proto_calculator_proto_enumTypes[1]
+}
+
+func (x StreamAggregateRequest_Aggregation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StreamAggregateRequest_Aggregation.Descriptor instead.
+func (StreamAggregateRequest_Aggregation) EnumDescriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{11, 0}
+}
+
+type CalculateRequest_Operation int32
+
+const (
+	CalculateRequest_OPERATION_UNSPECIFIED CalculateRequest_Operation = 0
+	// ADD and MULTIPLY take one or more operands, SUBTRACT and DIVIDE
+	// two or more, applied from left to right
+	CalculateRequest_ADD      CalculateRequest_Operation = 1
+	CalculateRequest_SUBTRACT CalculateRequest_Operation = 2
+	CalculateRequest_MULTIPLY CalculateRequest_Operation = 3
+	CalculateRequest_DIVIDE   CalculateRequest_Operation = 4
+	// of two operands, INTEGER mode only; with DIVIDE, the Euclidean
+	// division whose remainder is never negative
+	CalculateRequest_MODULO CalculateRequest_Operation = 5
+	// of two operands, the second being an integer exponent
+	CalculateRequest_POWER CalculateRequest_Operation = 6
+	// of one operand; the integer square root, rounded down, in INTEGER mode
+	CalculateRequest_SQUARE_ROOT CalculateRequest_Operation = 7
+)
+
+// Enum value maps for CalculateRequest_Operation.
+var (
+	CalculateRequest_Operation_name = map[int32]string{
+		0: "OPERATION_UNSPECIFIED",
+		1: "ADD",
+		2: "SUBTRACT",
+		3: "MULTIPLY",
+		4: "DIVIDE",
+		5: "MODULO",
+		6: "POWER",
+		7: "SQUARE_ROOT",
+	}
+	CalculateRequest_Operation_value = map[string]int32{
+		"OPERATION_UNSPECIFIED": 0,
+		"ADD":                   1,
+		"SUBTRACT":              2,
+		"MULTIPLY":              3,
+		"DIVIDE":                4,
+		"MODULO":                5,
+		"POWER":                 6,
+		"SQUARE_ROOT":           7,
+	}
+)
+
+func (x CalculateRequest_Operation) Enum() *CalculateRequest_Operation {
+	p := new(CalculateRequest_Operation)
+	*p = x
+	return p
+}
+
+func (x CalculateRequest_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CalculateRequest_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_calculator_proto_enumTypes[2].Descriptor()
+}
+
+func (CalculateRequest_Operation) Type() protoreflect.EnumType {
+	return &file_proto_calculator_proto_enumTypes[2]
+}
+
+func (x CalculateRequest_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CalculateRequest_Operation.Descriptor instead.
+func (CalculateRequest_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{24, 0}
+}
+
+type CalculateRequest_Mode int32
+
+const (
+	// operands such as "-123456789012345678901234567890"
+	CalculateRequest_INTEGER CalculateRequest_Mode = 0
+	// operands such as "1/3", "0.25" or "-2.5e-3", computed exactly
+	CalculateRequest_RATIONAL CalculateRequest_Mode = 1
+	// decimal operands such as "3.14159", computed with the requested precision
+	CalculateRequest_FLOAT CalculateRequest_Mode = 2
+)
+
+// Enum value maps for CalculateRequest_Mode.
+var (
+	CalculateRequest_Mode_name = map[int32]string{
+		0: "INTEGER",
+		1: "RATIONAL",
+		2: "FLOAT",
+	}
+	CalculateRequest_Mode_value = map[string]int32{
+		"INTEGER":  0,
+		"RATIONAL": 1,
+		"FLOAT":    2,
+	}
+)
+
+func (x CalculateRequest_Mode) Enum() *CalculateRequest_Mode {
+	p := new(CalculateRequest_Mode)
+	*p = x
+	return p
+}
+
+func (x CalculateRequest_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CalculateRequest_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_calculator_proto_enumTypes[3].Descriptor()
+}
+
+func (CalculateRequest_Mode) Type() protoreflect.EnumType {
+	return &file_proto_calculator_proto_enumTypes[3]
+}
+
+func (x CalculateRequest_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CalculateRequest_Mode.Descriptor instead.
+func (CalculateRequest_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{24, 1}
+}
+
+type CalculateQuantitiesRequest_Operation int32
+
+const (
+	CalculateQuantitiesRequest_OPERATION_UNSPECIFIED CalculateQuantitiesRequest_Operation = 0
+	// of quantities of the same dimension, in the unit of the left one.
+	// The right one of temperatures is a difference: 20 degC + 5 K is
+	// 25 degC.
+	CalculateQuantitiesRequest_ADD      CalculateQuantitiesRequest_Operation = 1
+	CalculateQuantitiesRequest_SUBTRACT CalculateQuantitiesRequest_Operation = 2
+	CalculateQuantitiesRequest_MULTIPLY CalculateQuantitiesRequest_Operation = 3
+	CalculateQuantitiesRequest_DIVIDE   CalculateQuantitiesRequest_Operation = 4
+)
+
+// Enum value maps for CalculateQuantitiesRequest_Operation.
+var (
+	CalculateQuantitiesRequest_Operation_name = map[int32]string{
+		0: "OPERATION_UNSPECIFIED",
+		1: "ADD",
+		2: "SUBTRACT",
+		3: "MULTIPLY",
+		4: "DIVIDE",
+	}
+	CalculateQuantitiesRequest_Operation_value = map[string]int32{
+		"OPERATION_UNSPECIFIED": 0,
+		"ADD":                   1,
+		"SUBTRACT":              2,
+		"MULTIPLY":              3,
+		"DIVIDE":                4,
+	}
+)
+
+func (x CalculateQuantitiesRequest_Operation) Enum() *CalculateQuantitiesRequest_Operation {
+	p := new(CalculateQuantitiesRequest_Operation)
+	*p = x
+	return p
+}
+
+func (x CalculateQuantitiesRequest_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CalculateQuantitiesRequest_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_calculator_proto_enumTypes[4].Descriptor()
+}
+
+func (CalculateQuantitiesRequest_Operation) Type() protoreflect.EnumType {
+	return &file_proto_calculator_proto_enumTypes[4]
+}
+
+func (x CalculateQuantitiesRequest_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CalculateQuantitiesRequest_Operation.Descriptor instead.
+func (CalculateQuantitiesRequest_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{31, 0}
+}
+
+// the int32 RPCs fail with OUT_OF_RANGE when a result does not fit, see
+// Calculate for big numbers
 type SumRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -31,6 +320,8 @@ type SumRequest struct {
 
 	FirstNumber  int32 `protobuf:"varint,1,opt,name=first_number,json=firstNumber,proto3" json:"first_number,omitempty"`
 	SecondNumber int32 `protobuf:"varint,2,opt,name=second_number,json=secondNumber,proto3" json:"second_number,omitempty"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,3,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
 }
 
 func (x *SumRequest) Reset() {
@@ -79,6 +370,13 @@ func (x *SumRequest) GetSecondNumber() int32 {
 	return 0
 }
 
+func (x *SumRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
 type SumResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -132,6 +430,10 @@ type PrimeNumberDecompositionRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// a decimal number of up to 1000 digits, used instead of number when set
+	BigNumber string `protobuf:"bytes,2,opt,name=big_number,json=bigNumber,proto3" json:"big_number,omitempty"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,3,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
 }
 
 func (x *PrimeNumberDecompositionRequest) Reset() {
@@ -173,12 +475,29 @@ func (x *PrimeNumberDecompositionRequest) GetNumber() int64 {
 	return 0
 }
 
+func (x *PrimeNumberDecompositionRequest) GetBigNumber() string {
+	if x != nil {
+		return x.BigNumber
+	}
+	return ""
+}
+
+func (x *PrimeNumberDecompositionRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
 type PrimeNumberDecompositionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// zero when the factor does not fit in an int64
 	PrimeFactor int64 `protobuf:"varint,1,opt,name=prime_factor,json=primeFactor,proto3" json:"prime_factor,omitempty"`
+	// the factor in decimal, always set
+	BigPrimeFactor string `protobuf:"bytes,2,opt,name=big_prime_factor,json=bigPrimeFactor,proto3" json:"big_prime_factor,omitempty"`
 }
 
 func (x *PrimeNumberDecompositionResponse) Reset() {
@@ -220,6 +539,13 @@ func (x *PrimeNumberDecompositionResponse) GetPrimeFactor() int64 {
 	return 0
 }
 
+func (x *PrimeNumberDecompositionResponse) GetBigPrimeFactor() string {
+	if x != nil {
+		return x.BigPrimeFactor
+	}
+	return ""
+}
+
 type ComputeAverageRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -314,16 +640,16 @@ func (x *ComputeAverageResponse) GetAverage() float64 {
 	return 0
 }
 
-type FindMaximumRequest struct {
+type ComputeStatisticsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Number int32 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Number float64 `protobuf:"fixed64,1,opt,name=number,proto3" json:"number,omitempty"`
 }
 
-func (x *FindMaximumRequest) Reset() {
-	*x = FindMaximumRequest{}
+func (x *ComputeStatisticsRequest) Reset() {
+	*x = ComputeStatisticsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_calculator_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -331,13 +657,13 @@ func (x *FindMaximumRequest) Reset() {
 	}
 }
 
-func (x *FindMaximumRequest) String() string {
+func (x *ComputeStatisticsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FindMaximumRequest) ProtoMessage() {}
+func (*ComputeStatisticsRequest) ProtoMessage() {}
 
-func (x *FindMaximumRequest) ProtoReflect() protoreflect.Message {
+func (x *ComputeStatisticsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_calculator_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -349,28 +675,38 @@ func (x *FindMaximumRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FindMaximumRequest.ProtoReflect.Descriptor instead.
-func (*FindMaximumRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ComputeStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*ComputeStatisticsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_calculator_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *FindMaximumRequest) GetNumber() int32 {
+func (x *ComputeStatisticsRequest) GetNumber() float64 {
 	if x != nil {
 		return x.Number
 	}
 	return 0
 }
 
-type FindMaximumResponse struct {
+type ComputeStatisticsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Maximum int32 `protobuf:"varint,1,opt,name=maximum,proto3" json:"maximum,omitempty"`
+	Count int64   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Sum   float64 `protobuf:"fixed64,2,opt,name=sum,proto3" json:"sum,omitempty"`
+	Mean  float64 `protobuf:"fixed64,3,opt,name=mean,proto3" json:"mean,omitempty"`
+	// the sample variance, with count - 1 as denominator
+	Variance float64 `protobuf:"fixed64,4,opt,name=variance,proto3" json:"variance,omitempty"`
+	Stddev   float64 `protobuf:"fixed64,5,opt,name=stddev,proto3" json:"stddev,omitempty"`
+	Min      float64 `protobuf:"fixed64,6,opt,name=min,proto3" json:"min,omitempty"`
+	Max      float64 `protobuf:"fixed64,7,opt,name=max,proto3" json:"max,omitempty"`
+	// the 25th, 50th, 75th, 90th, 95th and 99th percentiles: exact up to
+	// 4096 numbers, estimated from a uniform sample of them beyond
+	Percentiles []*ComputeStatisticsResponse_Percentile `protobuf:"bytes,8,rep,name=percentiles,proto3" json:"percentiles,omitempty"`
 }
 
-func (x *FindMaximumResponse) Reset() {
-	*x = FindMaximumResponse{}
+func (x *ComputeStatisticsResponse) Reset() {
+	*x = ComputeStatisticsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_calculator_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -378,13 +714,13 @@ func (x *FindMaximumResponse) Reset() {
 	}
 }
 
-func (x *FindMaximumResponse) String() string {
+func (x *ComputeStatisticsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FindMaximumResponse) ProtoMessage() {}
+func (*ComputeStatisticsResponse) ProtoMessage() {}
 
-func (x *FindMaximumResponse) ProtoReflect() protoreflect.Message {
+func (x *ComputeStatisticsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_calculator_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -396,19 +732,68 @@ func (x *FindMaximumResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FindMaximumResponse.ProtoReflect.Descriptor instead.
-func (*FindMaximumResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ComputeStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*ComputeStatisticsResponse) Descriptor() ([]byte, []int) {
 	return file_proto_calculator_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *FindMaximumResponse) GetMaximum() int32 {
+func (x *ComputeStatisticsResponse) GetCount() int64 {
 	if x != nil {
-		return x.Maximum
+		return x.Count
 	}
 	return 0
 }
 
-type SquareRootRequest struct {
+func (x *ComputeStatisticsResponse) GetSum() float64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+func (x *ComputeStatisticsResponse) GetMean() float64 {
+	if x != nil {
+		return x.Mean
+	}
+	return 0
+}
+
+func (x *ComputeStatisticsResponse) GetVariance() float64 {
+	if x != nil {
+		return x.Variance
+	}
+	return 0
+}
+
+func (x *ComputeStatisticsResponse) GetStddev() float64 {
+	if x != nil {
+		return x.Stddev
+	}
+	return 0
+}
+
+func (x *ComputeStatisticsResponse) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *ComputeStatisticsResponse) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *ComputeStatisticsResponse) GetPercentiles() []*ComputeStatisticsResponse_Percentile {
+	if x != nil {
+		return x.Percentiles
+	}
+	return nil
+}
+
+type FindMaximumRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -416,8 +801,8 @@ type SquareRootRequest struct {
 	Number int32 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
 }
 
-func (x *SquareRootRequest) Reset() {
-	*x = SquareRootRequest{}
+func (x *FindMaximumRequest) Reset() {
+	*x = FindMaximumRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_calculator_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -425,13 +810,13 @@ func (x *SquareRootRequest) Reset() {
 	}
 }
 
-func (x *SquareRootRequest) String() string {
+func (x *FindMaximumRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SquareRootRequest) ProtoMessage() {}
+func (*FindMaximumRequest) ProtoMessage() {}
 
-func (x *SquareRootRequest) ProtoReflect() protoreflect.Message {
+func (x *FindMaximumRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_calculator_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -443,28 +828,28 @@ func (x *SquareRootRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SquareRootRequest.ProtoReflect.Descriptor instead.
-func (*SquareRootRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FindMaximumRequest.ProtoReflect.Descriptor instead.
+func (*FindMaximumRequest) Descriptor() ([]byte, []int) {
 	return file_proto_calculator_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *SquareRootRequest) GetNumber() int32 {
+func (x *FindMaximumRequest) GetNumber() int32 {
 	if x != nil {
 		return x.Number
 	}
 	return 0
 }
 
-type SquareRootResponse struct {
+type FindMaximumResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	NumberRoot float64 `protobuf:"fixed64,1,opt,name=number_root,json=numberRoot,proto3" json:"number_root,omitempty"`
+	Maximum int32 `protobuf:"varint,1,opt,name=maximum,proto3" json:"maximum,omitempty"`
 }
 
-func (x *SquareRootResponse) Reset() {
-	*x = SquareRootResponse{}
+func (x *FindMaximumResponse) Reset() {
+	*x = FindMaximumResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_calculator_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -472,13 +857,13 @@ func (x *SquareRootResponse) Reset() {
 	}
 }
 
-func (x *SquareRootResponse) String() string {
+func (x *FindMaximumResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SquareRootResponse) ProtoMessage() {}
+func (*FindMaximumResponse) ProtoMessage() {}
 
-func (x *SquareRootResponse) ProtoReflect() protoreflect.Message {
+func (x *FindMaximumResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_calculator_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -490,138 +875,3545 @@ func (x *SquareRootResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SquareRootResponse.ProtoReflect.Descriptor instead.
-func (*SquareRootResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use FindMaximumResponse.ProtoReflect.Descriptor instead.
+func (*FindMaximumResponse) Descriptor() ([]byte, []int) {
 	return file_proto_calculator_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *SquareRootResponse) GetNumberRoot() float64 {
+func (x *FindMaximumResponse) GetMaximum() int32 {
 	if x != nil {
-		return x.NumberRoot
+		return x.Maximum
 	}
 	return 0
 }
 
-var File_proto_calculator_proto protoreflect.FileDescriptor
+// a window of a stream of numbers, by count or by time. Tumbling windows
+// follow each other; sliding ones start every slide numbers or slide_duration
+// and overlap when that is shorter than the window.
+type Window struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_proto_calculator_proto_rawDesc = []byte{
-	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
-	0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
-	0x61, 0x74, 0x6f, 0x72, 0x22, 0x54, 0x0a, 0x0a, 0x53, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x5f,
-	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x65,
-	0x63, 0x6f, 0x6e, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x2c, 0x0a, 0x0b, 0x53, 0x75,
-	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x6d,
-	0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73,
-	0x75, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x39, 0x0a, 0x1f, 0x50, 0x72, 0x69, 0x6d,
-	0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x22, 0x45, 0x0a, 0x20, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x4e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x6d, 0x65,
-	0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x70,
-	0x72, 0x69, 0x6d, 0x65, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x2f, 0x0a, 0x15, 0x43, 0x6f,
-	0x6d, 0x70, 0x75, 0x74, 0x65, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x32, 0x0a, 0x16, 0x43,
-	0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x22,
-	0x2c, 0x0a, 0x12, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x2f, 0x0a,
-	0x13, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x22, 0x2b,
-	0x0a, 0x11, 0x53, 0x71, 0x75, 0x61, 0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x35, 0x0a, 0x12, 0x53,
-	0x71, 0x75, 0x61, 0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x72, 0x6f, 0x6f, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x6f,
-	0x6f, 0x74, 0x32, 0xca, 0x03, 0x0a, 0x11, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f,
-	0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x03, 0x53, 0x75, 0x6d, 0x12,
-	0x16, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x6d,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
-	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x79, 0x0a, 0x18, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b,
-	0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x50, 0x72, 0x69, 0x6d,
-	0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x63, 0x61,
-	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x4e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x5b, 0x0a,
-	0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12,
-	0x21, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6d,
-	0x70, 0x75, 0x74, 0x65, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e,
-	0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x54, 0x0a, 0x0b, 0x46, 0x69,
-	0x6e, 0x64, 0x4d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x12, 0x1e, 0x2e, 0x63, 0x61, 0x6c, 0x63,
-	0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x78, 0x69, 0x6d,
-	0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x63, 0x61, 0x6c, 0x63,
-	0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x78, 0x69, 0x6d,
-	0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01,
-	0x12, 0x4d, 0x0a, 0x0a, 0x53, 0x71, 0x75, 0x61, 0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x1d,
-	0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x71, 0x75, 0x61,
-	0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
-	0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x71, 0x75, 0x61, 0x72,
-	0x65, 0x52, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
-	0x1b, 0x5a, 0x19, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x70, 0x62, 0x3b,
-	0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	Type Window_Type `protobuf:"varint,1,opt,name=type,proto3,enum=calculator.Window_Type" json:"type,omitempty"`
+	// a window by count holds size numbers, up to 100000
+	Size  uint32 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Slide uint32 `protobuf:"varint,3,opt,name=slide,proto3" json:"slide,omitempty"`
+	// a window by time lasts duration, from 10ms to 1h, counted from the
+	// start of the stream; numbers are timed when the server receives them
+	Duration      *duration.Duration `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	SlideDuration *duration.Duration `protobuf:"bytes,5,opt,name=slide_duration,json=slideDuration,proto3" json:"slide_duration,omitempty"`
 }
 
-var (
-	file_proto_calculator_proto_rawDescOnce sync.Once
-	file_proto_calculator_proto_rawDescData = file_proto_calculator_proto_rawDesc
-)
+func (x *Window) Reset() {
+	*x = Window{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_proto_calculator_proto_rawDescGZIP() []byte {
-	file_proto_calculator_proto_rawDescOnce.Do(func() {
-		file_proto_calculator_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_calculator_proto_rawDescData)
-	})
-	return file_proto_calculator_proto_rawDescData
+func (x *Window) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_proto_calculator_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
-var file_proto_calculator_proto_goTypes = []interface{}{
-	(*SumRequest)(nil),                       // 0: calculator.SumRequest
-	(*SumResponse)(nil),                      // 1: calculator.SumResponse
-	(*PrimeNumberDecompositionRequest)(nil),  // 2: calculator.PrimeNumberDecompositionRequest
-	(*PrimeNumberDecompositionResponse)(nil), // 3: calculator.PrimeNumberDecompositionResponse
-	(*ComputeAverageRequest)(nil),            // 4: calculator.ComputeAverageRequest
-	(*ComputeAverageResponse)(nil),           // 5: calculator.ComputeAverageResponse
-	(*FindMaximumRequest)(nil),               // 6: calculator.FindMaximumRequest
-	(*FindMaximumResponse)(nil),              // 7: calculator.FindMaximumResponse
-	(*SquareRootRequest)(nil),                // 8: calculator.SquareRootRequest
-	(*SquareRootResponse)(nil),               // 9: calculator.SquareRootResponse
+func (*Window) ProtoMessage() {}
+
+func (x *Window) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_proto_calculator_proto_depIdxs = []int32{
-	0, // 0: calculator.CalculatorService.Sum:input_type -> calculator.SumRequest
-	2, // 1: calculator.CalculatorService.PrimeNumberDecomposition:input_type -> calculator.PrimeNumberDecompositionRequest
-	4, // 2: calculator.CalculatorService.ComputeAverage:input_type -> calculator.ComputeAverageRequest
-	6, // 3: calculator.CalculatorService.FindMaximum:input_type -> calculator.FindMaximumRequest
-	8, // 4: calculator.CalculatorService.SquareRoot:input_type -> calculator.SquareRootRequest
-	1, // 5: calculator.CalculatorService.Sum:output_type -> calculator.SumResponse
-	3, // 6: calculator.CalculatorService.PrimeNumberDecomposition:output_type -> calculator.PrimeNumberDecompositionResponse
-	5, // 7: calculator.CalculatorService.ComputeAverage:output_type -> calculator.ComputeAverageResponse
-	7, // 8: calculator.CalculatorService.FindMaximum:output_type -> calculator.FindMaximumResponse
-	9, // 9: calculator.CalculatorService.SquareRoot:output_type -> calculator.SquareRootResponse
-	5, // [5:10] is the sub-list for method output_type
-	0, // [0:5] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+// Deprecated: Use Window.ProtoReflect.Descriptor instead.
+func (*Window) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{10}
 }
 
-func init() { file_proto_calculator_proto_init() }
-func file_proto_calculator_proto_init() {
-	if File_proto_calculator_proto != nil {
+func (x *Window) GetType() Window_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Window_TUMBLING
+}
+
+func (x *Window) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Window) GetSlide() uint32 {
+	if x != nil {
+		return x.Slide
+	}
+	return 0
+}
+
+func (x *Window) GetDuration() *duration.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *Window) GetSlideDuration() *duration.Duration {
+	if x != nil {
+		return x.SlideDuration
+	}
+	return nil
+}
+
+type StreamAggregateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the first message of the stream is the config, the others numbers
+	//
+	// Types that are assignable to Message:
+	//	*StreamAggregateRequest_Config_
+	//	*StreamAggregateRequest_Number
+	Message isStreamAggregateRequest_Message `protobuf_oneof:"message"`
+}
+
+func (x *StreamAggregateRequest) Reset() {
+	*x = StreamAggregateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamAggregateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAggregateRequest) ProtoMessage() {}
+
+func (x *StreamAggregateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAggregateRequest.ProtoReflect.Descriptor instead.
+func (*StreamAggregateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{11}
+}
+
+func (m *StreamAggregateRequest) GetMessage() isStreamAggregateRequest_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *StreamAggregateRequest) GetConfig() *StreamAggregateRequest_Config {
+	if x, ok := x.GetMessage().(*StreamAggregateRequest_Config_); ok {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *StreamAggregateRequest) GetNumber() float64 {
+	if x, ok := x.GetMessage().(*StreamAggregateRequest_Number); ok {
+		return x.Number
+	}
+	return 0
+}
+
+type isStreamAggregateRequest_Message interface {
+	isStreamAggregateRequest_Message()
+}
+
+type StreamAggregateRequest_Config_ struct {
+	Config *StreamAggregateRequest_Config `protobuf:"bytes,1,opt,name=config,proto3,oneof"`
+}
+
+type StreamAggregateRequest_Number struct {
+	Number float64 `protobuf:"fixed64,2,opt,name=number,proto3,oneof"`
+}
+
+func (*StreamAggregateRequest_Config_) isStreamAggregateRequest_Message() {}
+
+func (*StreamAggregateRequest_Number) isStreamAggregateRequest_Message() {}
+
+type StreamAggregateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	// the numbers in the window
+	Count int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// the positions of the numbers of a window by count, from 0, end excluded
+	StartIndex int64 `protobuf:"varint,3,opt,name=start_index,json=startIndex,proto3" json:"start_index,omitempty"`
+	EndIndex   int64 `protobuf:"varint,4,opt,name=end_index,json=endIndex,proto3" json:"end_index,omitempty"`
+	// the bounds of a window by time, end excluded
+	StartTime *timestamp.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamp.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// set for the windows cut short by the end of the stream
+	Partial bool `protobuf:"varint,7,opt,name=partial,proto3" json:"partial,omitempty"`
+}
+
+func (x *StreamAggregateResponse) Reset() {
+	*x = StreamAggregateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamAggregateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAggregateResponse) ProtoMessage() {}
+
+func (x *StreamAggregateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAggregateResponse.ProtoReflect.Descriptor instead.
+func (*StreamAggregateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StreamAggregateResponse) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *StreamAggregateResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *StreamAggregateResponse) GetStartIndex() int64 {
+	if x != nil {
+		return x.StartIndex
+	}
+	return 0
+}
+
+func (x *StreamAggregateResponse) GetEndIndex() int64 {
+	if x != nil {
+		return x.EndIndex
+	}
+	return 0
+}
+
+func (x *StreamAggregateResponse) GetStartTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *StreamAggregateResponse) GetEndTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *StreamAggregateResponse) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+type Vector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []float64 `protobuf:"fixed64,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *Vector) Reset() {
+	*x = Vector{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Vector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vector) ProtoMessage() {}
+
+func (x *Vector) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vector.ProtoReflect.Descriptor instead.
+func (*Vector) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Vector) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// a matrix of rows x columns numbers, up to 500 x 500
+type Matrix struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rows    uint32 `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Columns uint32 `protobuf:"varint,2,opt,name=columns,proto3" json:"columns,omitempty"`
+	// row after row, rows * columns values
+	Values []float64 `protobuf:"fixed64,3,rep,packed,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *Matrix) Reset() {
+	*x = Matrix{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Matrix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Matrix) ProtoMessage() {}
+
+func (x *Matrix) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Matrix.ProtoReflect.Descriptor instead.
+func (*Matrix) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Matrix) GetRows() uint32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *Matrix) GetColumns() uint32 {
+	if x != nil {
+		return x.Columns
+	}
+	return 0
+}
+
+func (x *Matrix) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type MultiplyMatricesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Left *Matrix `protobuf:"bytes,1,opt,name=left,proto3" json:"left,omitempty"`
+	// a matrix, or a vector taken as a column
+	//
+	// Types that are assignable to Right:
+	//	*MultiplyMatricesRequest_Matrix
+	//	*MultiplyMatricesRequest_Vector
+	Right isMultiplyMatricesRequest_Right `protobuf_oneof:"right"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,4,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+}
+
+func (x *MultiplyMatricesRequest) Reset() {
+	*x = MultiplyMatricesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultiplyMatricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiplyMatricesRequest) ProtoMessage() {}
+
+func (x *MultiplyMatricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiplyMatricesRequest.ProtoReflect.Descriptor instead.
+func (*MultiplyMatricesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MultiplyMatricesRequest) GetLeft() *Matrix {
+	if x != nil {
+		return x.Left
+	}
+	return nil
+}
+
+func (m *MultiplyMatricesRequest) GetRight() isMultiplyMatricesRequest_Right {
+	if m != nil {
+		return m.Right
+	}
+	return nil
+}
+
+func (x *MultiplyMatricesRequest) GetMatrix() *Matrix {
+	if x, ok := x.GetRight().(*MultiplyMatricesRequest_Matrix); ok {
+		return x.Matrix
+	}
+	return nil
+}
+
+func (x *MultiplyMatricesRequest) GetVector() *Vector {
+	if x, ok := x.GetRight().(*MultiplyMatricesRequest_Vector); ok {
+		return x.Vector
+	}
+	return nil
+}
+
+func (x *MultiplyMatricesRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+type isMultiplyMatricesRequest_Right interface {
+	isMultiplyMatricesRequest_Right()
+}
+
+type MultiplyMatricesRequest_Matrix struct {
+	Matrix *Matrix `protobuf:"bytes,2,opt,name=matrix,proto3,oneof"`
+}
+
+type MultiplyMatricesRequest_Vector struct {
+	Vector *Vector `protobuf:"bytes,3,opt,name=vector,proto3,oneof"`
+}
+
+func (*MultiplyMatricesRequest_Matrix) isMultiplyMatricesRequest_Right() {}
+
+func (*MultiplyMatricesRequest_Vector) isMultiplyMatricesRequest_Right() {}
+
+type MultiplyMatricesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// a matrix, or a vector when the right operand is one
+	//
+	// Types that are assignable to Result:
+	//	*MultiplyMatricesResponse_Matrix
+	//	*MultiplyMatricesResponse_Vector
+	Result isMultiplyMatricesResponse_Result `protobuf_oneof:"result"`
+}
+
+func (x *MultiplyMatricesResponse) Reset() {
+	*x = MultiplyMatricesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultiplyMatricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiplyMatricesResponse) ProtoMessage() {}
+
+func (x *MultiplyMatricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiplyMatricesResponse.ProtoReflect.Descriptor instead.
+func (*MultiplyMatricesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{16}
+}
+
+func (m *MultiplyMatricesResponse) GetResult() isMultiplyMatricesResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *MultiplyMatricesResponse) GetMatrix() *Matrix {
+	if x, ok := x.GetResult().(*MultiplyMatricesResponse_Matrix); ok {
+		return x.Matrix
+	}
+	return nil
+}
+
+func (x *MultiplyMatricesResponse) GetVector() *Vector {
+	if x, ok := x.GetResult().(*MultiplyMatricesResponse_Vector); ok {
+		return x.Vector
+	}
+	return nil
+}
+
+type isMultiplyMatricesResponse_Result interface {
+	isMultiplyMatricesResponse_Result()
+}
+
+type MultiplyMatricesResponse_Matrix struct {
+	Matrix *Matrix `protobuf:"bytes,1,opt,name=matrix,proto3,oneof"`
+}
+
+type MultiplyMatricesResponse_Vector struct {
+	Vector *Vector `protobuf:"bytes,2,opt,name=vector,proto3,oneof"`
+}
+
+func (*MultiplyMatricesResponse_Matrix) isMultiplyMatricesResponse_Result() {}
+
+func (*MultiplyMatricesResponse_Vector) isMultiplyMatricesResponse_Result() {}
+
+type MatrixRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Matrix *Matrix `protobuf:"bytes,1,opt,name=matrix,proto3" json:"matrix,omitempty"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,2,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+}
+
+func (x *MatrixRequest) Reset() {
+	*x = MatrixRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatrixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatrixRequest) ProtoMessage() {}
+
+func (x *MatrixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatrixRequest.ProtoReflect.Descriptor instead.
+func (*MatrixRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MatrixRequest) GetMatrix() *Matrix {
+	if x != nil {
+		return x.Matrix
+	}
+	return nil
+}
+
+func (x *MatrixRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+type MatrixResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Matrix *Matrix `protobuf:"bytes,1,opt,name=matrix,proto3" json:"matrix,omitempty"`
+}
+
+func (x *MatrixResponse) Reset() {
+	*x = MatrixResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatrixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatrixResponse) ProtoMessage() {}
+
+func (x *MatrixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatrixResponse.ProtoReflect.Descriptor instead.
+func (*MatrixResponse) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MatrixResponse) GetMatrix() *Matrix {
+	if x != nil {
+		return x.Matrix
+	}
+	return nil
+}
+
+type DeterminantResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Determinant float64 `protobuf:"fixed64,1,opt,name=determinant,proto3" json:"determinant,omitempty"`
+}
+
+func (x *DeterminantResponse) Reset() {
+	*x = DeterminantResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeterminantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeterminantResponse) ProtoMessage() {}
+
+func (x *DeterminantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeterminantResponse.ProtoReflect.Descriptor instead.
+func (*DeterminantResponse) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeterminantResponse) GetDeterminant() float64 {
+	if x != nil {
+		return x.Determinant
+	}
+	return 0
+}
+
+// the system coefficients * x = constants
+type SolveLinearSystemRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Coefficients *Matrix `protobuf:"bytes,1,opt,name=coefficients,proto3" json:"coefficients,omitempty"`
+	Constants    *Vector `protobuf:"bytes,2,opt,name=constants,proto3" json:"constants,omitempty"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,3,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+}
+
+func (x *SolveLinearSystemRequest) Reset() {
+	*x = SolveLinearSystemRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SolveLinearSystemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SolveLinearSystemRequest) ProtoMessage() {}
+
+func (x *SolveLinearSystemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SolveLinearSystemRequest.ProtoReflect.Descriptor instead.
+func (*SolveLinearSystemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SolveLinearSystemRequest) GetCoefficients() *Matrix {
+	if x != nil {
+		return x.Coefficients
+	}
+	return nil
+}
+
+func (x *SolveLinearSystemRequest) GetConstants() *Vector {
+	if x != nil {
+		return x.Constants
+	}
+	return nil
+}
+
+func (x *SolveLinearSystemRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+type SolveLinearSystemResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Solution *Vector `protobuf:"bytes,1,opt,name=solution,proto3" json:"solution,omitempty"`
+}
+
+func (x *SolveLinearSystemResponse) Reset() {
+	*x = SolveLinearSystemResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SolveLinearSystemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SolveLinearSystemResponse) ProtoMessage() {}
+
+func (x *SolveLinearSystemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SolveLinearSystemResponse.ProtoReflect.Descriptor instead.
+func (*SolveLinearSystemResponse) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SolveLinearSystemResponse) GetSolution() *Vector {
+	if x != nil {
+		return x.Solution
+	}
+	return nil
+}
+
+type SquareRootRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number int32 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,2,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+}
+
+func (x *SquareRootRequest) Reset() {
+	*x = SquareRootRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SquareRootRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SquareRootRequest) ProtoMessage() {}
+
+func (x *SquareRootRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SquareRootRequest.ProtoReflect.Descriptor instead.
+func (*SquareRootRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SquareRootRequest) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *SquareRootRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+type SquareRootResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NumberRoot float64 `protobuf:"fixed64,1,opt,name=number_root,json=numberRoot,proto3" json:"number_root,omitempty"`
+}
+
+func (x *SquareRootResponse) Reset() {
+	*x = SquareRootResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SquareRootResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SquareRootResponse) ProtoMessage() {}
+
+func (x *SquareRootResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SquareRootResponse.ProtoReflect.Descriptor instead.
+func (*SquareRootResponse) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SquareRootResponse) GetNumberRoot() float64 {
+	if x != nil {
+		return x.NumberRoot
+	}
+	return 0
+}
+
+type CalculateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operation CalculateRequest_Operation `protobuf:"varint,1,opt,name=operation,proto3,enum=calculator.CalculateRequest_Operation" json:"operation,omitempty"`
+	// decimal strings
+	Operands []string              `protobuf:"bytes,2,rep,name=operands,proto3" json:"operands,omitempty"`
+	Mode     CalculateRequest_Mode `protobuf:"varint,3,opt,name=mode,proto3,enum=calculator.CalculateRequest_Mode" json:"mode,omitempty"`
+	// significant digits of FLOAT results, 34 when zero, 10000 at most
+	Precision uint32 `protobuf:"varint,4,opt,name=precision,proto3" json:"precision,omitempty"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,5,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+}
+
+func (x *CalculateRequest) Reset() {
+	*x = CalculateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CalculateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateRequest) ProtoMessage() {}
+
+func (x *CalculateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateRequest.ProtoReflect.Descriptor instead.
+func (*CalculateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CalculateRequest) GetOperation() CalculateRequest_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return CalculateRequest_OPERATION_UNSPECIFIED
+}
+
+func (x *CalculateRequest) GetOperands() []string {
+	if x != nil {
+		return x.Operands
+	}
+	return nil
+}
+
+func (x *CalculateRequest) GetMode() CalculateRequest_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return CalculateRequest_INTEGER
+}
+
+func (x *CalculateRequest) GetPrecision() uint32 {
+	if x != nil {
+		return x.Precision
+	}
+	return 0
+}
+
+func (x *CalculateRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+type CalculateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// a decimal integer, a reduced fraction such as "-7/3", or a float such
+	// as "3.333333333333333333333333333333333"
+	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *CalculateResponse) Reset() {
+	*x = CalculateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CalculateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateResponse) ProtoMessage() {}
+
+func (x *CalculateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateResponse.ProtoReflect.Descriptor instead.
+func (*CalculateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CalculateResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+type EvaluateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// an arithmetic expression such as "2 * (x + 1) ^ 2 - sqrt(y)"
+	Expression string `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	// the values of the variables of the expression
+	Variables map[string]float64 `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,3,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+}
+
+func (x *EvaluateRequest) Reset() {
+	*x = EvaluateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvaluateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateRequest) ProtoMessage() {}
+
+func (x *EvaluateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *EvaluateRequest) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetVariables() map[string]float64 {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *EvaluateRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+type EvaluateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result float64 `protobuf:"fixed64,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *EvaluateResponse) Reset() {
+	*x = EvaluateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvaluateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateResponse) ProtoMessage() {}
+
+func (x *EvaluateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *EvaluateResponse) GetResult() float64 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+// a value with its unit: a named unit such as "km", "lb", "h", "degC",
+// "MiB" or "Mbps", or units multiplied with '*', divided with '/' and
+// raised to integer powers with '^', such as "km/h", "Mbit/s" or "m/s^2";
+// plain numbers have no unit
+type Quantity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	Unit  string  `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+}
+
+func (x *Quantity) Reset() {
+	*x = Quantity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Quantity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quantity) ProtoMessage() {}
+
+func (x *Quantity) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quantity.ProtoReflect.Descriptor instead.
+func (*Quantity) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Quantity) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Quantity) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+type ConvertUnitsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Quantity *Quantity `protobuf:"bytes,1,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// of the same dimension as the unit of the quantity
+	Unit string `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,3,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+}
+
+func (x *ConvertUnitsRequest) Reset() {
+	*x = ConvertUnitsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConvertUnitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertUnitsRequest) ProtoMessage() {}
+
+func (x *ConvertUnitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertUnitsRequest.ProtoReflect.Descriptor instead.
+func (*ConvertUnitsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ConvertUnitsRequest) GetQuantity() *Quantity {
+	if x != nil {
+		return x.Quantity
+	}
+	return nil
+}
+
+func (x *ConvertUnitsRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *ConvertUnitsRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+type ConvertUnitsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Quantity *Quantity `protobuf:"bytes,1,opt,name=quantity,proto3" json:"quantity,omitempty"`
+}
+
+func (x *ConvertUnitsResponse) Reset() {
+	*x = ConvertUnitsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConvertUnitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertUnitsResponse) ProtoMessage() {}
+
+func (x *ConvertUnitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertUnitsResponse.ProtoReflect.Descriptor instead.
+func (*ConvertUnitsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ConvertUnitsResponse) GetQuantity() *Quantity {
+	if x != nil {
+		return x.Quantity
+	}
+	return nil
+}
+
+type CalculateQuantitiesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operation CalculateQuantitiesRequest_Operation `protobuf:"varint,1,opt,name=operation,proto3,enum=calculator.CalculateQuantitiesRequest_Operation" json:"operation,omitempty"`
+	Left      *Quantity                            `protobuf:"bytes,2,opt,name=left,proto3" json:"left,omitempty"`
+	Right     *Quantity                            `protobuf:"bytes,3,opt,name=right,proto3" json:"right,omitempty"`
+	// the unit of the result; that of left for ADD and SUBTRACT, base units
+	// such as "m/s" or "kg*m^2" for MULTIPLY and DIVIDE when empty
+	Unit string `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,5,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+}
+
+func (x *CalculateQuantitiesRequest) Reset() {
+	*x = CalculateQuantitiesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CalculateQuantitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateQuantitiesRequest) ProtoMessage() {}
+
+func (x *CalculateQuantitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateQuantitiesRequest.ProtoReflect.Descriptor instead.
+func (*CalculateQuantitiesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CalculateQuantitiesRequest) GetOperation() CalculateQuantitiesRequest_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return CalculateQuantitiesRequest_OPERATION_UNSPECIFIED
+}
+
+func (x *CalculateQuantitiesRequest) GetLeft() *Quantity {
+	if x != nil {
+		return x.Left
+	}
+	return nil
+}
+
+func (x *CalculateQuantitiesRequest) GetRight() *Quantity {
+	if x != nil {
+		return x.Right
+	}
+	return nil
+}
+
+func (x *CalculateQuantitiesRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *CalculateQuantitiesRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+type CalculateQuantitiesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *Quantity `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *CalculateQuantitiesResponse) Reset() {
+	*x = CalculateQuantitiesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CalculateQuantitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateQuantitiesResponse) ProtoMessage() {}
+
+func (x *CalculateQuantitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateQuantitiesResponse.ProtoReflect.Descriptor instead.
+func (*CalculateQuantitiesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CalculateQuantitiesResponse) GetResult() *Quantity {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// decimal integers such as "-123456789012345678901234567890", of 1000
+// digits at most
+type IntegersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Numbers []string `protobuf:"bytes,1,rep,name=numbers,proto3" json:"numbers,omitempty"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,2,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+}
+
+func (x *IntegersRequest) Reset() {
+	*x = IntegersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntegersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntegersRequest) ProtoMessage() {}
+
+func (x *IntegersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntegersRequest.ProtoReflect.Descriptor instead.
+func (*IntegersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *IntegersRequest) GetNumbers() []string {
+	if x != nil {
+		return x.Numbers
+	}
+	return nil
+}
+
+func (x *IntegersRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+type IntegerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *IntegerResponse) Reset() {
+	*x = IntegerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntegerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntegerResponse) ProtoMessage() {}
+
+func (x *IntegerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntegerResponse.ProtoReflect.Descriptor instead.
+func (*IntegerResponse) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *IntegerResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+type ModularExponentiationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Base string `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	// negative exponents are those of the modular inverse of the base
+	Exponent string `protobuf:"bytes,2,opt,name=exponent,proto3" json:"exponent,omitempty"`
+	// positive
+	Modulus string `protobuf:"bytes,3,opt,name=modulus,proto3" json:"modulus,omitempty"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,4,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+}
+
+func (x *ModularExponentiationRequest) Reset() {
+	*x = ModularExponentiationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModularExponentiationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModularExponentiationRequest) ProtoMessage() {}
+
+func (x *ModularExponentiationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModularExponentiationRequest.ProtoReflect.Descriptor instead.
+func (*ModularExponentiationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ModularExponentiationRequest) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *ModularExponentiationRequest) GetExponent() string {
+	if x != nil {
+		return x.Exponent
+	}
+	return ""
+}
+
+func (x *ModularExponentiationRequest) GetModulus() string {
+	if x != nil {
+		return x.Modulus
+	}
+	return ""
+}
+
+func (x *ModularExponentiationRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+type ModularInverseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number string `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	// positive
+	Modulus string `protobuf:"bytes,2,opt,name=modulus,proto3" json:"modulus,omitempty"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,3,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+}
+
+func (x *ModularInverseRequest) Reset() {
+	*x = ModularInverseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModularInverseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModularInverseRequest) ProtoMessage() {}
+
+func (x *ModularInverseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModularInverseRequest.ProtoReflect.Descriptor instead.
+func (*ModularInverseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ModularInverseRequest) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *ModularInverseRequest) GetModulus() string {
+	if x != nil {
+		return x.Modulus
+	}
+	return ""
+}
+
+func (x *ModularInverseRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+type IsPrimeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number string `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,2,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+}
+
+func (x *IsPrimeRequest) Reset() {
+	*x = IsPrimeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IsPrimeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsPrimeRequest) ProtoMessage() {}
+
+func (x *IsPrimeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsPrimeRequest.ProtoReflect.Descriptor instead.
+func (*IsPrimeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *IsPrimeRequest) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *IsPrimeRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+type IsPrimeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prime bool `protobuf:"varint,1,opt,name=prime,proto3" json:"prime,omitempty"`
+	// set under 2^64, where the test is exact; beyond, a composite number
+	// passes it with a probability under 1 in 2^40
+	Certain bool `protobuf:"varint,2,opt,name=certain,proto3" json:"certain,omitempty"`
+}
+
+func (x *IsPrimeResponse) Reset() {
+	*x = IsPrimeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IsPrimeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsPrimeResponse) ProtoMessage() {}
+
+func (x *IsPrimeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsPrimeResponse.ProtoReflect.Descriptor instead.
+func (*IsPrimeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *IsPrimeResponse) GetPrime() bool {
+	if x != nil {
+		return x.Prime
+	}
+	return false
+}
+
+func (x *IsPrimeResponse) GetCertain() bool {
+	if x != nil {
+		return x.Certain
+	}
+	return false
+}
+
+// the integers from from to to, both included, with to up to 10^12
+type GeneratePrimesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From uint64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   uint64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,3,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+}
+
+func (x *GeneratePrimesRequest) Reset() {
+	*x = GeneratePrimesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeneratePrimesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratePrimesRequest) ProtoMessage() {}
+
+func (x *GeneratePrimesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratePrimesRequest.ProtoReflect.Descriptor instead.
+func (*GeneratePrimesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GeneratePrimesRequest) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GeneratePrimesRequest) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GeneratePrimesRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+type GeneratePrimesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the primes of the next segment of the range, in increasing order
+	Primes []uint64 `protobuf:"varint,1,rep,packed,name=primes,proto3" json:"primes,omitempty"`
+}
+
+func (x *GeneratePrimesResponse) Reset() {
+	*x = GeneratePrimesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeneratePrimesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratePrimesResponse) ProtoMessage() {}
+
+func (x *GeneratePrimesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratePrimesResponse.ProtoReflect.Descriptor instead.
+func (*GeneratePrimesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GeneratePrimesResponse) GetPrimes() []uint64 {
+	if x != nil {
+		return x.Primes
+	}
+	return nil
+}
+
+type BatchComputeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 10000 at most
+	Operations []*BatchComputeRequest_Operation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	// handles the call without the server's response cache
+	NoCache bool `protobuf:"varint,2,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+}
+
+func (x *BatchComputeRequest) Reset() {
+	*x = BatchComputeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchComputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchComputeRequest) ProtoMessage() {}
+
+func (x *BatchComputeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchComputeRequest.ProtoReflect.Descriptor instead.
+func (*BatchComputeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *BatchComputeRequest) GetOperations() []*BatchComputeRequest_Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+type BatchComputeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// in the order of the operations
+	Results []*BatchComputeResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *BatchComputeResponse) Reset() {
+	*x = BatchComputeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchComputeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchComputeResponse) ProtoMessage() {}
+
+func (x *BatchComputeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchComputeResponse.ProtoReflect.Descriptor instead.
+func (*BatchComputeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *BatchComputeResponse) GetResults() []*BatchComputeResponse_Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ComputeStatisticsResponse_Percentile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// between 0 and 100
+	Rank  float64 `protobuf:"fixed64,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	Value float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ComputeStatisticsResponse_Percentile) Reset() {
+	*x = ComputeStatisticsResponse_Percentile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ComputeStatisticsResponse_Percentile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComputeStatisticsResponse_Percentile) ProtoMessage() {}
+
+func (x *ComputeStatisticsResponse_Percentile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComputeStatisticsResponse_Percentile.ProtoReflect.Descriptor instead.
+func (*ComputeStatisticsResponse_Percentile) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *ComputeStatisticsResponse_Percentile) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *ComputeStatisticsResponse_Percentile) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type StreamAggregateRequest_Config struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Aggregation StreamAggregateRequest_Aggregation `protobuf:"varint,1,opt,name=aggregation,proto3,enum=calculator.StreamAggregateRequest_Aggregation" json:"aggregation,omitempty"`
+	Window      *Window                            `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+}
+
+func (x *StreamAggregateRequest_Config) Reset() {
+	*x = StreamAggregateRequest_Config{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamAggregateRequest_Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAggregateRequest_Config) ProtoMessage() {}
+
+func (x *StreamAggregateRequest_Config) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAggregateRequest_Config.ProtoReflect.Descriptor instead.
+func (*StreamAggregateRequest_Config) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{11, 0}
+}
+
+func (x *StreamAggregateRequest_Config) GetAggregation() StreamAggregateRequest_Aggregation {
+	if x != nil {
+		return x.Aggregation
+	}
+	return StreamAggregateRequest_AGGREGATION_UNSPECIFIED
+}
+
+func (x *StreamAggregateRequest_Config) GetWindow() *Window {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+// the request of one of the unary RPCs, whose no_cache is ignored
+type BatchComputeRequest_Operation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Request:
+	//	*BatchComputeRequest_Operation_Sum
+	//	*BatchComputeRequest_Operation_SquareRoot
+	//	*BatchComputeRequest_Operation_Calculate
+	//	*BatchComputeRequest_Operation_Evaluate
+	//	*BatchComputeRequest_Operation_MultiplyMatrices
+	//	*BatchComputeRequest_Operation_TransposeMatrix
+	//	*BatchComputeRequest_Operation_Determinant
+	//	*BatchComputeRequest_Operation_InvertMatrix
+	//	*BatchComputeRequest_Operation_SolveLinearSystem
+	//	*BatchComputeRequest_Operation_ConvertUnits
+	//	*BatchComputeRequest_Operation_CalculateQuantities
+	//	*BatchComputeRequest_Operation_GreatestCommonDivisor
+	//	*BatchComputeRequest_Operation_LeastCommonMultiple
+	//	*BatchComputeRequest_Operation_ModularExponentiation
+	//	*BatchComputeRequest_Operation_ModularInverse
+	//	*BatchComputeRequest_Operation_IsPrime
+	Request isBatchComputeRequest_Operation_Request `protobuf_oneof:"request"`
+}
+
+func (x *BatchComputeRequest_Operation) Reset() {
+	*x = BatchComputeRequest_Operation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchComputeRequest_Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchComputeRequest_Operation) ProtoMessage() {}
+
+func (x *BatchComputeRequest_Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchComputeRequest_Operation.ProtoReflect.Descriptor instead.
+func (*BatchComputeRequest_Operation) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{41, 0}
+}
+
+func (m *BatchComputeRequest_Operation) GetRequest() isBatchComputeRequest_Operation_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetSum() *SumRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_Sum); ok {
+		return x.Sum
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetSquareRoot() *SquareRootRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_SquareRoot); ok {
+		return x.SquareRoot
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetCalculate() *CalculateRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_Calculate); ok {
+		return x.Calculate
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetEvaluate() *EvaluateRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_Evaluate); ok {
+		return x.Evaluate
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetMultiplyMatrices() *MultiplyMatricesRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_MultiplyMatrices); ok {
+		return x.MultiplyMatrices
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetTransposeMatrix() *MatrixRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_TransposeMatrix); ok {
+		return x.TransposeMatrix
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetDeterminant() *MatrixRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_Determinant); ok {
+		return x.Determinant
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetInvertMatrix() *MatrixRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_InvertMatrix); ok {
+		return x.InvertMatrix
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetSolveLinearSystem() *SolveLinearSystemRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_SolveLinearSystem); ok {
+		return x.SolveLinearSystem
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetConvertUnits() *ConvertUnitsRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_ConvertUnits); ok {
+		return x.ConvertUnits
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetCalculateQuantities() *CalculateQuantitiesRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_CalculateQuantities); ok {
+		return x.CalculateQuantities
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetGreatestCommonDivisor() *IntegersRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_GreatestCommonDivisor); ok {
+		return x.GreatestCommonDivisor
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetLeastCommonMultiple() *IntegersRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_LeastCommonMultiple); ok {
+		return x.LeastCommonMultiple
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetModularExponentiation() *ModularExponentiationRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_ModularExponentiation); ok {
+		return x.ModularExponentiation
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetModularInverse() *ModularInverseRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_ModularInverse); ok {
+		return x.ModularInverse
+	}
+	return nil
+}
+
+func (x *BatchComputeRequest_Operation) GetIsPrime() *IsPrimeRequest {
+	if x, ok := x.GetRequest().(*BatchComputeRequest_Operation_IsPrime); ok {
+		return x.IsPrime
+	}
+	return nil
+}
+
+type isBatchComputeRequest_Operation_Request interface {
+	isBatchComputeRequest_Operation_Request()
+}
+
+type BatchComputeRequest_Operation_Sum struct {
+	Sum *SumRequest `protobuf:"bytes,1,opt,name=sum,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_SquareRoot struct {
+	SquareRoot *SquareRootRequest `protobuf:"bytes,2,opt,name=square_root,json=squareRoot,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_Calculate struct {
+	Calculate *CalculateRequest `protobuf:"bytes,3,opt,name=calculate,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_Evaluate struct {
+	Evaluate *EvaluateRequest `protobuf:"bytes,4,opt,name=evaluate,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_MultiplyMatrices struct {
+	MultiplyMatrices *MultiplyMatricesRequest `protobuf:"bytes,5,opt,name=multiply_matrices,json=multiplyMatrices,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_TransposeMatrix struct {
+	TransposeMatrix *MatrixRequest `protobuf:"bytes,6,opt,name=transpose_matrix,json=transposeMatrix,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_Determinant struct {
+	Determinant *MatrixRequest `protobuf:"bytes,7,opt,name=determinant,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_InvertMatrix struct {
+	InvertMatrix *MatrixRequest `protobuf:"bytes,8,opt,name=invert_matrix,json=invertMatrix,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_SolveLinearSystem struct {
+	SolveLinearSystem *SolveLinearSystemRequest `protobuf:"bytes,9,opt,name=solve_linear_system,json=solveLinearSystem,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_ConvertUnits struct {
+	ConvertUnits *ConvertUnitsRequest `protobuf:"bytes,10,opt,name=convert_units,json=convertUnits,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_CalculateQuantities struct {
+	CalculateQuantities *CalculateQuantitiesRequest `protobuf:"bytes,11,opt,name=calculate_quantities,json=calculateQuantities,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_GreatestCommonDivisor struct {
+	GreatestCommonDivisor *IntegersRequest `protobuf:"bytes,12,opt,name=greatest_common_divisor,json=greatestCommonDivisor,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_LeastCommonMultiple struct {
+	LeastCommonMultiple *IntegersRequest `protobuf:"bytes,13,opt,name=least_common_multiple,json=leastCommonMultiple,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_ModularExponentiation struct {
+	ModularExponentiation *ModularExponentiationRequest `protobuf:"bytes,14,opt,name=modular_exponentiation,json=modularExponentiation,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_ModularInverse struct {
+	ModularInverse *ModularInverseRequest `protobuf:"bytes,15,opt,name=modular_inverse,json=modularInverse,proto3,oneof"`
+}
+
+type BatchComputeRequest_Operation_IsPrime struct {
+	IsPrime *IsPrimeRequest `protobuf:"bytes,16,opt,name=is_prime,json=isPrime,proto3,oneof"`
+}
+
+func (*BatchComputeRequest_Operation_Sum) isBatchComputeRequest_Operation_Request() {}
+
+func (*BatchComputeRequest_Operation_SquareRoot) isBatchComputeRequest_Operation_Request() {}
+
+func (*BatchComputeRequest_Operation_Calculate) isBatchComputeRequest_Operation_Request() {}
+
+func (*BatchComputeRequest_Operation_Evaluate) isBatchComputeRequest_Operation_Request() {}
+
+func (*BatchComputeRequest_Operation_MultiplyMatrices) isBatchComputeRequest_Operation_Request() {}
+
+func (*BatchComputeRequest_Operation_TransposeMatrix) isBatchComputeRequest_Operation_Request() {}
+
+func (*BatchComputeRequest_Operation_Determinant) isBatchComputeRequest_Operation_Request() {}
+
+func (*BatchComputeRequest_Operation_InvertMatrix) isBatchComputeRequest_Operation_Request() {}
+
+func (*BatchComputeRequest_Operation_SolveLinearSystem) isBatchComputeRequest_Operation_Request() {}
+
+func (*BatchComputeRequest_Operation_ConvertUnits) isBatchComputeRequest_Operation_Request() {}
+
+func (*BatchComputeRequest_Operation_CalculateQuantities) isBatchComputeRequest_Operation_Request() {}
+
+func (*BatchComputeRequest_Operation_GreatestCommonDivisor) isBatchComputeRequest_Operation_Request() {
+}
+
+func (*BatchComputeRequest_Operation_LeastCommonMultiple) isBatchComputeRequest_Operation_Request() {}
+
+func (*BatchComputeRequest_Operation_ModularExponentiation) isBatchComputeRequest_Operation_Request() {
+}
+
+func (*BatchComputeRequest_Operation_ModularInverse) isBatchComputeRequest_Operation_Request() {}
+
+func (*BatchComputeRequest_Operation_IsPrime) isBatchComputeRequest_Operation_Request() {}
+
+// the response of an operation, or the status of its failure
+type BatchComputeResponse_Result struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Result:
+	//	*BatchComputeResponse_Result_Error
+	//	*BatchComputeResponse_Result_Sum
+	//	*BatchComputeResponse_Result_SquareRoot
+	//	*BatchComputeResponse_Result_Calculate
+	//	*BatchComputeResponse_Result_Evaluate
+	//	*BatchComputeResponse_Result_MultiplyMatrices
+	//	*BatchComputeResponse_Result_TransposeMatrix
+	//	*BatchComputeResponse_Result_Determinant
+	//	*BatchComputeResponse_Result_InvertMatrix
+	//	*BatchComputeResponse_Result_SolveLinearSystem
+	//	*BatchComputeResponse_Result_ConvertUnits
+	//	*BatchComputeResponse_Result_CalculateQuantities
+	//	*BatchComputeResponse_Result_GreatestCommonDivisor
+	//	*BatchComputeResponse_Result_LeastCommonMultiple
+	//	*BatchComputeResponse_Result_ModularExponentiation
+	//	*BatchComputeResponse_Result_ModularInverse
+	//	*BatchComputeResponse_Result_IsPrime
+	Result isBatchComputeResponse_Result_Result `protobuf_oneof:"result"`
+}
+
+func (x *BatchComputeResponse_Result) Reset() {
+	*x = BatchComputeResponse_Result{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_calculator_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchComputeResponse_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchComputeResponse_Result) ProtoMessage() {}
+
+func (x *BatchComputeResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_calculator_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchComputeResponse_Result.ProtoReflect.Descriptor instead.
+func (*BatchComputeResponse_Result) Descriptor() ([]byte, []int) {
+	return file_proto_calculator_proto_rawDescGZIP(), []int{42, 0}
+}
+
+func (m *BatchComputeResponse_Result) GetResult() isBatchComputeResponse_Result_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetError() *status.Status {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetSum() *SumResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_Sum); ok {
+		return x.Sum
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetSquareRoot() *SquareRootResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_SquareRoot); ok {
+		return x.SquareRoot
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetCalculate() *CalculateResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_Calculate); ok {
+		return x.Calculate
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetEvaluate() *EvaluateResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_Evaluate); ok {
+		return x.Evaluate
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetMultiplyMatrices() *MultiplyMatricesResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_MultiplyMatrices); ok {
+		return x.MultiplyMatrices
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetTransposeMatrix() *MatrixResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_TransposeMatrix); ok {
+		return x.TransposeMatrix
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetDeterminant() *DeterminantResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_Determinant); ok {
+		return x.Determinant
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetInvertMatrix() *MatrixResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_InvertMatrix); ok {
+		return x.InvertMatrix
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetSolveLinearSystem() *SolveLinearSystemResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_SolveLinearSystem); ok {
+		return x.SolveLinearSystem
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetConvertUnits() *ConvertUnitsResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_ConvertUnits); ok {
+		return x.ConvertUnits
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetCalculateQuantities() *CalculateQuantitiesResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_CalculateQuantities); ok {
+		return x.CalculateQuantities
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetGreatestCommonDivisor() *IntegerResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_GreatestCommonDivisor); ok {
+		return x.GreatestCommonDivisor
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetLeastCommonMultiple() *IntegerResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_LeastCommonMultiple); ok {
+		return x.LeastCommonMultiple
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetModularExponentiation() *IntegerResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_ModularExponentiation); ok {
+		return x.ModularExponentiation
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetModularInverse() *IntegerResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_ModularInverse); ok {
+		return x.ModularInverse
+	}
+	return nil
+}
+
+func (x *BatchComputeResponse_Result) GetIsPrime() *IsPrimeResponse {
+	if x, ok := x.GetResult().(*BatchComputeResponse_Result_IsPrime); ok {
+		return x.IsPrime
+	}
+	return nil
+}
+
+type isBatchComputeResponse_Result_Result interface {
+	isBatchComputeResponse_Result_Result()
+}
+
+type BatchComputeResponse_Result_Error struct {
+	Error *status.Status `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_Sum struct {
+	Sum *SumResponse `protobuf:"bytes,2,opt,name=sum,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_SquareRoot struct {
+	SquareRoot *SquareRootResponse `protobuf:"bytes,3,opt,name=square_root,json=squareRoot,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_Calculate struct {
+	Calculate *CalculateResponse `protobuf:"bytes,4,opt,name=calculate,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_Evaluate struct {
+	Evaluate *EvaluateResponse `protobuf:"bytes,5,opt,name=evaluate,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_MultiplyMatrices struct {
+	MultiplyMatrices *MultiplyMatricesResponse `protobuf:"bytes,6,opt,name=multiply_matrices,json=multiplyMatrices,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_TransposeMatrix struct {
+	TransposeMatrix *MatrixResponse `protobuf:"bytes,7,opt,name=transpose_matrix,json=transposeMatrix,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_Determinant struct {
+	Determinant *DeterminantResponse `protobuf:"bytes,8,opt,name=determinant,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_InvertMatrix struct {
+	InvertMatrix *MatrixResponse `protobuf:"bytes,9,opt,name=invert_matrix,json=invertMatrix,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_SolveLinearSystem struct {
+	SolveLinearSystem *SolveLinearSystemResponse `protobuf:"bytes,10,opt,name=solve_linear_system,json=solveLinearSystem,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_ConvertUnits struct {
+	ConvertUnits *ConvertUnitsResponse `protobuf:"bytes,11,opt,name=convert_units,json=convertUnits,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_CalculateQuantities struct {
+	CalculateQuantities *CalculateQuantitiesResponse `protobuf:"bytes,12,opt,name=calculate_quantities,json=calculateQuantities,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_GreatestCommonDivisor struct {
+	GreatestCommonDivisor *IntegerResponse `protobuf:"bytes,13,opt,name=greatest_common_divisor,json=greatestCommonDivisor,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_LeastCommonMultiple struct {
+	LeastCommonMultiple *IntegerResponse `protobuf:"bytes,14,opt,name=least_common_multiple,json=leastCommonMultiple,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_ModularExponentiation struct {
+	ModularExponentiation *IntegerResponse `protobuf:"bytes,15,opt,name=modular_exponentiation,json=modularExponentiation,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_ModularInverse struct {
+	ModularInverse *IntegerResponse `protobuf:"bytes,16,opt,name=modular_inverse,json=modularInverse,proto3,oneof"`
+}
+
+type BatchComputeResponse_Result_IsPrime struct {
+	IsPrime *IsPrimeResponse `protobuf:"bytes,17,opt,name=is_prime,json=isPrime,proto3,oneof"`
+}
+
+func (*BatchComputeResponse_Result_Error) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_Sum) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_SquareRoot) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_Calculate) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_Evaluate) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_MultiplyMatrices) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_TransposeMatrix) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_Determinant) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_InvertMatrix) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_SolveLinearSystem) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_ConvertUnits) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_CalculateQuantities) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_GreatestCommonDivisor) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_LeastCommonMultiple) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_ModularExponentiation) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_ModularInverse) isBatchComputeResponse_Result_Result() {}
+
+func (*BatchComputeResponse_Result_IsPrime) isBatchComputeResponse_Result_Result() {}
+
+var File_proto_calculator_proto protoreflect.FileDescriptor
+
+var file_proto_calculator_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x72, 0x70,
+	0x63, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x6f,
+	0x0a, 0x0a, 0x53, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x23, 0x0a, 0x0d, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x43, 0x61, 0x63, 0x68, 0x65, 0x22,
+	0x2c, 0x0a, 0x0b, 0x53, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x75, 0x6d, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x73, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x73, 0x0a,
+	0x1f, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x44, 0x65, 0x63, 0x6f,
+	0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x69, 0x67, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x69,
+	0x67, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x63, 0x61,
+	0x63, 0x68, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x22, 0x6f, 0x0a, 0x20, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x6d, 0x65, 0x5f,
+	0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x72,
+	0x69, 0x6d, 0x65, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x69, 0x67,
+	0x5f, 0x70, 0x72, 0x69, 0x6d, 0x65, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x62, 0x69, 0x67, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x46, 0x61, 0x63,
+	0x74, 0x6f, 0x72, 0x22, 0x2f, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x41, 0x76,
+	0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x22, 0x32, 0x0a, 0x16, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x41,
+	0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x07, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x22, 0x32, 0x0a, 0x18, 0x43, 0x6f, 0x6d, 0x70,
+	0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xbb, 0x02, 0x0a,
+	0x19, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x73,
+	0x75, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x65, 0x61, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x04, 0x6d, 0x65, 0x61, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x64, 0x65, 0x76, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x06, 0x73, 0x74, 0x64, 0x64, 0x65, 0x76, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03,
+	0x6d, 0x61, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x52,
+	0x0a, 0x0b, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x69, 0x6c, 0x65, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c,
+	0x65, 0x73, 0x1a, 0x36, 0x0a, 0x0a, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04,
+	0x72, 0x61, 0x6e, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x2c, 0x0a, 0x12, 0x46, 0x69,
+	0x6e, 0x64, 0x4d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x2f, 0x0a, 0x13, 0x46, 0x69, 0x6e, 0x64,
+	0x4d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x22, 0xfb, 0x01, 0x0a, 0x06, 0x57, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x12, 0x2b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x6c, 0x69, 0x64, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x6c, 0x69, 0x64, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0e, 0x73, 0x6c, 0x69, 0x64, 0x65, 0x5f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x73, 0x6c, 0x69, 0x64, 0x65, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x21, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08,
+	0x54, 0x55, 0x4d, 0x42, 0x4c, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x4c,
+	0x49, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x22, 0xe7, 0x02, 0x0a, 0x16, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x43, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x00, 0x52,
+	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x1a, 0x86, 0x01, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x50, 0x0a, 0x0b,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x2e, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a,
+	0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x57, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0x5a, 0x0a, 0x0b, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x47, 0x47,
+	0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x41, 0x58, 0x10, 0x01, 0x12,
+	0x07, 0x0a, 0x03, 0x4d, 0x49, 0x4e, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x55, 0x4d, 0x10,
+	0x03, 0x12, 0x08, 0x0a, 0x04, 0x4d, 0x45, 0x41, 0x4e, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x43,
+	0x4f, 0x55, 0x4e, 0x54, 0x10, 0x05, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x8f, 0x02, 0x0a, 0x17, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e,
+	0x64, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65,
+	0x6e, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72,
+	0x74, 0x69, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74,
+	0x69, 0x61, 0x6c, 0x22, 0x20, 0x0a, 0x06, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x4e, 0x0a, 0x06, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x12,
+	0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72,
+	0x6f, 0x77, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0xc1, 0x01, 0x0a, 0x17, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70,
+	0x6c, 0x79, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x26, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x61, 0x74,
+	0x72, 0x69, 0x78, 0x52, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x6d, 0x61, 0x74,
+	0x72, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x48, 0x00, 0x52,
+	0x06, 0x6d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x12, 0x2c, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x06, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x42, 0x07, 0x0a, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x22, 0x80, 0x01, 0x0a, 0x18, 0x4d, 0x75,
+	0x6c, 0x74, 0x69, 0x70, 0x6c, 0x79, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x6d, 0x61, 0x74, 0x72, 0x69, 0x78,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x48, 0x00, 0x52, 0x06, 0x6d, 0x61,
+	0x74, 0x72, 0x69, 0x78, 0x12, 0x2c, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x06, 0x76, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x56, 0x0a, 0x0d,
+	0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a,
+	0x06, 0x6d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69,
+	0x78, 0x52, 0x06, 0x6d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f,
+	0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x22, 0x3c, 0x0a, 0x0e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x6d, 0x61, 0x74, 0x72, 0x69, 0x78,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x06, 0x6d, 0x61, 0x74, 0x72,
+	0x69, 0x78, 0x22, 0x37, 0x0a, 0x13, 0x44, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6e,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x74,
+	0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b,
+	0x64, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6e, 0x74, 0x22, 0x9f, 0x01, 0x0a, 0x18,
+	0x53, 0x6f, 0x6c, 0x76, 0x65, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x0c, 0x63, 0x6f, 0x65, 0x66,
+	0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x61, 0x74, 0x72,
+	0x69, 0x78, 0x52, 0x0c, 0x63, 0x6f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x30, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x74, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x43, 0x61, 0x63, 0x68, 0x65, 0x22, 0x4b, 0x0a,
+	0x19, 0x53, 0x6f, 0x6c, 0x76, 0x65, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x73, 0x6f,
+	0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63,
+	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x52, 0x08, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x46, 0x0a, 0x11, 0x53, 0x71,
+	0x75, 0x61, 0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x63, 0x61,
+	0x63, 0x68, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x22, 0x35, 0x0a, 0x12, 0x53, 0x71, 0x75, 0x61, 0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x6f, 0x6f, 0x74, 0x22, 0x93, 0x03, 0x0a, 0x10, 0x43, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x44,
+	0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x26, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43,
+	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x73,
+	0x12, 0x35, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21,
+	0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x65, 0x63, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72, 0x65, 0x63,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x22, 0x7f, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a,
+	0x15, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x44, 0x44, 0x10,
+	0x01, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x55, 0x42, 0x54, 0x52, 0x41, 0x43, 0x54, 0x10, 0x02, 0x12,
+	0x0c, 0x0a, 0x08, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x50, 0x4c, 0x59, 0x10, 0x03, 0x12, 0x0a, 0x0a,
+	0x06, 0x44, 0x49, 0x56, 0x49, 0x44, 0x45, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x4f, 0x44,
+	0x55, 0x4c, 0x4f, 0x10, 0x05, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x10, 0x06,
+	0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x51, 0x55, 0x41, 0x52, 0x45, 0x5f, 0x52, 0x4f, 0x4f, 0x54, 0x10,
+	0x07, 0x22, 0x2c, 0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x54,
+	0x45, 0x47, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x41, 0x4c, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x10, 0x02, 0x22,
+	0x2b, 0x0a, 0x11, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0xd4, 0x01, 0x0a,
+	0x0f, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x48, 0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f,
+	0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x1a, 0x3c, 0x0a, 0x0e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x2a, 0x0a, 0x10, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22,
+	0x34, 0x0a, 0x08, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x75, 0x6e, 0x69, 0x74, 0x22, 0x76, 0x0a, 0x13, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74,
+	0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x08,
+	0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x51, 0x75, 0x61, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x12,
+	0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e,
+	0x69, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x43, 0x61, 0x63, 0x68, 0x65, 0x22, 0x48, 0x0a,
+	0x14, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x08, 0x71,
+	0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0xca, 0x02, 0x0a, 0x1a, 0x43, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x65, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4e, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x30, 0x2e, 0x63, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65,
+	0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x04, 0x6c, 0x65, 0x66, 0x74,
+	0x12, 0x2a, 0x0a, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x51, 0x75, 0x61,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x75, 0x6e, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74,
+	0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x43, 0x61, 0x63, 0x68, 0x65, 0x22, 0x57, 0x0a, 0x09, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x50, 0x45, 0x52,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x44, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08,
+	0x53, 0x55, 0x42, 0x54, 0x52, 0x41, 0x43, 0x54, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x4d, 0x55,
+	0x4c, 0x54, 0x49, 0x50, 0x4c, 0x59, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x49, 0x56, 0x49,
+	0x44, 0x45, 0x10, 0x04, 0x22, 0x4b, 0x0a, 0x1b, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
+	0x65, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x22, 0x46, 0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x19,
+	0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x6e, 0x6f, 0x43, 0x61, 0x63, 0x68, 0x65, 0x22, 0x29, 0x0a, 0x0f, 0x49, 0x6e, 0x74,
+	0x65, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x22, 0x83, 0x01, 0x0a, 0x1c, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x61, 0x72,
+	0x45, 0x78, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x75, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x75, 0x73, 0x12,
+	0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x6e, 0x6f, 0x43, 0x61, 0x63, 0x68, 0x65, 0x22, 0x64, 0x0a, 0x15, 0x4d, 0x6f,
+	0x64, 0x75, 0x6c, 0x61, 0x72, 0x49, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x6f, 0x64, 0x75, 0x6c, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x6f,
+	0x64, 0x75, 0x6c, 0x75, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x22, 0x43, 0x0a, 0x0e, 0x49, 0x73, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f,
+	0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x22, 0x41, 0x0a, 0x0f, 0x49, 0x73, 0x50, 0x72, 0x69, 0x6d, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x70, 0x72, 0x69, 0x6d, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x65, 0x72, 0x74, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x63, 0x65, 0x72, 0x74, 0x61, 0x69, 0x6e, 0x22, 0x56, 0x0a, 0x15, 0x47, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x22, 0x30, 0x0a, 0x16, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x50, 0x72, 0x69, 0x6d,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72,
+	0x69, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x06, 0x70, 0x72, 0x69, 0x6d,
+	0x65, 0x73, 0x22, 0xa9, 0x0a, 0x0a, 0x13, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x6d, 0x70,
+	0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x49, 0x0a, 0x0a, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x6f, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x1a, 0xab, 0x09, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a,
+	0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x03, 0x73, 0x75, 0x6d, 0x12, 0x40, 0x0a, 0x0b, 0x73, 0x71,
+	0x75, 0x61, 0x72, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x71, 0x75,
+	0x61, 0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x0a, 0x73, 0x71, 0x75, 0x61, 0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x3c, 0x0a, 0x09,
+	0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x09, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x65, 0x76,
+	0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63,
+	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x65, 0x76, 0x61,
+	0x6c, 0x75, 0x61, 0x74, 0x65, 0x12, 0x52, 0x0a, 0x11, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c,
+	0x79, 0x5f, 0x6d, 0x61, 0x74, 0x72, 0x69, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x75,
+	0x6c, 0x74, 0x69, 0x70, 0x6c, 0x79, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x10, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c,
+	0x79, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x63, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x10, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x70, 0x6f, 0x73, 0x65, 0x5f, 0x6d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x73, 0x65, 0x4d, 0x61, 0x74, 0x72, 0x69,
+	0x78, 0x12, 0x3d, 0x0a, 0x0b, 0x64, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6e, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6e, 0x74,
+	0x12, 0x40, 0x0a, 0x0d, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x5f, 0x6d, 0x61, 0x74, 0x72, 0x69,
+	0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x4d, 0x61, 0x74, 0x72,
+	0x69, 0x78, 0x12, 0x56, 0x0a, 0x13, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x5f, 0x6c, 0x69, 0x6e, 0x65,
+	0x61, 0x72, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x6f, 0x6c,
+	0x76, 0x65, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x11, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x4c, 0x69,
+	0x6e, 0x65, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x46, 0x0a, 0x0d, 0x63, 0x6f,
+	0x6e, 0x76, 0x65, 0x72, 0x74, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x55, 0x6e, 0x69,
+	0x74, 0x73, 0x12, 0x5b, 0x0a, 0x14, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x5f,
+	0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x13, 0x63, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x65, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12,
+	0x55, 0x0a, 0x17, 0x67, 0x72, 0x65, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x5f, 0x64, 0x69, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x49, 0x6e,
+	0x74, 0x65, 0x67, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x15, 0x67, 0x72, 0x65, 0x61, 0x74, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x44,
+	0x69, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x12, 0x51, 0x0a, 0x15, 0x6c, 0x65, 0x61, 0x73, 0x74, 0x5f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x13, 0x6c, 0x65, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x12, 0x61, 0x0a, 0x16, 0x6d, 0x6f, 0x64,
+	0x75, 0x6c, 0x61, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x61, 0x72, 0x45, 0x78,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x15, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x61, 0x72, 0x45, 0x78,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4c, 0x0a, 0x0f,
+	0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x61, 0x72, 0x5f, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x65, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x61, 0x72, 0x49, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x6d, 0x6f, 0x64, 0x75,
+	0x6c, 0x61, 0x72, 0x49, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x08, 0x69, 0x73,
+	0x5f, 0x70, 0x72, 0x69, 0x6d, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63,
+	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x49, 0x73, 0x50, 0x72, 0x69, 0x6d,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x07, 0x69, 0x73, 0x50, 0x72,
+	0x69, 0x6d, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xad,
+	0x0a, 0x0a, 0x14, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75,
+	0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0xd1, 0x09, 0x0a, 0x06, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x2b, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x6d, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x03, 0x73, 0x75, 0x6d, 0x12, 0x41,
+	0x0a, 0x0b, 0x73, 0x71, 0x75, 0x61, 0x72, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x53, 0x71, 0x75, 0x61, 0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x71, 0x75, 0x61, 0x72, 0x65, 0x52, 0x6f, 0x6f,
+	0x74, 0x12, 0x3d, 0x0a, 0x09, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x09, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65,
+	0x12, 0x3a, 0x0a, 0x08, 0x65, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x00, 0x52, 0x08, 0x65, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x12, 0x53, 0x0a, 0x11,
+	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x79, 0x5f, 0x6d, 0x61, 0x74, 0x72, 0x69, 0x63, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x79, 0x4d, 0x61, 0x74,
+	0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52,
+	0x10, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x79, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x63, 0x65,
+	0x73, 0x12, 0x47, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x73, 0x65, 0x5f, 0x6d,
+	0x61, 0x74, 0x72, 0x69, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x70, 0x6f, 0x73, 0x65, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x12, 0x43, 0x0a, 0x0b, 0x64, 0x65,
+	0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x44, 0x65, 0x74,
+	0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6e, 0x74, 0x12,
+	0x41, 0x0a, 0x0d, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x5f, 0x6d, 0x61, 0x74, 0x72, 0x69, 0x78,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x4d, 0x61, 0x74, 0x72,
+	0x69, 0x78, 0x12, 0x57, 0x0a, 0x13, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x5f, 0x6c, 0x69, 0x6e, 0x65,
+	0x61, 0x72, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x25, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x6f, 0x6c,
+	0x76, 0x65, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x11, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x4c,
+	0x69, 0x6e, 0x65, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x47, 0x0a, 0x0d, 0x63,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x55,
+	0x6e, 0x69, 0x74, 0x73, 0x12, 0x5c, 0x0a, 0x14, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
+	0x65, 0x5f, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x13, 0x63,
+	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x12, 0x55, 0x0a, 0x17, 0x67, 0x72, 0x65, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x64, 0x69, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x00, 0x52, 0x15, 0x67, 0x72, 0x65, 0x61, 0x74, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x44, 0x69, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x12, 0x51, 0x0a, 0x15, 0x6c, 0x65, 0x61,
+	0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70,
+	0x6c, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75,
+	0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x13, 0x6c, 0x65, 0x61, 0x73, 0x74, 0x43, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x12, 0x54, 0x0a, 0x16,
+	0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x61, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63,
+	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x15, 0x6d, 0x6f, 0x64,
+	0x75, 0x6c, 0x61, 0x72, 0x45, 0x78, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x46, 0x0a, 0x0f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x61, 0x72, 0x5f, 0x69, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x6d, 0x6f, 0x64, 0x75,
+	0x6c, 0x61, 0x72, 0x49, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x69, 0x73,
+	0x5f, 0x70, 0x72, 0x69, 0x6d, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63,
+	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x49, 0x73, 0x50, 0x72, 0x69, 0x6d,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x07, 0x69, 0x73, 0x50,
+	0x72, 0x69, 0x6d, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x32, 0xe3,
+	0x0f, 0x0a, 0x11, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x03, 0x53, 0x75, 0x6d, 0x12, 0x16, 0x2e, 0x63, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x53, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x79,
+	0x0a, 0x18, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x44, 0x65, 0x63,
+	0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x2e, 0x63, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x5b, 0x0a, 0x0e, 0x43, 0x6f, 0x6d,
+	0x70, 0x75, 0x74, 0x65, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x21, 0x2e, 0x63, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65,
+	0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22,
+	0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
+	0x75, 0x74, 0x65, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x64, 0x0a, 0x11, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x24, 0x2e, 0x63, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x25, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43,
+	0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x54, 0x0a, 0x0b,
+	0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x12, 0x1e, 0x2e, 0x63, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x78,
+	0x69, 0x6d, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x63, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x78,
+	0x69, 0x6d, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01,
+	0x30, 0x01, 0x12, 0x60, 0x0a, 0x0f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x22, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x63, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x28, 0x01, 0x30, 0x01, 0x12, 0x4d, 0x0a, 0x0a, 0x53, 0x71, 0x75, 0x61, 0x72, 0x65, 0x52, 0x6f,
+	0x6f, 0x74, 0x12, 0x1d, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x53, 0x71, 0x75, 0x61, 0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53,
+	0x71, 0x75, 0x61, 0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x08, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x12,
+	0x1b, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x45, 0x76, 0x61,
+	0x6c, 0x75, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63,
+	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x09,
+	0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x63, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5f, 0x0a, 0x10, 0x4d, 0x75, 0x6c, 0x74,
+	0x69, 0x70, 0x6c, 0x79, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x63, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x63,
+	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70,
+	0x6c, 0x79, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x24, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d,
+	0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x79, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0f, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x70, 0x6f, 0x73, 0x65, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x12, 0x19, 0x2e, 0x63,
+	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0b, 0x44, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69,
+	0x6e, 0x61, 0x6e, 0x74, 0x12, 0x19, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1f, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x44, 0x65, 0x74,
+	0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x47, 0x0a, 0x0c, 0x49, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x4d, 0x61, 0x74, 0x72,
+	0x69, 0x78, 0x12, 0x19, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e,
+	0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69,
+	0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x62, 0x0a, 0x11, 0x53,
+	0x6f, 0x6c, 0x76, 0x65, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x12, 0x24, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x53, 0x6f,
+	0x6c, 0x76, 0x65, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x53, 0x6f, 0x6c, 0x76, 0x65, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x53, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x12,
+	0x1f, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x20, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x6f,
+	0x6e, 0x76, 0x65, 0x72, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x68, 0x0a, 0x13, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
+	0x65, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x26, 0x2e, 0x63, 0x61,
+	0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61,
+	0x74, 0x65, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53,
+	0x0a, 0x15, 0x47, 0x72, 0x65, 0x61, 0x74, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x44, 0x69, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x12, 0x1b, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x13, 0x4c, 0x65, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x12, 0x1b, 0x2e, 0x63, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x15, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x61,
+	0x72, 0x45, 0x78, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x28, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x6f, 0x64,
+	0x75, 0x6c, 0x61, 0x72, 0x45, 0x78, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x61, 0x6c, 0x63,
+	0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0e, 0x4d, 0x6f, 0x64, 0x75,
+	0x6c, 0x61, 0x72, 0x49, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x65, 0x12, 0x21, 0x2e, 0x63, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x61, 0x72, 0x49,
+	0x6e, 0x76, 0x65, 0x72, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x07,
+	0x49, 0x73, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x49, 0x73, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x49, 0x73, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x5b, 0x0a, 0x0e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x50, 0x72,
+	0x69, 0x6d, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x50, 0x72, 0x69,
+	0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12,
+	0x53, 0x0a, 0x0c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x12,
+	0x1f, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x20, 0x2e, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x42, 0x1b, 0x5a, 0x19, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74,
+	0x6f, 0x72, 0x70, 0x62, 0x3b, 0x63, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_calculator_proto_rawDescOnce sync.Once
+	file_proto_calculator_proto_rawDescData = file_proto_calculator_proto_rawDesc
+)
+
+func file_proto_calculator_proto_rawDescGZIP() []byte {
+	file_proto_calculator_proto_rawDescOnce.Do(func() {
+		file_proto_calculator_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_calculator_proto_rawDescData)
+	})
+	return file_proto_calculator_proto_rawDescData
+}
+
+var file_proto_calculator_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_proto_calculator_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
+var file_proto_calculator_proto_goTypes = []interface{}{
+	(Window_Type)(0),                             // 0: calculator.Window.Type
+	(StreamAggregateRequest_Aggregation)(0),      // 1: calculator.StreamAggregateRequest.Aggregation
+	(CalculateRequest_Operation)(0),              // 2: calculator.CalculateRequest.Operation
+	(CalculateRequest_Mode)(0),                   // 3: calculator.CalculateRequest.Mode
+	(CalculateQuantitiesRequest_Operation)(0),    // 4: calculator.CalculateQuantitiesRequest.Operation
+	(*SumRequest)(nil),                           // 5: calculator.SumRequest
+	(*SumResponse)(nil),                          // 6: calculator.SumResponse
+	(*PrimeNumberDecompositionRequest)(nil),      // 7: calculator.PrimeNumberDecompositionRequest
+	(*PrimeNumberDecompositionResponse)(nil),     // 8: calculator.PrimeNumberDecompositionResponse
+	(*ComputeAverageRequest)(nil),                // 9: calculator.ComputeAverageRequest
+	(*ComputeAverageResponse)(nil),               // 10: calculator.ComputeAverageResponse
+	(*ComputeStatisticsRequest)(nil),             // 11: calculator.ComputeStatisticsRequest
+	(*ComputeStatisticsResponse)(nil),            // 12: calculator.ComputeStatisticsResponse
+	(*FindMaximumRequest)(nil),                   // 13: calculator.FindMaximumRequest
+	(*FindMaximumResponse)(nil),                  // 14: calculator.FindMaximumResponse
+	(*Window)(nil),                               // 15: calculator.Window
+	(*StreamAggregateRequest)(nil),               // 16: calculator.StreamAggregateRequest
+	(*StreamAggregateResponse)(nil),              // 17: calculator.StreamAggregateResponse
+	(*Vector)(nil),                               // 18: calculator.Vector
+	(*Matrix)(nil),                               // 19: calculator.Matrix
+	(*MultiplyMatricesRequest)(nil),              // 20: calculator.MultiplyMatricesRequest
+	(*MultiplyMatricesResponse)(nil),             // 21: calculator.MultiplyMatricesResponse
+	(*MatrixRequest)(nil),                        // 22: calculator.MatrixRequest
+	(*MatrixResponse)(nil),                       // 23: calculator.MatrixResponse
+	(*DeterminantResponse)(nil),                  // 24: calculator.DeterminantResponse
+	(*SolveLinearSystemRequest)(nil),             // 25: calculator.SolveLinearSystemRequest
+	(*SolveLinearSystemResponse)(nil),            // 26: calculator.SolveLinearSystemResponse
+	(*SquareRootRequest)(nil),                    // 27: calculator.SquareRootRequest
+	(*SquareRootResponse)(nil),                   // 28: calculator.SquareRootResponse
+	(*CalculateRequest)(nil),                     // 29: calculator.CalculateRequest
+	(*CalculateResponse)(nil),                    // 30: calculator.CalculateResponse
+	(*EvaluateRequest)(nil),                      // 31: calculator.EvaluateRequest
+	(*EvaluateResponse)(nil),                     // 32: calculator.EvaluateResponse
+	(*Quantity)(nil),                             // 33: calculator.Quantity
+	(*ConvertUnitsRequest)(nil),                  // 34: calculator.ConvertUnitsRequest
+	(*ConvertUnitsResponse)(nil),                 // 35: calculator.ConvertUnitsResponse
+	(*CalculateQuantitiesRequest)(nil),           // 36: calculator.CalculateQuantitiesRequest
+	(*CalculateQuantitiesResponse)(nil),          // 37: calculator.CalculateQuantitiesResponse
+	(*IntegersRequest)(nil),                      // 38: calculator.IntegersRequest
+	(*IntegerResponse)(nil),                      // 39: calculator.IntegerResponse
+	(*ModularExponentiationRequest)(nil),         // 40: calculator.ModularExponentiationRequest
+	(*ModularInverseRequest)(nil),                // 41: calculator.ModularInverseRequest
+	(*IsPrimeRequest)(nil),                       // 42: calculator.IsPrimeRequest
+	(*IsPrimeResponse)(nil),                      // 43: calculator.IsPrimeResponse
+	(*GeneratePrimesRequest)(nil),                // 44: calculator.GeneratePrimesRequest
+	(*GeneratePrimesResponse)(nil),               // 45: calculator.GeneratePrimesResponse
+	(*BatchComputeRequest)(nil),                  // 46: calculator.BatchComputeRequest
+	(*BatchComputeResponse)(nil),                 // 47: calculator.BatchComputeResponse
+	(*ComputeStatisticsResponse_Percentile)(nil), // 48: calculator.ComputeStatisticsResponse.Percentile
+	(*StreamAggregateRequest_Config)(nil),        // 49: calculator.StreamAggregateRequest.Config
+	nil,                                          // 50: calculator.EvaluateRequest.VariablesEntry
+	(*BatchComputeRequest_Operation)(nil),        // 51: calculator.BatchComputeRequest.Operation
+	(*BatchComputeResponse_Result)(nil),          // 52: calculator.BatchComputeResponse.Result
+	(*duration.Duration)(nil),                    // 53: google.protobuf.Duration
+	(*timestamp.Timestamp)(nil),                  // 54: google.protobuf.Timestamp
+	(*status.Status)(nil),                        // 55: google.rpc.Status
+}
+var file_proto_calculator_proto_depIdxs = []int32{
+	48, // 0: calculator.ComputeStatisticsResponse.percentiles:type_name -> calculator.ComputeStatisticsResponse.Percentile
+	0,  // 1: calculator.Window.type:type_name -> calculator.Window.Type
+	53, // 2: calculator.Window.duration:type_name -> google.protobuf.Duration
+	53, // 3: calculator.Window.slide_duration:type_name -> google.protobuf.Duration
+	49, // 4: calculator.StreamAggregateRequest.config:type_name -> calculator.StreamAggregateRequest.Config
+	54, // 5: calculator.StreamAggregateResponse.start_time:type_name -> google.protobuf.Timestamp
+	54, // 6: calculator.StreamAggregateResponse.end_time:type_name -> google.protobuf.Timestamp
+	19, // 7: calculator.MultiplyMatricesRequest.left:type_name -> calculator.Matrix
+	19, // 8: calculator.MultiplyMatricesRequest.matrix:type_name -> calculator.Matrix
+	18, // 9: calculator.MultiplyMatricesRequest.vector:type_name -> calculator.Vector
+	19, // 10: calculator.MultiplyMatricesResponse.matrix:type_name -> calculator.Matrix
+	18, // 11: calculator.MultiplyMatricesResponse.vector:type_name -> calculator.Vector
+	19, // 12: calculator.MatrixRequest.matrix:type_name -> calculator.Matrix
+	19, // 13: calculator.MatrixResponse.matrix:type_name -> calculator.Matrix
+	19, // 14: calculator.SolveLinearSystemRequest.coefficients:type_name -> calculator.Matrix
+	18, // 15: calculator.SolveLinearSystemRequest.constants:type_name -> calculator.Vector
+	18, // 16: calculator.SolveLinearSystemResponse.solution:type_name -> calculator.Vector
+	2,  // 17: calculator.CalculateRequest.operation:type_name -> calculator.CalculateRequest.Operation
+	3,  // 18: calculator.CalculateRequest.mode:type_name -> calculator.CalculateRequest.Mode
+	50, // 19: calculator.EvaluateRequest.variables:type_name -> calculator.EvaluateRequest.VariablesEntry
+	33, // 20: calculator.ConvertUnitsRequest.quantity:type_name -> calculator.Quantity
+	33, // 21: calculator.ConvertUnitsResponse.quantity:type_name -> calculator.Quantity
+	4,  // 22: calculator.CalculateQuantitiesRequest.operation:type_name -> calculator.CalculateQuantitiesRequest.Operation
+	33, // 23: calculator.CalculateQuantitiesRequest.left:type_name -> calculator.Quantity
+	33, // 24: calculator.CalculateQuantitiesRequest.right:type_name -> calculator.Quantity
+	33, // 25: calculator.CalculateQuantitiesResponse.result:type_name -> calculator.Quantity
+	51, // 26: calculator.BatchComputeRequest.operations:type_name -> calculator.BatchComputeRequest.Operation
+	52, // 27: calculator.BatchComputeResponse.results:type_name -> calculator.BatchComputeResponse.Result
+	1,  // 28: calculator.StreamAggregateRequest.Config.aggregation:type_name -> calculator.StreamAggregateRequest.Aggregation
+	15, // 29: calculator.StreamAggregateRequest.Config.window:type_name -> calculator.Window
+	5,  // 30: calculator.BatchComputeRequest.Operation.sum:type_name -> calculator.SumRequest
+	27, // 31: calculator.BatchComputeRequest.Operation.square_root:type_name -> calculator.SquareRootRequest
+	29, // 32: calculator.BatchComputeRequest.Operation.calculate:type_name -> calculator.CalculateRequest
+	31, // 33: calculator.BatchComputeRequest.Operation.evaluate:type_name -> calculator.EvaluateRequest
+	20, // 34: calculator.BatchComputeRequest.Operation.multiply_matrices:type_name -> calculator.MultiplyMatricesRequest
+	22, // 35: calculator.BatchComputeRequest.Operation.transpose_matrix:type_name -> calculator.MatrixRequest
+	22, // 36: calculator.BatchComputeRequest.Operation.determinant:type_name -> calculator.MatrixRequest
+	22, // 37: calculator.BatchComputeRequest.Operation.invert_matrix:type_name -> calculator.MatrixRequest
+	25, // 38: calculator.BatchComputeRequest.Operation.solve_linear_system:type_name -> calculator.SolveLinearSystemRequest
+	34, // 39: calculator.BatchComputeRequest.Operation.convert_units:type_name -> calculator.ConvertUnitsRequest
+	36, // 40: calculator.BatchComputeRequest.Operation.calculate_quantities:type_name -> calculator.CalculateQuantitiesRequest
+	38, // 41: calculator.BatchComputeRequest.Operation.greatest_common_divisor:type_name -> calculator.IntegersRequest
+	38, // 42: calculator.BatchComputeRequest.Operation.least_common_multiple:type_name -> calculator.IntegersRequest
+	40, // 43: calculator.BatchComputeRequest.Operation.modular_exponentiation:type_name -> calculator.ModularExponentiationRequest
+	41, // 44: calculator.BatchComputeRequest.Operation.modular_inverse:type_name -> calculator.ModularInverseRequest
+	42, // 45: calculator.BatchComputeRequest.Operation.is_prime:type_name -> calculator.IsPrimeRequest
+	55, // 46: calculator.BatchComputeResponse.Result.error:type_name -> google.rpc.Status
+	6,  // 47: calculator.BatchComputeResponse.Result.sum:type_name -> calculator.SumResponse
+	28, // 48: calculator.BatchComputeResponse.Result.square_root:type_name -> calculator.SquareRootResponse
+	30, // 49: calculator.BatchComputeResponse.Result.calculate:type_name -> calculator.CalculateResponse
+	32, // 50: calculator.BatchComputeResponse.Result.evaluate:type_name -> calculator.EvaluateResponse
+	21, // 51: calculator.BatchComputeResponse.Result.multiply_matrices:type_name -> calculator.MultiplyMatricesResponse
+	23, // 52: calculator.BatchComputeResponse.Result.transpose_matrix:type_name -> calculator.MatrixResponse
+	24, // 53: calculator.BatchComputeResponse.Result.determinant:type_name -> calculator.DeterminantResponse
+	23, // 54: calculator.BatchComputeResponse.Result.invert_matrix:type_name -> calculator.MatrixResponse
+	26, // 55: calculator.BatchComputeResponse.Result.solve_linear_system:type_name -> calculator.SolveLinearSystemResponse
+	35, // 56: calculator.BatchComputeResponse.Result.convert_units:type_name -> calculator.ConvertUnitsResponse
+	37, // 57: calculator.BatchComputeResponse.Result.calculate_quantities:type_name -> calculator.CalculateQuantitiesResponse
+	39, // 58: calculator.BatchComputeResponse.Result.greatest_common_divisor:type_name -> calculator.IntegerResponse
+	39, // 59: calculator.BatchComputeResponse.Result.least_common_multiple:type_name -> calculator.IntegerResponse
+	39, // 60: calculator.BatchComputeResponse.Result.modular_exponentiation:type_name -> calculator.IntegerResponse
+	39, // 61: calculator.BatchComputeResponse.Result.modular_inverse:type_name -> calculator.IntegerResponse
+	43, // 62: calculator.BatchComputeResponse.Result.is_prime:type_name -> calculator.IsPrimeResponse
+	5,  // 63: calculator.CalculatorService.Sum:input_type -> calculator.SumRequest
+	7,  // 64: calculator.CalculatorService.PrimeNumberDecomposition:input_type -> calculator.PrimeNumberDecompositionRequest
+	9,  // 65: calculator.CalculatorService.ComputeAverage:input_type -> calculator.ComputeAverageRequest
+	11, // 66: calculator.CalculatorService.ComputeStatistics:input_type -> calculator.ComputeStatisticsRequest
+	13, // 67: calculator.CalculatorService.FindMaximum:input_type -> calculator.FindMaximumRequest
+	16, // 68: calculator.CalculatorService.StreamAggregate:input_type -> calculator.StreamAggregateRequest
+	27, // 69: calculator.CalculatorService.SquareRoot:input_type -> calculator.SquareRootRequest
+	31, // 70: calculator.CalculatorService.Evaluate:input_type -> calculator.EvaluateRequest
+	29, // 71: calculator.CalculatorService.Calculate:input_type -> calculator.CalculateRequest
+	20, // 72: calculator.CalculatorService.MultiplyMatrices:input_type -> calculator.MultiplyMatricesRequest
+	22, // 73: calculator.CalculatorService.TransposeMatrix:input_type -> calculator.MatrixRequest
+	22, // 74: calculator.CalculatorService.Determinant:input_type -> calculator.MatrixRequest
+	22, // 75: calculator.CalculatorService.InvertMatrix:input_type -> calculator.MatrixRequest
+	25, // 76: calculator.CalculatorService.SolveLinearSystem:input_type -> calculator.SolveLinearSystemRequest
+	34, // 77: calculator.CalculatorService.ConvertUnits:input_type -> calculator.ConvertUnitsRequest
+	36, // 78: calculator.CalculatorService.CalculateQuantities:input_type -> calculator.CalculateQuantitiesRequest
+	38, // 79: calculator.CalculatorService.GreatestCommonDivisor:input_type -> calculator.IntegersRequest
+	38, // 80: calculator.CalculatorService.LeastCommonMultiple:input_type -> calculator.IntegersRequest
+	40, // 81: calculator.CalculatorService.ModularExponentiation:input_type -> calculator.ModularExponentiationRequest
+	41, // 82: calculator.CalculatorService.ModularInverse:input_type -> calculator.ModularInverseRequest
+	42, // 83: calculator.CalculatorService.IsPrime:input_type -> calculator.IsPrimeRequest
+	44, // 84: calculator.CalculatorService.GeneratePrimes:input_type -> calculator.GeneratePrimesRequest
+	46, // 85: calculator.CalculatorService.BatchCompute:input_type -> calculator.BatchComputeRequest
+	6,  // 86: calculator.CalculatorService.Sum:output_type -> calculator.SumResponse
+	8,  // 87: calculator.CalculatorService.PrimeNumberDecomposition:output_type -> calculator.PrimeNumberDecompositionResponse
+	10, // 88: calculator.CalculatorService.ComputeAverage:output_type -> calculator.ComputeAverageResponse
+	12, // 89: calculator.CalculatorService.ComputeStatistics:output_type -> calculator.ComputeStatisticsResponse
+	14, // 90: calculator.CalculatorService.FindMaximum:output_type -> calculator.FindMaximumResponse
+	17, // 91: calculator.CalculatorService.StreamAggregate:output_type -> calculator.StreamAggregateResponse
+	28, // 92: calculator.CalculatorService.SquareRoot:output_type -> calculator.SquareRootResponse
+	32, // 93: calculator.CalculatorService.Evaluate:output_type -> calculator.EvaluateResponse
+	30, // 94: calculator.CalculatorService.Calculate:output_type -> calculator.CalculateResponse
+	21, // 95: calculator.CalculatorService.MultiplyMatrices:output_type -> calculator.MultiplyMatricesResponse
+	23, // 96: calculator.CalculatorService.TransposeMatrix:output_type -> calculator.MatrixResponse
+	24, // 97: calculator.CalculatorService.Determinant:output_type -> calculator.DeterminantResponse
+	23, // 98: calculator.CalculatorService.InvertMatrix:output_type -> calculator.MatrixResponse
+	26, // 99: calculator.CalculatorService.SolveLinearSystem:output_type -> calculator.SolveLinearSystemResponse
+	35, // 100: calculator.CalculatorService.ConvertUnits:output_type -> calculator.ConvertUnitsResponse
+	37, // 101: calculator.CalculatorService.CalculateQuantities:output_type -> calculator.CalculateQuantitiesResponse
+	39, // 102: calculator.CalculatorService.GreatestCommonDivisor:output_type -> calculator.IntegerResponse
+	39, // 103: calculator.CalculatorService.LeastCommonMultiple:output_type -> calculator.IntegerResponse
+	39, // 104: calculator.CalculatorService.ModularExponentiation:output_type -> calculator.IntegerResponse
+	39, // 105: calculator.CalculatorService.ModularInverse:output_type -> calculator.IntegerResponse
+	43, // 106: calculator.CalculatorService.IsPrime:output_type -> calculator.IsPrimeResponse
+	45, // 107: calculator.CalculatorService.GeneratePrimes:output_type -> calculator.GeneratePrimesResponse
+	47, // 108: calculator.CalculatorService.BatchCompute:output_type -> calculator.BatchComputeResponse
+	86, // [86:109] is the sub-list for method output_type
+	63, // [63:86] is the sub-list for method input_type
+	63, // [63:63] is the sub-list for extension type_name
+	63, // [63:63] is the sub-list for extension extendee
+	0,  // [0:63] is the sub-list for field type_name
+}
+
+func init() { file_proto_calculator_proto_init() }
+func file_proto_calculator_proto_init() {
+	if File_proto_calculator_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
@@ -637,8 +4429,164 @@ func file_proto_calculator_proto_init() {
 				return nil
 			}
 		}
-		file_proto_calculator_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SumResponse); i {
+		file_proto_calculator_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SumResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrimeNumberDecompositionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrimeNumberDecompositionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ComputeAverageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ComputeAverageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ComputeStatisticsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ComputeStatisticsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindMaximumRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindMaximumResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Window); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamAggregateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamAggregateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Vector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Matrix); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -649,8 +4597,8 @@ func file_proto_calculator_proto_init() {
 				return nil
 			}
 		}
-		file_proto_calculator_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PrimeNumberDecompositionRequest); i {
+		file_proto_calculator_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiplyMatricesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -661,8 +4609,296 @@ func file_proto_calculator_proto_init() {
 				return nil
 			}
 		}
-		file_proto_calculator_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PrimeNumberDecompositionResponse); i {
+		file_proto_calculator_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiplyMatricesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatrixRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatrixResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeterminantResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SolveLinearSystemRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SolveLinearSystemResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SquareRootRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SquareRootResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CalculateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CalculateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvaluateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvaluateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Quantity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConvertUnitsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConvertUnitsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CalculateQuantitiesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CalculateQuantitiesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IntegersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IntegerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModularExponentiationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModularInverseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IsPrimeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IsPrimeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeneratePrimesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_calculator_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeneratePrimesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -673,8 +4909,8 @@ func file_proto_calculator_proto_init() {
 				return nil
 			}
 		}
-		file_proto_calculator_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ComputeAverageRequest); i {
+		file_proto_calculator_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchComputeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -685,8 +4921,8 @@ func file_proto_calculator_proto_init() {
 				return nil
 			}
 		}
-		file_proto_calculator_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ComputeAverageResponse); i {
+		file_proto_calculator_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchComputeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -697,8 +4933,8 @@ func file_proto_calculator_proto_init() {
 				return nil
 			}
 		}
-		file_proto_calculator_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FindMaximumRequest); i {
+		file_proto_calculator_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ComputeStatisticsResponse_Percentile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -709,8 +4945,8 @@ func file_proto_calculator_proto_init() {
 				return nil
 			}
 		}
-		file_proto_calculator_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FindMaximumResponse); i {
+		file_proto_calculator_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamAggregateRequest_Config); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -721,8 +4957,8 @@ func file_proto_calculator_proto_init() {
 				return nil
 			}
 		}
-		file_proto_calculator_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SquareRootRequest); i {
+		file_proto_calculator_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchComputeRequest_Operation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -733,8 +4969,8 @@ func file_proto_calculator_proto_init() {
 				return nil
 			}
 		}
-		file_proto_calculator_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SquareRootResponse); i {
+		file_proto_calculator_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchComputeResponse_Result); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -746,18 +4982,68 @@ func file_proto_calculator_proto_init() {
 			}
 		}
 	}
+	file_proto_calculator_proto_msgTypes[11].OneofWrappers = []interface{}{
+		(*StreamAggregateRequest_Config_)(nil),
+		(*StreamAggregateRequest_Number)(nil),
+	}
+	file_proto_calculator_proto_msgTypes[15].OneofWrappers = []interface{}{
+		(*MultiplyMatricesRequest_Matrix)(nil),
+		(*MultiplyMatricesRequest_Vector)(nil),
+	}
+	file_proto_calculator_proto_msgTypes[16].OneofWrappers = []interface{}{
+		(*MultiplyMatricesResponse_Matrix)(nil),
+		(*MultiplyMatricesResponse_Vector)(nil),
+	}
+	file_proto_calculator_proto_msgTypes[46].OneofWrappers = []interface{}{
+		(*BatchComputeRequest_Operation_Sum)(nil),
+		(*BatchComputeRequest_Operation_SquareRoot)(nil),
+		(*BatchComputeRequest_Operation_Calculate)(nil),
+		(*BatchComputeRequest_Operation_Evaluate)(nil),
+		(*BatchComputeRequest_Operation_MultiplyMatrices)(nil),
+		(*BatchComputeRequest_Operation_TransposeMatrix)(nil),
+		(*BatchComputeRequest_Operation_Determinant)(nil),
+		(*BatchComputeRequest_Operation_InvertMatrix)(nil),
+		(*BatchComputeRequest_Operation_SolveLinearSystem)(nil),
+		(*BatchComputeRequest_Operation_ConvertUnits)(nil),
+		(*BatchComputeRequest_Operation_CalculateQuantities)(nil),
+		(*BatchComputeRequest_Operation_GreatestCommonDivisor)(nil),
+		(*BatchComputeRequest_Operation_LeastCommonMultiple)(nil),
+		(*BatchComputeRequest_Operation_ModularExponentiation)(nil),
+		(*BatchComputeRequest_Operation_ModularInverse)(nil),
+		(*BatchComputeRequest_Operation_IsPrime)(nil),
+	}
+	file_proto_calculator_proto_msgTypes[47].OneofWrappers = []interface{}{
+		(*BatchComputeResponse_Result_Error)(nil),
+		(*BatchComputeResponse_Result_Sum)(nil),
+		(*BatchComputeResponse_Result_SquareRoot)(nil),
+		(*BatchComputeResponse_Result_Calculate)(nil),
+		(*BatchComputeResponse_Result_Evaluate)(nil),
+		(*BatchComputeResponse_Result_MultiplyMatrices)(nil),
+		(*BatchComputeResponse_Result_TransposeMatrix)(nil),
+		(*BatchComputeResponse_Result_Determinant)(nil),
+		(*BatchComputeResponse_Result_InvertMatrix)(nil),
+		(*BatchComputeResponse_Result_SolveLinearSystem)(nil),
+		(*BatchComputeResponse_Result_ConvertUnits)(nil),
+		(*BatchComputeResponse_Result_CalculateQuantities)(nil),
+		(*BatchComputeResponse_Result_GreatestCommonDivisor)(nil),
+		(*BatchComputeResponse_Result_LeastCommonMultiple)(nil),
+		(*BatchComputeResponse_Result_ModularExponentiation)(nil),
+		(*BatchComputeResponse_Result_ModularInverse)(nil),
+		(*BatchComputeResponse_Result_IsPrime)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_calculator_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   10,
+			NumEnums:      5,
+			NumMessages:   48,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_calculator_proto_goTypes,
 		DependencyIndexes: file_proto_calculator_proto_depIdxs,
+		EnumInfos:         file_proto_calculator_proto_enumTypes,
 		MessageInfos:      file_proto_calculator_proto_msgTypes,
 	}.Build()
 	File_proto_calculator_proto = out.File
@@ -770,47 +5056,397 @@ func file_proto_calculator_proto_init() {
 var _ context.Context
 var _ grpc.ClientConnInterface
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// CalculatorServiceClient is the client API for CalculatorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CalculatorServiceClient interface {
+	Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error)
+	// streams the prime factors of a positive number in increasing order, as
+	// many times as they divide it. Other numbers fail with INVALID_ARGUMENT,
+	// and those the server cannot factor within its time budget with
+	// DEADLINE_EXCEEDED.
+	PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error)
+	// an empty stream fails with INVALID_ARGUMENT
+	ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error)
+	// summarizes a stream of numbers; an empty stream, NaN or infinite
+	// numbers fail with INVALID_ARGUMENT
+	ComputeStatistics(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeStatisticsClient, error)
+	FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error)
+	// aggregates the numbers of a stream by window, sending a result at the
+	// end of each window that holds numbers; empty windows are skipped. When
+	// the client closes the stream, the windows it cut short are sent as
+	// partial. An invalid config fails with INVALID_ARGUMENT.
+	StreamAggregate(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_StreamAggregateClient, error)
+	// error handling
+	// this RPC will throw an exception if the sent number is negative
+	// The error being sent is of type INVALID_ARGUMENT
+	SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error)
+	// evaluates an expression with + - * / % ^, parentheses, the constants
+	// pi and e, and functions such as sqrt, pow, log, sin, cos and tan.
+	// A malformed expression fails with INVALID_ARGUMENT, the position of
+	// the error in the message and a google.rpc.BadRequest detail.
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	// arbitrary precision arithmetic on decimal strings. Invalid operands
+	// and division by zero fail with INVALID_ARGUMENT, results too large to
+	// compute with OUT_OF_RANGE.
+	Calculate(ctx context.Context, in *CalculateRequest, opts ...grpc.CallOption) (*CalculateResponse, error)
+	// linear algebra. Malformed matrices, a matrix that is not square where
+	// one is needed, and operands of mismatched dimensions fail with
+	// INVALID_ARGUMENT; inverting a singular matrix or solving a system
+	// without a unique solution fails with FAILED_PRECONDITION.
+	MultiplyMatrices(ctx context.Context, in *MultiplyMatricesRequest, opts ...grpc.CallOption) (*MultiplyMatricesResponse, error)
+	TransposeMatrix(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (*MatrixResponse, error)
+	Determinant(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (*DeterminantResponse, error)
+	InvertMatrix(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (*MatrixResponse, error)
+	SolveLinearSystem(ctx context.Context, in *SolveLinearSystemRequest, opts ...grpc.CallOption) (*SolveLinearSystemResponse, error)
+	// converts a quantity to another unit of length, mass, time,
+	// temperature, data size or rate. Unknown units and units of different
+	// dimensions fail with INVALID_ARGUMENT.
+	ConvertUnits(ctx context.Context, in *ConvertUnitsRequest, opts ...grpc.CallOption) (*ConvertUnitsResponse, error)
+	// adds, subtracts, multiplies or divides quantities. Adding or
+	// subtracting quantities of different dimensions, such as meters and
+	// seconds, fails with INVALID_ARGUMENT.
+	CalculateQuantities(ctx context.Context, in *CalculateQuantitiesRequest, opts ...grpc.CallOption) (*CalculateQuantitiesResponse, error)
+	// the number theory RPCs fail with INVALID_ARGUMENT on numbers that are
+	// not integers, and with FAILED_PRECONDITION when a number has no
+	// modular inverse
+	GreatestCommonDivisor(ctx context.Context, in *IntegersRequest, opts ...grpc.CallOption) (*IntegerResponse, error)
+	LeastCommonMultiple(ctx context.Context, in *IntegersRequest, opts ...grpc.CallOption) (*IntegerResponse, error)
+	ModularExponentiation(ctx context.Context, in *ModularExponentiationRequest, opts ...grpc.CallOption) (*IntegerResponse, error)
+	ModularInverse(ctx context.Context, in *ModularInverseRequest, opts ...grpc.CallOption) (*IntegerResponse, error)
+	IsPrime(ctx context.Context, in *IsPrimeRequest, opts ...grpc.CallOption) (*IsPrimeResponse, error)
+	// streams the primes of a range with a segmented sieve of Eratosthenes
+	GeneratePrimes(ctx context.Context, in *GeneratePrimesRequest, opts ...grpc.CallOption) (CalculatorService_GeneratePrimesClient, error)
+	// performs operations of the unary RPCs in parallel. The failure of an
+	// operation is its result and does not fail the others; the call itself
+	// only fails with INVALID_ARGUMENT when there are too many operations.
+	BatchCompute(ctx context.Context, in *BatchComputeRequest, opts ...grpc.CallOption) (*BatchComputeResponse, error)
+}
+
+type calculatorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCalculatorServiceClient(cc grpc.ClientConnInterface) CalculatorServiceClient {
+	return &calculatorServiceClient{cc}
+}
+
+func (c *calculatorServiceClient) Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error) {
+	out := new(SumResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Sum", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[0], "/calculator.CalculatorService/PrimeNumberDecomposition", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServicePrimeNumberDecompositionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CalculatorService_PrimeNumberDecompositionClient interface {
+	Recv() (*PrimeNumberDecompositionResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServicePrimeNumberDecompositionClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServicePrimeNumberDecompositionClient) Recv() (*PrimeNumberDecompositionResponse, error) {
+	m := new(PrimeNumberDecompositionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[1], "/calculator.CalculatorService/ComputeAverage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceComputeAverageClient{stream}
+	return x, nil
+}
+
+type CalculatorService_ComputeAverageClient interface {
+	Send(*ComputeAverageRequest) error
+	CloseAndRecv() (*ComputeAverageResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceComputeAverageClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceComputeAverageClient) Send(m *ComputeAverageRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceComputeAverageClient) CloseAndRecv() (*ComputeAverageResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ComputeAverageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) ComputeStatistics(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeStatisticsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[2], "/calculator.CalculatorService/ComputeStatistics", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceComputeStatisticsClient{stream}
+	return x, nil
+}
+
+type CalculatorService_ComputeStatisticsClient interface {
+	Send(*ComputeStatisticsRequest) error
+	CloseAndRecv() (*ComputeStatisticsResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceComputeStatisticsClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceComputeStatisticsClient) Send(m *ComputeStatisticsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceComputeStatisticsClient) CloseAndRecv() (*ComputeStatisticsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ComputeStatisticsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[3], "/calculator.CalculatorService/FindMaximum", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceFindMaximumClient{stream}
+	return x, nil
+}
+
+type CalculatorService_FindMaximumClient interface {
+	Send(*FindMaximumRequest) error
+	Recv() (*FindMaximumResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceFindMaximumClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceFindMaximumClient) Send(m *FindMaximumRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceFindMaximumClient) Recv() (*FindMaximumResponse, error) {
+	m := new(FindMaximumResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) StreamAggregate(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_StreamAggregateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[4], "/calculator.CalculatorService/StreamAggregate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceStreamAggregateClient{stream}
+	return x, nil
+}
+
+type CalculatorService_StreamAggregateClient interface {
+	Send(*StreamAggregateRequest) error
+	Recv() (*StreamAggregateResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceStreamAggregateClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceStreamAggregateClient) Send(m *StreamAggregateRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceStreamAggregateClient) Recv() (*StreamAggregateResponse, error) {
+	m := new(StreamAggregateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error) {
+	out := new(SquareRootResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/SquareRoot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Evaluate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Calculate(ctx context.Context, in *CalculateRequest, opts ...grpc.CallOption) (*CalculateResponse, error) {
+	out := new(CalculateResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Calculate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) MultiplyMatrices(ctx context.Context, in *MultiplyMatricesRequest, opts ...grpc.CallOption) (*MultiplyMatricesResponse, error) {
+	out := new(MultiplyMatricesResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/MultiplyMatrices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) TransposeMatrix(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (*MatrixResponse, error) {
+	out := new(MatrixResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/TransposeMatrix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Determinant(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (*DeterminantResponse, error) {
+	out := new(DeterminantResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Determinant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) InvertMatrix(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (*MatrixResponse, error) {
+	out := new(MatrixResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/InvertMatrix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) SolveLinearSystem(ctx context.Context, in *SolveLinearSystemRequest, opts ...grpc.CallOption) (*SolveLinearSystemResponse, error) {
+	out := new(SolveLinearSystemResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/SolveLinearSystem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) ConvertUnits(ctx context.Context, in *ConvertUnitsRequest, opts ...grpc.CallOption) (*ConvertUnitsResponse, error) {
+	out := new(ConvertUnitsResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/ConvertUnits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) CalculateQuantities(ctx context.Context, in *CalculateQuantitiesRequest, opts ...grpc.CallOption) (*CalculateQuantitiesResponse, error) {
+	out := new(CalculateQuantitiesResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/CalculateQuantities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) GreatestCommonDivisor(ctx context.Context, in *IntegersRequest, opts ...grpc.CallOption) (*IntegerResponse, error) {
+	out := new(IntegerResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/GreatestCommonDivisor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// CalculatorServiceClient is the client API for CalculatorService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type CalculatorServiceClient interface {
-	Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error)
-	PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error)
-	ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error)
-	FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error)
-	// error handling
-	// this RPC will throw an exception if the sent number is negative
-	// The error being sent is of type INVALID_ARGUMENT
-	SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error)
+func (c *calculatorServiceClient) LeastCommonMultiple(ctx context.Context, in *IntegersRequest, opts ...grpc.CallOption) (*IntegerResponse, error) {
+	out := new(IntegerResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/LeastCommonMultiple", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type calculatorServiceClient struct {
-	cc grpc.ClientConnInterface
+func (c *calculatorServiceClient) ModularExponentiation(ctx context.Context, in *ModularExponentiationRequest, opts ...grpc.CallOption) (*IntegerResponse, error) {
+	out := new(IntegerResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/ModularExponentiation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewCalculatorServiceClient(cc grpc.ClientConnInterface) CalculatorServiceClient {
-	return &calculatorServiceClient{cc}
+func (c *calculatorServiceClient) ModularInverse(ctx context.Context, in *ModularInverseRequest, opts ...grpc.CallOption) (*IntegerResponse, error) {
+	out := new(IntegerResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/ModularInverse", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *calculatorServiceClient) Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error) {
-	out := new(SumResponse)
-	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Sum", in, out, opts...)
+func (c *calculatorServiceClient) IsPrime(ctx context.Context, in *IsPrimeRequest, opts ...grpc.CallOption) (*IsPrimeResponse, error) {
+	out := new(IsPrimeResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/IsPrime", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *calculatorServiceClient) PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[0], "/calculator.CalculatorService/PrimeNumberDecomposition", opts...)
+func (c *calculatorServiceClient) GeneratePrimes(ctx context.Context, in *GeneratePrimesRequest, opts ...grpc.CallOption) (CalculatorService_GeneratePrimesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[5], "/calculator.CalculatorService/GeneratePrimes", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &calculatorServicePrimeNumberDecompositionClient{stream}
+	x := &calculatorServiceGeneratePrimesClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -820,238 +5456,623 @@ func (c *calculatorServiceClient) PrimeNumberDecomposition(ctx context.Context,
 	return x, nil
 }
 
-type CalculatorService_PrimeNumberDecompositionClient interface {
-	Recv() (*PrimeNumberDecompositionResponse, error)
+type CalculatorService_GeneratePrimesClient interface {
+	Recv() (*GeneratePrimesResponse, error)
 	grpc.ClientStream
 }
 
-type calculatorServicePrimeNumberDecompositionClient struct {
+type calculatorServiceGeneratePrimesClient struct {
 	grpc.ClientStream
 }
 
-func (x *calculatorServicePrimeNumberDecompositionClient) Recv() (*PrimeNumberDecompositionResponse, error) {
-	m := new(PrimeNumberDecompositionResponse)
+func (x *calculatorServiceGeneratePrimesClient) Recv() (*GeneratePrimesResponse, error) {
+	m := new(GeneratePrimesResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *calculatorServiceClient) ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[1], "/calculator.CalculatorService/ComputeAverage", opts...)
+func (c *calculatorServiceClient) BatchCompute(ctx context.Context, in *BatchComputeRequest, opts ...grpc.CallOption) (*BatchComputeResponse, error) {
+	out := new(BatchComputeResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/BatchCompute", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &calculatorServiceComputeAverageClient{stream}
-	return x, nil
+	return out, nil
 }
 
-type CalculatorService_ComputeAverageClient interface {
-	Send(*ComputeAverageRequest) error
-	CloseAndRecv() (*ComputeAverageResponse, error)
-	grpc.ClientStream
+// CalculatorServiceServer is the server API for CalculatorService service.
+type CalculatorServiceServer interface {
+	Sum(context.Context, *SumRequest) (*SumResponse, error)
+	// streams the prime factors of a positive number in increasing order, as
+	// many times as they divide it. Other numbers fail with INVALID_ARGUMENT,
+	// and those the server cannot factor within its time budget with
+	// DEADLINE_EXCEEDED.
+	PrimeNumberDecomposition(*PrimeNumberDecompositionRequest, CalculatorService_PrimeNumberDecompositionServer) error
+	// an empty stream fails with INVALID_ARGUMENT
+	ComputeAverage(CalculatorService_ComputeAverageServer) error
+	// summarizes a stream of numbers; an empty stream, NaN or infinite
+	// numbers fail with INVALID_ARGUMENT
+	ComputeStatistics(CalculatorService_ComputeStatisticsServer) error
+	FindMaximum(CalculatorService_FindMaximumServer) error
+	// aggregates the numbers of a stream by window, sending a result at the
+	// end of each window that holds numbers; empty windows are skipped. When
+	// the client closes the stream, the windows it cut short are sent as
+	// partial. An invalid config fails with INVALID_ARGUMENT.
+	StreamAggregate(CalculatorService_StreamAggregateServer) error
+	// error handling
+	// this RPC will throw an exception if the sent number is negative
+	// The error being sent is of type INVALID_ARGUMENT
+	SquareRoot(context.Context, *SquareRootRequest) (*SquareRootResponse, error)
+	// evaluates an expression with + - * / % ^, parentheses, the constants
+	// pi and e, and functions such as sqrt, pow, log, sin, cos and tan.
+	// A malformed expression fails with INVALID_ARGUMENT, the position of
+	// the error in the message and a google.rpc.BadRequest detail.
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	// arbitrary precision arithmetic on decimal strings. Invalid operands
+	// and division by zero fail with INVALID_ARGUMENT, results too large to
+	// compute with OUT_OF_RANGE.
+	Calculate(context.Context, *CalculateRequest) (*CalculateResponse, error)
+	// linear algebra. Malformed matrices, a matrix that is not square where
+	// one is needed, and operands of mismatched dimensions fail with
+	// INVALID_ARGUMENT; inverting a singular matrix or solving a system
+	// without a unique solution fails with FAILED_PRECONDITION.
+	MultiplyMatrices(context.Context, *MultiplyMatricesRequest) (*MultiplyMatricesResponse, error)
+	TransposeMatrix(context.Context, *MatrixRequest) (*MatrixResponse, error)
+	Determinant(context.Context, *MatrixRequest) (*DeterminantResponse, error)
+	InvertMatrix(context.Context, *MatrixRequest) (*MatrixResponse, error)
+	SolveLinearSystem(context.Context, *SolveLinearSystemRequest) (*SolveLinearSystemResponse, error)
+	// converts a quantity to another unit of length, mass, time,
+	// temperature, data size or rate. Unknown units and units of different
+	// dimensions fail with INVALID_ARGUMENT.
+	ConvertUnits(context.Context, *ConvertUnitsRequest) (*ConvertUnitsResponse, error)
+	// adds, subtracts, multiplies or divides quantities. Adding or
+	// subtracting quantities of different dimensions, such as meters and
+	// seconds, fails with INVALID_ARGUMENT.
+	CalculateQuantities(context.Context, *CalculateQuantitiesRequest) (*CalculateQuantitiesResponse, error)
+	// the number theory RPCs fail with INVALID_ARGUMENT on numbers that are
+	// not integers, and with FAILED_PRECONDITION when a number has no
+	// modular inverse
+	GreatestCommonDivisor(context.Context, *IntegersRequest) (*IntegerResponse, error)
+	LeastCommonMultiple(context.Context, *IntegersRequest) (*IntegerResponse, error)
+	ModularExponentiation(context.Context, *ModularExponentiationRequest) (*IntegerResponse, error)
+	ModularInverse(context.Context, *ModularInverseRequest) (*IntegerResponse, error)
+	IsPrime(context.Context, *IsPrimeRequest) (*IsPrimeResponse, error)
+	// streams the primes of a range with a segmented sieve of Eratosthenes
+	GeneratePrimes(*GeneratePrimesRequest, CalculatorService_GeneratePrimesServer) error
+	// performs operations of the unary RPCs in parallel. The failure of an
+	// operation is its result and does not fail the others; the call itself
+	// only fails with INVALID_ARGUMENT when there are too many operations.
+	BatchCompute(context.Context, *BatchComputeRequest) (*BatchComputeResponse, error)
 }
 
-type calculatorServiceComputeAverageClient struct {
-	grpc.ClientStream
+// UnimplementedCalculatorServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCalculatorServiceServer struct {
 }
 
-func (x *calculatorServiceComputeAverageClient) Send(m *ComputeAverageRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (*UnimplementedCalculatorServiceServer) Sum(context.Context, *SumRequest) (*SumResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method Sum not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) PrimeNumberDecomposition(*PrimeNumberDecompositionRequest, CalculatorService_PrimeNumberDecompositionServer) error {
+	return status1.Errorf(codes.Unimplemented, "method PrimeNumberDecomposition not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) ComputeAverage(CalculatorService_ComputeAverageServer) error {
+	return status1.Errorf(codes.Unimplemented, "method ComputeAverage not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) ComputeStatistics(CalculatorService_ComputeStatisticsServer) error {
+	return status1.Errorf(codes.Unimplemented, "method ComputeStatistics not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) FindMaximum(CalculatorService_FindMaximumServer) error {
+	return status1.Errorf(codes.Unimplemented, "method FindMaximum not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) StreamAggregate(CalculatorService_StreamAggregateServer) error {
+	return status1.Errorf(codes.Unimplemented, "method StreamAggregate not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) SquareRoot(context.Context, *SquareRootRequest) (*SquareRootResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method SquareRoot not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method Evaluate not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Calculate(context.Context, *CalculateRequest) (*CalculateResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method Calculate not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) MultiplyMatrices(context.Context, *MultiplyMatricesRequest) (*MultiplyMatricesResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method MultiplyMatrices not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) TransposeMatrix(context.Context, *MatrixRequest) (*MatrixResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method TransposeMatrix not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Determinant(context.Context, *MatrixRequest) (*DeterminantResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method Determinant not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) InvertMatrix(context.Context, *MatrixRequest) (*MatrixResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method InvertMatrix not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) SolveLinearSystem(context.Context, *SolveLinearSystemRequest) (*SolveLinearSystemResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method SolveLinearSystem not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) ConvertUnits(context.Context, *ConvertUnitsRequest) (*ConvertUnitsResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ConvertUnits not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) CalculateQuantities(context.Context, *CalculateQuantitiesRequest) (*CalculateQuantitiesResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method CalculateQuantities not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) GreatestCommonDivisor(context.Context, *IntegersRequest) (*IntegerResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method GreatestCommonDivisor not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) LeastCommonMultiple(context.Context, *IntegersRequest) (*IntegerResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method LeastCommonMultiple not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) ModularExponentiation(context.Context, *ModularExponentiationRequest) (*IntegerResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ModularExponentiation not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) ModularInverse(context.Context, *ModularInverseRequest) (*IntegerResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method ModularInverse not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) IsPrime(context.Context, *IsPrimeRequest) (*IsPrimeResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method IsPrime not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) GeneratePrimes(*GeneratePrimesRequest, CalculatorService_GeneratePrimesServer) error {
+	return status1.Errorf(codes.Unimplemented, "method GeneratePrimes not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) BatchCompute(context.Context, *BatchComputeRequest) (*BatchComputeResponse, error) {
+	return nil, status1.Errorf(codes.Unimplemented, "method BatchCompute not implemented")
 }
 
-func (x *calculatorServiceComputeAverageClient) CloseAndRecv() (*ComputeAverageResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
+func RegisterCalculatorServiceServer(s *grpc.Server, srv CalculatorServiceServer) {
+	s.RegisterService(&_CalculatorService_serviceDesc, srv)
+}
+
+func _CalculatorService_Sum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SumRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	m := new(ComputeAverageResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Sum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Sum",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Sum(ctx, req.(*SumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_PrimeNumberDecomposition_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PrimeNumberDecompositionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalculatorServiceServer).PrimeNumberDecomposition(m, &calculatorServicePrimeNumberDecompositionServer{stream})
+}
+
+type CalculatorService_PrimeNumberDecompositionServer interface {
+	Send(*PrimeNumberDecompositionResponse) error
+	grpc.ServerStream
+}
+
+type calculatorServicePrimeNumberDecompositionServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServicePrimeNumberDecompositionServer) Send(m *PrimeNumberDecompositionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CalculatorService_ComputeAverage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).ComputeAverage(&calculatorServiceComputeAverageServer{stream})
+}
+
+type CalculatorService_ComputeAverageServer interface {
+	SendAndClose(*ComputeAverageResponse) error
+	Recv() (*ComputeAverageRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceComputeAverageServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServiceComputeAverageServer) SendAndClose(m *ComputeAverageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceComputeAverageServer) Recv() (*ComputeAverageRequest, error) {
+	m := new(ComputeAverageRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *calculatorServiceClient) FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[2], "/calculator.CalculatorService/FindMaximum", opts...)
-	if err != nil {
+func _CalculatorService_ComputeStatistics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).ComputeStatistics(&calculatorServiceComputeStatisticsServer{stream})
+}
+
+type CalculatorService_ComputeStatisticsServer interface {
+	SendAndClose(*ComputeStatisticsResponse) error
+	Recv() (*ComputeStatisticsRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceComputeStatisticsServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServiceComputeStatisticsServer) SendAndClose(m *ComputeStatisticsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceComputeStatisticsServer) Recv() (*ComputeStatisticsRequest, error) {
+	m := new(ComputeStatisticsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	x := &calculatorServiceFindMaximumClient{stream}
-	return x, nil
+	return m, nil
 }
 
-type CalculatorService_FindMaximumClient interface {
-	Send(*FindMaximumRequest) error
-	Recv() (*FindMaximumResponse, error)
-	grpc.ClientStream
+func _CalculatorService_FindMaximum_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).FindMaximum(&calculatorServiceFindMaximumServer{stream})
 }
 
-type calculatorServiceFindMaximumClient struct {
-	grpc.ClientStream
+type CalculatorService_FindMaximumServer interface {
+	Send(*FindMaximumResponse) error
+	Recv() (*FindMaximumRequest, error)
+	grpc.ServerStream
 }
 
-func (x *calculatorServiceFindMaximumClient) Send(m *FindMaximumRequest) error {
-	return x.ClientStream.SendMsg(m)
+type calculatorServiceFindMaximumServer struct {
+	grpc.ServerStream
 }
 
-func (x *calculatorServiceFindMaximumClient) Recv() (*FindMaximumResponse, error) {
-	m := new(FindMaximumResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func (x *calculatorServiceFindMaximumServer) Send(m *FindMaximumResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceFindMaximumServer) Recv() (*FindMaximumRequest, error) {
+	m := new(FindMaximumRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _CalculatorService_StreamAggregate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).StreamAggregate(&calculatorServiceStreamAggregateServer{stream})
+}
+
+type CalculatorService_StreamAggregateServer interface {
+	Send(*StreamAggregateResponse) error
+	Recv() (*StreamAggregateRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceStreamAggregateServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServiceStreamAggregateServer) Send(m *StreamAggregateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceStreamAggregateServer) Recv() (*StreamAggregateRequest, error) {
+	m := new(StreamAggregateRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _CalculatorService_SquareRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SquareRootRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).SquareRoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/SquareRoot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).SquareRoot(ctx, req.(*SquareRootRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Evaluate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *calculatorServiceClient) SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error) {
-	out := new(SquareRootResponse)
-	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/SquareRoot", in, out, opts...)
-	if err != nil {
+func _CalculatorService_Calculate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// CalculatorServiceServer is the server API for CalculatorService service.
-type CalculatorServiceServer interface {
-	Sum(context.Context, *SumRequest) (*SumResponse, error)
-	PrimeNumberDecomposition(*PrimeNumberDecompositionRequest, CalculatorService_PrimeNumberDecompositionServer) error
-	ComputeAverage(CalculatorService_ComputeAverageServer) error
-	FindMaximum(CalculatorService_FindMaximumServer) error
-	// error handling
-	// this RPC will throw an exception if the sent number is negative
-	// The error being sent is of type INVALID_ARGUMENT
-	SquareRoot(context.Context, *SquareRootRequest) (*SquareRootResponse, error)
-}
-
-// UnimplementedCalculatorServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedCalculatorServiceServer struct {
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Calculate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Calculate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Calculate(ctx, req.(*CalculateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (*UnimplementedCalculatorServiceServer) Sum(context.Context, *SumRequest) (*SumResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Sum not implemented")
-}
-func (*UnimplementedCalculatorServiceServer) PrimeNumberDecomposition(*PrimeNumberDecompositionRequest, CalculatorService_PrimeNumberDecompositionServer) error {
-	return status.Errorf(codes.Unimplemented, "method PrimeNumberDecomposition not implemented")
-}
-func (*UnimplementedCalculatorServiceServer) ComputeAverage(CalculatorService_ComputeAverageServer) error {
-	return status.Errorf(codes.Unimplemented, "method ComputeAverage not implemented")
-}
-func (*UnimplementedCalculatorServiceServer) FindMaximum(CalculatorService_FindMaximumServer) error {
-	return status.Errorf(codes.Unimplemented, "method FindMaximum not implemented")
-}
-func (*UnimplementedCalculatorServiceServer) SquareRoot(context.Context, *SquareRootRequest) (*SquareRootResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SquareRoot not implemented")
+func _CalculatorService_MultiplyMatrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiplyMatricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).MultiplyMatrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/MultiplyMatrices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).MultiplyMatrices(ctx, req.(*MultiplyMatricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterCalculatorServiceServer(s *grpc.Server, srv CalculatorServiceServer) {
-	s.RegisterService(&_CalculatorService_serviceDesc, srv)
+func _CalculatorService_TransposeMatrix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).TransposeMatrix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/TransposeMatrix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).TransposeMatrix(ctx, req.(*MatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _CalculatorService_Sum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SumRequest)
+func _CalculatorService_Determinant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatrixRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalculatorServiceServer).Sum(ctx, in)
+		return srv.(CalculatorServiceServer).Determinant(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/calculator.CalculatorService/Sum",
+		FullMethod: "/calculator.CalculatorService/Determinant",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalculatorServiceServer).Sum(ctx, req.(*SumRequest))
+		return srv.(CalculatorServiceServer).Determinant(ctx, req.(*MatrixRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CalculatorService_PrimeNumberDecomposition_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PrimeNumberDecompositionRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _CalculatorService_InvertMatrix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(CalculatorServiceServer).PrimeNumberDecomposition(m, &calculatorServicePrimeNumberDecompositionServer{stream})
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).InvertMatrix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/InvertMatrix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).InvertMatrix(ctx, req.(*MatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type CalculatorService_PrimeNumberDecompositionServer interface {
-	Send(*PrimeNumberDecompositionResponse) error
-	grpc.ServerStream
+func _CalculatorService_SolveLinearSystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SolveLinearSystemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).SolveLinearSystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/SolveLinearSystem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).SolveLinearSystem(ctx, req.(*SolveLinearSystemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type calculatorServicePrimeNumberDecompositionServer struct {
-	grpc.ServerStream
+func _CalculatorService_ConvertUnits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertUnitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).ConvertUnits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/ConvertUnits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).ConvertUnits(ctx, req.(*ConvertUnitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *calculatorServicePrimeNumberDecompositionServer) Send(m *PrimeNumberDecompositionResponse) error {
-	return x.ServerStream.SendMsg(m)
+func _CalculatorService_CalculateQuantities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateQuantitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).CalculateQuantities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/CalculateQuantities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).CalculateQuantities(ctx, req.(*CalculateQuantitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _CalculatorService_ComputeAverage_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(CalculatorServiceServer).ComputeAverage(&calculatorServiceComputeAverageServer{stream})
+func _CalculatorService_GreatestCommonDivisor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntegersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).GreatestCommonDivisor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/GreatestCommonDivisor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).GreatestCommonDivisor(ctx, req.(*IntegersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type CalculatorService_ComputeAverageServer interface {
-	SendAndClose(*ComputeAverageResponse) error
-	Recv() (*ComputeAverageRequest, error)
-	grpc.ServerStream
+func _CalculatorService_LeastCommonMultiple_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntegersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).LeastCommonMultiple(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/LeastCommonMultiple",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).LeastCommonMultiple(ctx, req.(*IntegersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type calculatorServiceComputeAverageServer struct {
-	grpc.ServerStream
+func _CalculatorService_ModularExponentiation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModularExponentiationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).ModularExponentiation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/ModularExponentiation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).ModularExponentiation(ctx, req.(*ModularExponentiationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *calculatorServiceComputeAverageServer) SendAndClose(m *ComputeAverageResponse) error {
-	return x.ServerStream.SendMsg(m)
+func _CalculatorService_ModularInverse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModularInverseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).ModularInverse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/ModularInverse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).ModularInverse(ctx, req.(*ModularInverseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *calculatorServiceComputeAverageServer) Recv() (*ComputeAverageRequest, error) {
-	m := new(ComputeAverageRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func _CalculatorService_IsPrime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsPrimeRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).IsPrime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/IsPrime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).IsPrime(ctx, req.(*IsPrimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _CalculatorService_FindMaximum_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(CalculatorServiceServer).FindMaximum(&calculatorServiceFindMaximumServer{stream})
+func _CalculatorService_GeneratePrimes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GeneratePrimesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalculatorServiceServer).GeneratePrimes(m, &calculatorServiceGeneratePrimesServer{stream})
 }
 
-type CalculatorService_FindMaximumServer interface {
-	Send(*FindMaximumResponse) error
-	Recv() (*FindMaximumRequest, error)
+type CalculatorService_GeneratePrimesServer interface {
+	Send(*GeneratePrimesResponse) error
 	grpc.ServerStream
 }
 
-type calculatorServiceFindMaximumServer struct {
+type calculatorServiceGeneratePrimesServer struct {
 	grpc.ServerStream
 }
 
-func (x *calculatorServiceFindMaximumServer) Send(m *FindMaximumResponse) error {
+func (x *calculatorServiceGeneratePrimesServer) Send(m *GeneratePrimesResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *calculatorServiceFindMaximumServer) Recv() (*FindMaximumRequest, error) {
-	m := new(FindMaximumRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
-}
-
-func _CalculatorService_SquareRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SquareRootRequest)
+func _CalculatorService_BatchCompute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchComputeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalculatorServiceServer).SquareRoot(ctx, in)
+		return srv.(CalculatorServiceServer).BatchCompute(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/calculator.CalculatorService/SquareRoot",
+		FullMethod: "/calculator.CalculatorService/BatchCompute",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalculatorServiceServer).SquareRoot(ctx, req.(*SquareRootRequest))
+		return srv.(CalculatorServiceServer).BatchCompute(ctx, req.(*BatchComputeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1068,6 +6089,66 @@ var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SquareRoot",
 			Handler:    _CalculatorService_SquareRoot_Handler,
 		},
+		{
+			MethodName: "Evaluate",
+			Handler:    _CalculatorService_Evaluate_Handler,
+		},
+		{
+			MethodName: "Calculate",
+			Handler:    _CalculatorService_Calculate_Handler,
+		},
+		{
+			MethodName: "MultiplyMatrices",
+			Handler:    _CalculatorService_MultiplyMatrices_Handler,
+		},
+		{
+			MethodName: "TransposeMatrix",
+			Handler:    _CalculatorService_TransposeMatrix_Handler,
+		},
+		{
+			MethodName: "Determinant",
+			Handler:    _CalculatorService_Determinant_Handler,
+		},
+		{
+			MethodName: "InvertMatrix",
+			Handler:    _CalculatorService_InvertMatrix_Handler,
+		},
+		{
+			MethodName: "SolveLinearSystem",
+			Handler:    _CalculatorService_SolveLinearSystem_Handler,
+		},
+		{
+			MethodName: "ConvertUnits",
+			Handler:    _CalculatorService_ConvertUnits_Handler,
+		},
+		{
+			MethodName: "CalculateQuantities",
+			Handler:    _CalculatorService_CalculateQuantities_Handler,
+		},
+		{
+			MethodName: "GreatestCommonDivisor",
+			Handler:    _CalculatorService_GreatestCommonDivisor_Handler,
+		},
+		{
+			MethodName: "LeastCommonMultiple",
+			Handler:    _CalculatorService_LeastCommonMultiple_Handler,
+		},
+		{
+			MethodName: "ModularExponentiation",
+			Handler:    _CalculatorService_ModularExponentiation_Handler,
+		},
+		{
+			MethodName: "ModularInverse",
+			Handler:    _CalculatorService_ModularInverse_Handler,
+		},
+		{
+			MethodName: "IsPrime",
+			Handler:    _CalculatorService_IsPrime_Handler,
+		},
+		{
+			MethodName: "BatchCompute",
+			Handler:    _CalculatorService_BatchCompute_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1080,12 +6161,28 @@ var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _CalculatorService_ComputeAverage_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ComputeStatistics",
+			Handler:       _CalculatorService_ComputeStatistics_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "FindMaximum",
 			Handler:       _CalculatorService_FindMaximum_Handler,
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamAggregate",
+			Handler:       _CalculatorService_StreamAggregate_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GeneratePrimes",
+			Handler:       _CalculatorService_GeneratePrimes_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "proto/calculator.proto",
 }
@@ -0,0 +1,95 @@
+package calculatorfake_test
+
+import (
+	"calculator/calculatorpb"
+	"calculator/calculatorpb/calculatorfake"
+	"context"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestClient(t *testing.T) {
+	c := &calculatorfake.CalculatorServiceClient{}
+	c.OnSum(&calculatorpb.SumResponse{SumResult: 13}, nil)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "7")
+
+	res, err := c.Sum(ctx, &calculatorpb.SumRequest{FirstNumber: 3, SecondNumber: 10})
+	if err != nil || res.GetSumResult() != 13 {
+		t.Errorf("Sum = %v, %v; want 13", res, err)
+	}
+	if _, err := c.SquareRoot(ctx, &calculatorpb.SquareRootRequest{Number: 4}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("unscripted SquareRoot: got %v, want Unimplemented", err)
+	}
+
+	calls := c.Calls()
+	if len(calls) != 2 || calls[0].Method != "/calculator.CalculatorService/Sum" {
+		t.Fatalf("recorded %v", calls)
+	}
+	if !proto.Equal(calls[0].Request(), &calculatorpb.SumRequest{FirstNumber: 3, SecondNumber: 10}) {
+		t.Errorf("recorded request %v", calls[0].Request())
+	}
+	if got := calls[0].Metadata.Get("x-request-id"); len(got) != 1 || got[0] != "7" {
+		t.Errorf("x-request-id = %v, want [7]", got)
+	}
+}
+
+func TestServer(t *testing.T) {
+	s := &calculatorfake.CalculatorServiceServer{}
+	s.OnComputeAverage(&calculatorpb.ComputeAverageResponse{Average: 2.5}, nil)
+	c := s.Client(t)
+
+	stream, err := c.ComputeAverage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []int32{1, 2, 3, 4} {
+		if err := stream.Send(&calculatorpb.ComputeAverageRequest{Number: n}); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil || res.GetAverage() != 2.5 {
+		t.Fatalf("CloseAndRecv = %v, %v; want 2.5", res, err)
+	}
+	calls := s.CallsTo("/calculator.CalculatorService/ComputeAverage")
+	if len(calls) != 1 || len(calls[0].Requests) != 4 {
+		t.Errorf("recorded %v", calls)
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	s := &calculatorfake.CalculatorServiceServer{}
+	s.OnPrimeNumberDecomposition([]*calculatorpb.PrimeNumberDecompositionResponse{{PrimeFactor: 2}, {PrimeFactor: 5}}, nil)
+	c := s.Client(t)
+	factors := func() ([]int64, error) {
+		stream, err := c.PrimeNumberDecomposition(context.Background(), &calculatorpb.PrimeNumberDecompositionRequest{Number: 10})
+		if err != nil {
+			return nil, err
+		}
+		var got []int64
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return got, nil
+			}
+			if err != nil {
+				return got, err
+			}
+			got = append(got, res.GetPrimeFactor())
+		}
+	}
+
+	s.SetError("", status.Error(codes.ResourceExhausted, "busy"), 1)
+	if _, err := factors(); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("with error injected: got %v, want ResourceExhausted", err)
+	}
+	s.SetError("", nil, 0)
+	if got, err := factors(); err != nil || len(got) != 2 || got[0] != 2 || got[1] != 5 {
+		t.Errorf("without faults: got %v, %v; want [2 5]", got, err)
+	}
+}
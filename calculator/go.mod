@@ -3,6 +3,13 @@ module calculator
 go 1.15
 
 require (
-	google.golang.org/grpc v1.34.0
-	google.golang.org/protobuf v1.25.0
+	common v0.0.0
+	github.com/golang/protobuf v1.5.2
+	github.com/grpc-ecosystem/go-grpc-middleware v1.2.2
+	github.com/prometheus/client_golang v1.3.0
+	google.golang.org/genproto v0.0.0-20200806141610-86f49bd18e98
+	google.golang.org/grpc v1.41.0
+	google.golang.org/protobuf v1.27.1
 )
+
+replace common => ../common
@@ -0,0 +1,23 @@
+module cli
+
+go 1.15
+
+require (
+	blog v0.0.0
+	calculator v0.0.0
+	common v0.0.0
+	github.com/ghodss/yaml v1.0.0
+	github.com/spf13/cobra v1.9.1
+	github.com/spf13/pflag v1.0.6
+	golang.org/x/time v0.0.0-20201208040808-7e3f01d25324
+	google.golang.org/grpc v1.41.0
+	google.golang.org/protobuf v1.27.1
+	greet v0.0.0
+)
+
+replace (
+	blog => ../blog
+	calculator => ../calculator
+	common => ../common
+	greet => ../greet
+)
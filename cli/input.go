@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// fieldFlag is a flag setting a field of the request, possibly nested, such
+// as --greeting.first-name.
+type fieldFlag struct {
+	name string
+	path []protoreflect.FieldDescriptor
+}
+
+// addFieldFlags adds a flag for every scalar field of md and of the messages
+// it contains. Maps, repeated messages and recursive messages can only be set
+// with JSON input.
+func addFieldFlags(fs *pflag.FlagSet, md protoreflect.MessageDescriptor, prefix []protoreflect.FieldDescriptor) []fieldFlag {
+	var flags []fieldFlag
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := append(append([]protoreflect.FieldDescriptor(nil), prefix...), fd)
+		var names []string
+		for _, f := range path {
+			names = append(names, kebab(string(f.Name())))
+		}
+		name := strings.Join(names, ".")
+
+		switch {
+		case fd.IsMap():
+			continue
+		case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+			if fd.IsList() || contains(prefix, fd.Message()) {
+				continue
+			}
+			flags = append(flags, addFieldFlags(fs, fd.Message(), path)...)
+			continue
+		case fd.IsList():
+			fs.StringSlice(name, nil, fmt.Sprintf("%s, comma separated or repeated", typeName(fd)))
+		case fd.Kind() == protoreflect.BoolKind:
+			fs.Bool(name, false, "bool")
+		default:
+			fs.String(name, "", typeName(fd))
+		}
+		flags = append(flags, fieldFlag{name: name, path: path})
+	}
+	return flags
+}
+
+func contains(path []protoreflect.FieldDescriptor, md protoreflect.MessageDescriptor) bool {
+	for _, f := range path {
+		if f.Message() != nil && f.Message().FullName() == md.FullName() {
+			return true
+		}
+	}
+	return false
+}
+
+func typeName(fd protoreflect.FieldDescriptor) string {
+	if fd.Kind() != protoreflect.EnumKind {
+		return fd.Kind().String()
+	}
+	var names []string
+	values := fd.Enum().Values()
+	for i := 0; i < values.Len(); i++ {
+		names = append(names, string(values.Get(i).Name()))
+	}
+	return "one of " + strings.Join(names, ", ")
+}
+
+// applyFlags sets the fields of m given on the command line.
+func applyFlags(fs *pflag.FlagSet, flags []fieldFlag, m proto.Message) error {
+	for _, f := range flags {
+		if !fs.Changed(f.name) {
+			continue
+		}
+		msg := m.ProtoReflect()
+		for _, fd := range f.path[:len(f.path)-1] {
+			msg = msg.Mutable(fd).Message()
+		}
+		fd := f.path[len(f.path)-1]
+		if fd.IsList() {
+			values, err := fs.GetStringSlice(f.name)
+			if err != nil {
+				return err
+			}
+			list := msg.NewField(fd).List()
+			for _, s := range values {
+				v, err := parseScalar(fd, s)
+				if err != nil {
+					return fmt.Errorf("--%s: %w", f.name, err)
+				}
+				list.Append(v)
+			}
+			msg.Set(fd, protoreflect.ValueOfList(list))
+			continue
+		}
+		s := fs.Lookup(f.name).Value.String()
+		v, err := parseScalar(fd, s)
+		if err != nil {
+			return fmt.Errorf("--%s: %w", f.name, err)
+		}
+		msg.Set(fd, v)
+	}
+	return nil
+}
+
+func parseScalar(fd protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(s, 0, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(s, 0, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 0, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 0, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(s)), nil
+	case protoreflect.EnumKind:
+		if v := fd.Enum().Values().ByName(protoreflect.Name(s)); v != nil {
+			return protoreflect.ValueOfEnum(v.Number()), nil
+		}
+		n, err := strconv.ParseInt(s, 0, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("%q is not a value of %s", s, fd.Enum().FullName())
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("cannot set %s fields from a flag", fd.Kind())
+}
+
+// newMessage returns an empty message of the generated type of md.
+func newMessage(md protoreflect.MessageDescriptor) (proto.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName())
+	if err != nil {
+		return nil, err
+	}
+	return mt.New().Interface(), nil
+}
+
+// readRequests parses the JSON input, one request per object; an array holds
+// several requests. Without input, there is a single empty request.
+func readRequests(md protoreflect.MessageDescriptor, data string) ([]proto.Message, error) {
+	var input []byte
+	switch {
+	case data == "":
+		m, err := newMessage(md)
+		if err != nil {
+			return nil, err
+		}
+		return []proto.Message{m}, nil
+	case data == "@-":
+		b, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		input = b
+	case strings.HasPrefix(data, "@"):
+		b, err := ioutil.ReadFile(data[1:])
+		if err != nil {
+			return nil, err
+		}
+		input = b
+	default:
+		input = []byte(data)
+	}
+
+	var raws []json.RawMessage
+	dec := json.NewDecoder(bytes.NewReader(input))
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("reading JSON input: %w", err)
+		}
+		if t := bytes.TrimSpace(raw); len(t) > 0 && t[0] == '[' {
+			var items []json.RawMessage
+			if err := json.Unmarshal(raw, &items); err != nil {
+				return nil, fmt.Errorf("reading JSON input: %w", err)
+			}
+			raws = append(raws, items...)
+			continue
+		}
+		raws = append(raws, raw)
+	}
+
+	var reqs []proto.Message
+	for _, raw := range raws {
+		m, err := newMessage(md)
+		if err != nil {
+			return nil, err
+		}
+		if err := protojson.Unmarshal(raw, m); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", md.FullName(), err)
+		}
+		reqs = append(reqs, m)
+	}
+	return reqs, nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"calculator/calculatorpb"
+	"greet/greetpb"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/proto"
+)
+
+func greetRequest(first, last string) *greetpb.GreetRequest {
+	return &greetpb.GreetRequest{Greeting: &greetpb.Greeting{FirstName: first, LastName: last}}
+}
+
+// withStdin runs f with os.Stdin reading input.
+func withStdin(t *testing.T, input string, f func()) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stdin")
+	if err := ioutil.WriteFile(path, []byte(input), 0600); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	stdin := os.Stdin
+	os.Stdin = file
+	defer func() { os.Stdin = stdin }()
+	f()
+}
+
+func TestReadRequests(t *testing.T) {
+	md := (&greetpb.GreetRequest{}).ProtoReflect().Descriptor()
+	file := filepath.Join(t.TempDir(), "requests.json")
+	if err := ioutil.WriteFile(file, []byte(`{"greeting": {"first_name": "Ada"}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		data    string
+		stdin   string
+		want    []proto.Message
+		wantErr bool
+	}{
+		{"no input", "", "", []proto.Message{&greetpb.GreetRequest{}}, false},
+		{"object", `{"greeting": {"firstName": "Ming", "last_name": "Hsu"}}`, "", []proto.Message{greetRequest("Ming", "Hsu")}, false},
+		{"array", `[{"greeting": {"first_name": "A"}}, {"greeting": {"first_name": "B"}}]`, "",
+			[]proto.Message{greetRequest("A", ""), greetRequest("B", "")}, false},
+		{"concatenated objects", `{"greeting": {"first_name": "A"}} {"greeting": {"first_name": "B"}}`, "",
+			[]proto.Message{greetRequest("A", ""), greetRequest("B", "")}, false},
+		{"file", "@" + file, "", []proto.Message{greetRequest("Ada", "")}, false},
+		{"stdin", "@-", "{\"greeting\": {\"first_name\": \"A\"}}\n{\"greeting\": {\"first_name\": \"B\"}}\n", []proto.Message{greetRequest("A", ""), greetRequest("B", "")}, false},
+		{"missing file", "@" + file + ".nope", "", nil, true},
+		{"unknown field", `{"nope": 1}`, "", nil, true},
+		{"malformed", `{"greeting": `, "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []proto.Message
+			var err error
+			withStdin(t, tt.stdin, func() { got, err = readRequests(md, tt.data) })
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readRequests(%q): got error %v, want error %v", tt.data, err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("readRequests(%q) = %v, want %v", tt.data, got, tt.want)
+			}
+			for i := range got {
+				if !proto.Equal(got[i], tt.want[i]) {
+					t.Errorf("readRequests(%q)[%d] = %v, want %v", tt.data, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFieldFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     proto.Message
+		args    []string
+		want    proto.Message
+		wantErr bool
+	}{
+		{"nested", &greetpb.GreetRequest{}, []string{"--greeting.first-name", "Ming", "--greeting.last-name=Hsu"}, greetRequest("Ming", "Hsu"), false},
+		{"none", &greetpb.GreetRequest{}, nil, &greetpb.GreetRequest{}, false},
+		{"int32", &calculatorpb.SumRequest{}, []string{"--first-number", "3", "--second-number", "0x10"},
+			&calculatorpb.SumRequest{FirstNumber: 3, SecondNumber: 16}, false},
+		{"int32 overflow", &calculatorpb.SumRequest{}, []string{"--first-number", "3000000000"}, nil, true},
+		{"bool", &calculatorpb.SumRequest{}, []string{"--no-cache"}, &calculatorpb.SumRequest{NoCache: true}, false},
+		{"enum by name, repeated and uint32", &calculatorpb.CalculateRequest{},
+			[]string{"--operation", "ADD", "--operands", "1,2", "--operands", "3", "--mode", "RATIONAL", "--precision", "50"},
+			&calculatorpb.CalculateRequest{Operation: calculatorpb.CalculateRequest_ADD, Operands: []string{"1", "2", "3"}, Mode: calculatorpb.CalculateRequest_RATIONAL, Precision: 50}, false},
+		{"enum by number", &calculatorpb.CalculateRequest{}, []string{"--operation", "3"},
+			&calculatorpb.CalculateRequest{Operation: calculatorpb.CalculateRequest_MULTIPLY}, false},
+		{"unknown enum value", &calculatorpb.CalculateRequest{}, []string{"--operation", "CUBE"}, nil, true},
+		{"double in a nested message", &calculatorpb.ConvertUnitsRequest{}, []string{"--quantity.value", "2.5", "--quantity.unit", "km", "--unit", "m"},
+			&calculatorpb.ConvertUnitsRequest{Quantity: &calculatorpb.Quantity{Value: 2.5, Unit: "km"}, Unit: "m"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			flags := addFieldFlags(fs, tt.msg.ProtoReflect().Descriptor(), nil)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatalf("parsing %v: %v", tt.args, err)
+			}
+			got := proto.Clone(tt.msg)
+			err := applyFlags(fs, flags, got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyFlags(%v): got error %v, want error %v", tt.args, err, tt.wantErr)
+			}
+			if err == nil && !proto.Equal(got, tt.want) {
+				t.Errorf("applyFlags(%v) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFieldFlagsSkipMaps checks that fields only JSON can set get no flag.
+func TestFieldFlagsSkipMaps(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	var names []string
+	for _, f := range addFieldFlags(fs, (&calculatorpb.EvaluateRequest{}).ProtoReflect().Descriptor(), nil) {
+		names = append(names, f.name)
+	}
+	if want := []string{"expression", "no-cache"}; len(names) != len(want) || names[0] != want[0] || names[1] != want[1] {
+		t.Errorf("flags of EvaluateRequest = %v, want %v", names, want)
+	}
+}
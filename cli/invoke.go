@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// call sends reqs to the method md and passes every response to print.
+func call(ctx context.Context, cc *grpc.ClientConn, md protoreflect.MethodDescriptor, reqs []proto.Message, print func(proto.Message) error) error {
+	method := fmt.Sprintf("/%s/%s", md.Parent().FullName(), md.Name())
+	if !md.IsStreamingClient() && !md.IsStreamingServer() {
+		res, err := newMessage(md.Output())
+		if err != nil {
+			return err
+		}
+		if err := cc.Invoke(ctx, method, reqs[0], res); err != nil {
+			return err
+		}
+		return print(res)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	desc := &grpc.StreamDesc{
+		StreamName:    string(md.Name()),
+		ServerStreams: md.IsStreamingServer(),
+		ClientStreams: md.IsStreamingClient(),
+	}
+	stream, err := cc.NewStream(ctx, desc, method)
+	if err != nil {
+		return err
+	}
+	// send while receiving, so that bidirectional streams do not block
+	go func() {
+		for _, req := range reqs {
+			if err := stream.SendMsg(req); err != nil {
+				// the error is the one RecvMsg returns
+				return
+			}
+		}
+		stream.CloseSend()
+	}()
+	for {
+		res, err := newMessage(md.Output())
+		if err != nil {
+			return err
+		}
+		if err := stream.RecvMsg(res); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := print(res); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// loadOptions are the flags of the loadtest commands.
+type loadOptions struct {
+	qps         float64
+	concurrency int
+	duration    time.Duration
+	requests    int64
+	messages    int
+	payload     string
+	export      string
+}
+
+func loadtestCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "loadtest",
+		Short: "Drive an RPC with many concurrent calls and report latencies and errors",
+		Long: `Drive an RPC with many concurrent calls and report latencies and errors.
+
+The calls are spread over --concurrency workers sharing one connection. With
+--qps, calls start at that rate overall; otherwise each worker starts a new
+call as soon as the previous one ends. The test stops after --duration or
+--requests calls, whichever comes first.
+
+Requests are made from the --data JSON template; the help of each method
+lists the template functions.`,
+	}
+	for _, svc := range services {
+		svcCmd := &cobra.Command{
+			Use:   string(svc.desc.ParentFile().Package()),
+			Short: fmt.Sprintf("Load test %s", svc.desc.FullName()),
+		}
+		methods := svc.desc.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			svcCmd.AddCommand(loadtestMethodCommand(svc, methods.Get(i), opts))
+		}
+		cmd.AddCommand(svcCmd)
+	}
+	return cmd
+}
+
+func loadtestMethodCommand(svc service, md protoreflect.MethodDescriptor, opts *options) *cobra.Command {
+	var lo loadOptions
+	cmd := &cobra.Command{
+		Use:   kebab(string(md.Name())),
+		Short: fmt.Sprintf("Load test %s (%s)", md.FullName(), kind(md)),
+		Long: fmt.Sprintf(`Load test %s (%s).
+
+--data is a template of the JSON request, executed for every message. It can
+call seq (a counter starting at 1), randInt min max, randFloat min max,
+randString n, pick values... and now:
+
+  --data '{"number": {{randInt 1 1000}}}'`, md.FullName(), kind(md)),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			gen, err := newGenerator(md.Input(), lo.payload)
+			if err != nil {
+				return err
+			}
+			if lo.concurrency < 1 {
+				return fmt.Errorf("--concurrency must be at least 1")
+			}
+			ctx, err := withHeaders(context.Background(), opts.headers)
+			if err != nil {
+				return err
+			}
+			dialCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			defer cancel()
+			cc, err := dial(dialCtx, svc, cmd, opts)
+			if err != nil {
+				return err
+			}
+			defer cc.Close()
+
+			r := runLoad(ctx, md, lo, opts.timeout, gen, func(ctx context.Context, reqs []proto.Message, received func()) error {
+				return call(ctx, cc, md, reqs, func(proto.Message) error {
+					received()
+					return nil
+				})
+			})
+			r.print(os.Stdout)
+			return r.export(lo.export)
+		},
+	}
+	f := cmd.Flags()
+	f.Float64Var(&lo.qps, "qps", 0, "calls started per second over all workers; 0 means no limit")
+	f.IntVarP(&lo.concurrency, "concurrency", "c", 10, "number of calls in flight at once")
+	f.DurationVar(&lo.duration, "duration", 10*time.Second, "length of the test")
+	f.Int64VarP(&lo.requests, "requests", "n", 0, "stop after this many calls; 0 means no limit")
+	if md.IsStreamingClient() {
+		f.IntVar(&lo.messages, "messages", 10, "messages sent on every stream")
+	}
+	f.StringVarP(&lo.payload, "data", "d", "", "JSON template of the requests")
+	f.StringVar(&lo.export, "json", "", `write the results as JSON to this file, "-" for stdout`)
+	return cmd
+}
+
+// invoker makes one call with reqs, calling received for every response.
+type invoker func(ctx context.Context, reqs []proto.Message, received func()) error
+
+// runLoad calls invoke until the test is over and gathers the results. The
+// calls are made with contexts derived from base.
+func runLoad(base context.Context, md protoreflect.MethodDescriptor, lo loadOptions, timeout time.Duration, gen *generator, invoke invoker) *result {
+	r := &result{
+		Method:      string(md.FullName()),
+		Kind:        kind(md),
+		Concurrency: lo.concurrency,
+		TargetQPS:   lo.qps,
+		Codes:       make(map[string]int64),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), lo.duration)
+	defer cancel()
+	var limiter *rate.Limiter
+	if lo.qps > 0 {
+		limiter = rate.NewLimiter(rate.Limit(lo.qps), 1)
+	}
+	messages := 1
+	if md.IsStreamingClient() {
+		messages = lo.messages
+	}
+
+	var started int64
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < lo.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if limiter != nil {
+					if err := limiter.Wait(ctx); err != nil {
+						return
+					}
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				if lo.requests > 0 && atomic.AddInt64(&started, 1) > lo.requests {
+					return
+				}
+
+				reqs := make([]proto.Message, 0, messages)
+				for j := 0; j < messages; j++ {
+					req, err := gen.next()
+					if err != nil {
+						r.add(0, err, 0, 0)
+						return
+					}
+					reqs = append(reqs, req)
+				}
+				// calls in flight when the test ends are let finish
+				callCtx := base
+				var cancelCall context.CancelFunc = func() {}
+				if timeout > 0 {
+					callCtx, cancelCall = context.WithTimeout(callCtx, timeout)
+				}
+				var received int64
+				t := time.Now()
+				err := invoke(callCtx, reqs, func() { received++ })
+				r.add(time.Since(t), err, int64(len(reqs)), received)
+				cancelCall()
+			}
+		}()
+	}
+	wg.Wait()
+	r.finish(time.Since(start))
+	return r
+}
+
+// result holds the outcome of a load test, in the form it is exported.
+type result struct {
+	Method      string  `json:"method"`
+	Kind        string  `json:"kind"`
+	Concurrency int     `json:"concurrency"`
+	TargetQPS   float64 `json:"target_qps,omitempty"`
+
+	Seconds          float64          `json:"duration_seconds"`
+	Calls            int64            `json:"calls"`
+	Errors           int64            `json:"errors"`
+	QPS              float64          `json:"qps"`
+	Latency          latencyStats     `json:"latency_ms"`
+	Codes            map[string]int64 `json:"status_codes"`
+	MessagesSent     int64            `json:"messages_sent"`
+	MessagesReceived int64            `json:"messages_received"`
+	SentPerSec       float64          `json:"messages_sent_per_second"`
+	ReceivedPerSec   float64          `json:"messages_received_per_second"`
+
+	mu        sync.Mutex
+	latencies []time.Duration
+}
+
+type latencyStats struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+func (r *result) add(latency time.Duration, err error, sent, received int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Calls++
+	code := status.Code(err)
+	if err != nil {
+		r.Errors++
+	}
+	r.Codes[code.String()]++
+	r.MessagesSent += sent
+	r.MessagesReceived += received
+	r.latencies = append(r.latencies, latency)
+}
+
+func (r *result) finish(elapsed time.Duration) {
+	r.Seconds = elapsed.Seconds()
+	if r.Seconds > 0 {
+		r.QPS = float64(r.Calls) / r.Seconds
+		r.SentPerSec = float64(r.MessagesSent) / r.Seconds
+		r.ReceivedPerSec = float64(r.MessagesReceived) / r.Seconds
+	}
+	if len(r.latencies) == 0 {
+		return
+	}
+	sort.Slice(r.latencies, func(i, j int) bool { return r.latencies[i] < r.latencies[j] })
+	var total time.Duration
+	for _, l := range r.latencies {
+		total += l
+	}
+	r.Latency = latencyStats{
+		Min:  ms(r.latencies[0]),
+		Mean: ms(total / time.Duration(len(r.latencies))),
+		P50:  ms(percentile(r.latencies, 50)),
+		P90:  ms(percentile(r.latencies, 90)),
+		P99:  ms(percentile(r.latencies, 99)),
+		Max:  ms(r.latencies[len(r.latencies)-1]),
+	}
+}
+
+// percentile returns the nearest-rank percentile p of the sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+func ms(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Millisecond)*1000) / 1000
+}
+
+func (r *result) print(w io.Writer) {
+	fmt.Fprintf(w, "%s (%s), %d workers\n", r.Method, r.Kind, r.Concurrency)
+	fmt.Fprintf(w, "  calls:     %d in %.2fs, %.1f/s, %d errors\n", r.Calls, r.Seconds, r.QPS, r.Errors)
+	l := r.Latency
+	fmt.Fprintf(w, "  latency:   min %.2fms  mean %.2fms  p50 %.2fms  p90 %.2fms  p99 %.2fms  max %.2fms\n",
+		l.Min, l.Mean, l.P50, l.P90, l.P99, l.Max)
+	if r.Kind != "unary" {
+		fmt.Fprintf(w, "  messages:  %d sent (%.1f/s), %d received (%.1f/s)\n",
+			r.MessagesSent, r.SentPerSec, r.MessagesReceived, r.ReceivedPerSec)
+	}
+	codes := make([]string, 0, len(r.Codes))
+	for c := range r.Codes {
+		codes = append(codes, c)
+	}
+	sort.Strings(codes)
+	for _, c := range codes {
+		fmt.Fprintf(w, "  %-18s %d\n", c+":", r.Codes[c])
+	}
+}
+
+func (r *result) export(path string) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
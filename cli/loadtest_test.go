@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"greet/greetpb"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPercentile(t *testing.T) {
+	// 1ms to 100ms
+	var hundred []time.Duration
+	for i := 1; i <= 100; i++ {
+		hundred = append(hundred, time.Duration(i)*time.Millisecond)
+	}
+	ten := hundred[:10]
+	tests := []struct {
+		sorted []time.Duration
+		p      float64
+		want   time.Duration
+	}{
+		{hundred, 50, 50 * time.Millisecond},
+		{hundred, 90, 90 * time.Millisecond},
+		{hundred, 99, 99 * time.Millisecond},
+		{hundred, 100, 100 * time.Millisecond},
+		{hundred, 0, time.Millisecond},
+		// the nearest rank rounds up: the 10th of 10 values for p99
+		{ten, 50, 5 * time.Millisecond},
+		{ten, 91, 10 * time.Millisecond},
+		{ten, 99, 10 * time.Millisecond},
+		{hundred[:1], 50, time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := percentile(tt.sorted, tt.p); got != tt.want {
+			t.Errorf("percentile(%d values, %v) = %v, want %v", len(tt.sorted), tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestResult(t *testing.T) {
+	r := &result{Method: "greet.GreetService/Greet", Kind: "unary", Codes: make(map[string]int64)}
+	for _, l := range []time.Duration{4, 1, 3, 2} {
+		r.add(l*time.Millisecond, nil, 1, 1)
+	}
+	r.add(1500*time.Microsecond, status.Error(codes.Unavailable, "down"), 1, 0)
+	r.finish(2 * time.Second)
+
+	if r.Calls != 5 || r.Errors != 1 {
+		t.Errorf("got %d calls and %d errors, want 5 and 1", r.Calls, r.Errors)
+	}
+	if r.Codes["OK"] != 4 || r.Codes["Unavailable"] != 1 {
+		t.Errorf("got codes %v", r.Codes)
+	}
+	if r.QPS != 2.5 || r.SentPerSec != 2.5 || r.ReceivedPerSec != 2 {
+		t.Errorf("got %v calls, %v sent and %v received per second, want 2.5, 2.5 and 2", r.QPS, r.SentPerSec, r.ReceivedPerSec)
+	}
+	want := latencyStats{Min: 1, Mean: 2.3, P50: 2, P90: 4, P99: 4, Max: 4}
+	if r.Latency != want {
+		t.Errorf("got latencies %+v, want %+v", r.Latency, want)
+	}
+
+	var out bytes.Buffer
+	r.print(&out)
+	for _, line := range []string{
+		"calls:     5 in 2.00s, 2.5/s, 1 errors",
+		"min 1.00ms  mean 2.30ms  p50 2.00ms  p90 4.00ms  p99 4.00ms  max 4.00ms",
+		"Unavailable:       1",
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("report %q does not contain %q", out.String(), line)
+		}
+	}
+}
+
+func TestResultWithoutCalls(t *testing.T) {
+	r := &result{Codes: make(map[string]int64)}
+	r.finish(0)
+	if r.QPS != 0 || r.Latency != (latencyStats{}) {
+		t.Errorf("got %+v, want zero rates and latencies", r)
+	}
+}
+
+func TestRunLoad(t *testing.T) {
+	md := greetpb.File_proto_greet_proto.Services().Get(0).Methods().ByName("LongGreet")
+	gen, err := newGenerator(md.Input(), `{"greeting": {"first_name": "w{{seq}}"}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lo := loadOptions{concurrency: 4, duration: 10 * time.Second, requests: 20, messages: 3}
+	r := runLoad(context.Background(), md, lo, 0, gen, func(ctx context.Context, reqs []proto.Message, received func()) error {
+		received()
+		if reqs[0].(*greetpb.LongGreetRequest).GetGreeting().GetFirstName() == "w1" {
+			return status.Error(codes.Internal, "first")
+		}
+		return nil
+	})
+	if r.Calls != 20 || r.Errors != 1 || r.Codes["Internal"] != 1 {
+		t.Errorf("got %d calls, %d errors, codes %v; want 20 calls and one Internal error", r.Calls, r.Errors, r.Codes)
+	}
+	if r.MessagesSent != 60 || r.MessagesReceived != 20 {
+		t.Errorf("got %d messages sent and %d received, want 60 and 20", r.MessagesSent, r.MessagesReceived)
+	}
+	if r.Kind != "client streaming" || r.Concurrency != 4 {
+		t.Errorf("got kind %q and %d workers", r.Kind, r.Concurrency)
+	}
+}
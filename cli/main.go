@@ -0,0 +1,212 @@
+// Command grpcctl calls any RPC of the greet, calculator and blog services.
+//
+// Every RPC is a subcommand of its service, named after the method in kebab
+// case, and every field of the request is a flag:
+//
+//	grpcctl greet greet --greeting.first-name Ming
+//	grpcctl calculator sum --first-number 3 --second-number 10 -o json
+//	grpcctl blog create-blog -d '{"blog": {"title": "Hello", "tags": ["go"]}}'
+//
+// Client streaming RPCs send every message of the JSON input, given as an
+// array or as a sequence of objects, for example one per line on stdin:
+//
+//	printf '{"number": 1}\n{"number": 5}\n' | grpcctl calculator compute-average -d @-
+package main
+
+import (
+	"blog/blogpb"
+	"calculator/calculatorpb"
+	"common/auth"
+	"common/tlsutil"
+	"context"
+	"flag"
+	"fmt"
+	"greet/greetpb"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// service describes one of the services the CLI can call.
+type service struct {
+	desc protoreflect.ServiceDescriptor
+	// target and tls are the defaults matching the service's server.
+	target string
+	tls    bool
+}
+
+var services = []service{
+	{desc: greetpb.File_proto_greet_proto.Services().ByName("GreetService"), target: "localhost:10051", tls: true},
+	{desc: calculatorpb.File_proto_calculator_proto.Services().ByName("CalculatorService"), target: "localhost:50051"},
+	{desc: blogpb.File_proto_blog_proto.Services().ByName("BlogService"), target: "localhost:50051"},
+}
+
+// options are the flags shared by all commands.
+type options struct {
+	target  string
+	tls     bool
+	tlsCfg  tlsutil.ClientConfig
+	authCfg auth.ClientConfig
+	timeout time.Duration
+	output  string
+	headers []string
+}
+
+func main() {
+	var opts options
+	root := &cobra.Command{
+		Use:           "grpcctl",
+		Short:         "Call the RPCs of the greet, calculator and blog services",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	pf := root.PersistentFlags()
+	pf.StringVar(&opts.target, "target", "", "server address (default: the service's usual address)")
+	pf.BoolVar(&opts.tls, "tls", false, "connect over TLS (default: on for greet)")
+	pf.DurationVar(&opts.timeout, "timeout", 10*time.Second, "deadline of the call; 0 means none")
+	pf.StringVarP(&opts.output, "output", "o", "text", "output format: text, json or yaml")
+	pf.StringArrayVarP(&opts.headers, "header", "H", nil, `request metadata as "name: value", repeatable`)
+	// the TLS and token options are the ones of the other clients
+	gofs := flag.NewFlagSet("grpcctl", flag.ContinueOnError)
+	opts.tlsCfg.RegisterFlags(gofs, "")
+	opts.authCfg.RegisterFlags(gofs)
+	pf.AddGoFlagSet(gofs)
+
+	for _, svc := range services {
+		root.AddCommand(serviceCommand(svc, &opts))
+	}
+	root.AddCommand(loadtestCommand(&opts))
+	if err := root.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func serviceCommand(svc service, opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   string(svc.desc.ParentFile().Package()),
+		Short: fmt.Sprintf("Call %s (default target %s)", svc.desc.FullName(), svc.target),
+	}
+	methods := svc.desc.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		cmd.AddCommand(methodCommand(svc, methods.Get(i), opts))
+	}
+	return cmd
+}
+
+func methodCommand(svc service, md protoreflect.MethodDescriptor, opts *options) *cobra.Command {
+	var data string
+	var fields []fieldFlag
+	cmd := &cobra.Command{
+		Use:   kebab(string(md.Name())),
+		Short: fmt.Sprintf("Call %s (%s)", md.FullName(), kind(md)),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			reqs, err := readRequests(md.Input(), data)
+			if err != nil {
+				return err
+			}
+			for _, req := range reqs {
+				if err := applyFlags(cmd.Flags(), fields, req); err != nil {
+					return err
+				}
+			}
+			if !md.IsStreamingClient() && len(reqs) != 1 {
+				return fmt.Errorf("%s takes a single request, got %d", md.Name(), len(reqs))
+			}
+			p, err := newPrinter(opts.output, os.Stdout)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			if opts.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+				defer cancel()
+			}
+			ctx, err = withHeaders(ctx, opts.headers)
+			if err != nil {
+				return err
+			}
+			cc, err := dial(ctx, svc, cmd, opts)
+			if err != nil {
+				return err
+			}
+			defer cc.Close()
+			return call(ctx, cc, md, reqs, p.print)
+		},
+	}
+	cmd.Flags().StringVarP(&data, "data", "d", "", `request as JSON; "@file" reads a file and "@-" stdin`)
+	fields = addFieldFlags(cmd.Flags(), md.Input(), nil)
+	return cmd
+}
+
+func dial(ctx context.Context, svc service, cmd *cobra.Command, opts *options) (*grpc.ClientConn, error) {
+	target := opts.target
+	if target == "" {
+		target = svc.target
+	}
+	useTLS := svc.tls
+	if cmd.Flags().Changed("tls") {
+		useTLS = opts.tls
+	}
+	dialOpts := []grpc.DialOption{grpc.WithInsecure()}
+	if useTLS {
+		creds, err := tlsutil.ClientCredentials(opts.tlsCfg)
+		if err != nil {
+			return nil, err
+		}
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	}
+	if creds := opts.authCfg.Credentials(!useTLS); creds != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(creds))
+	}
+	return grpc.DialContext(ctx, target, dialOpts...)
+}
+
+func withHeaders(ctx context.Context, headers []string) (context.Context, error) {
+	for _, h := range headers {
+		i := strings.IndexByte(h, ':')
+		if i <= 0 {
+			return nil, fmt.Errorf(`header %q is not "name: value"`, h)
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, strings.TrimSpace(h[:i]), strings.TrimSpace(h[i+1:]))
+	}
+	return ctx, nil
+}
+
+func kind(md protoreflect.MethodDescriptor) string {
+	switch {
+	case md.IsStreamingClient() && md.IsStreamingServer():
+		return "bidirectional streaming"
+	case md.IsStreamingClient():
+		return "client streaming"
+	case md.IsStreamingServer():
+		return "server streaming"
+	}
+	return "unary"
+}
+
+// kebab turns a name like "GreetManyTimes" into "greet-many-times".
+func kebab(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('-')
+			}
+			r += 'a' - 'A'
+		}
+		if r == '_' {
+			r = '-'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
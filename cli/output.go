@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// printer writes the responses in the chosen format. Responses of a stream
+// are separate documents: blank line separated in text, one JSON value after
+// the other, or "---" separated in YAML.
+type printer struct {
+	format string
+	w      io.Writer
+	count  int
+}
+
+func newPrinter(format string, w io.Writer) (*printer, error) {
+	switch format {
+	case "text", "json", "yaml":
+		return &printer{format: format, w: w}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q, want text, json or yaml", format)
+}
+
+func (p *printer) print(m proto.Message) error {
+	var out []byte
+	var err error
+	switch p.format {
+	case "text":
+		out, err = prototext.MarshalOptions{Multiline: true}.Marshal(m)
+		if p.count > 0 {
+			out = append([]byte("\n"), out...)
+		}
+	case "json":
+		out, err = protojson.MarshalOptions{Multiline: true, UseProtoNames: true}.Marshal(m)
+		out = append(out, '\n')
+	case "yaml":
+		out, err = protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+		if err == nil {
+			out, err = yaml.JSONToYAML(out)
+		}
+		if p.count > 0 {
+			out = append([]byte("---\n"), out...)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	p.count++
+	_, err = p.w.Write(out)
+	return err
+}
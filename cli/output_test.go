@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"greet/greetpb"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPrinter(t *testing.T) {
+	responses := []proto.Message{
+		&greetpb.GreetResponse{Result: "Hello Ming"},
+		&greetpb.GreetResponse{Result: "Hello Lucy"},
+	}
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{"text", []string{`result: "Hello Ming"`, "", `result: "Hello Lucy"`}},
+		{"json", []string{"{", `"result": "Hello Ming"`, "}", "{", `"result": "Hello Lucy"`, "}"}},
+		{"yaml", []string{"result: Hello Ming", "---", "result: Hello Lucy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var out bytes.Buffer
+			p, err := newPrinter(tt.format, &out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, m := range responses {
+				if err := p.print(m); err != nil {
+					t.Fatalf("print: %v", err)
+				}
+			}
+			// protojson and prototext vary their spacing on purpose
+			var got []string
+			for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+				got = append(got, strings.Join(strings.Fields(line), " "))
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("got\n%s\nwant\n%s", out.String(), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestPrinterUnknownFormat(t *testing.T) {
+	if _, err := newPrinter("xml", &bytes.Buffer{}); err == nil {
+		t.Error("newPrinter(xml) succeeded")
+	}
+}
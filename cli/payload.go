@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// generator makes the requests of a load test from a JSON template, so that
+// every request can differ:
+//
+//	{"number": {{randInt 1 1000}}}
+//	{"greeting": {"first_name": "{{pick "Ming" "Lucy"}}-{{seq}}"}}
+//
+// The functions are seq (a counter starting at 1), randInt min max,
+// randFloat min max, randString n, pick values... and now (RFC 3339).
+type generator struct {
+	md   protoreflect.MessageDescriptor
+	tmpl *template.Template
+	seq  int64
+
+	mu  sync.Mutex // guards rnd, which is not safe for concurrent use
+	rnd *rand.Rand
+}
+
+func newGenerator(md protoreflect.MessageDescriptor, text string) (*generator, error) {
+	g := &generator{md: md, rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	if text == "" {
+		text = "{}"
+	}
+	tmpl, err := template.New("payload").Funcs(template.FuncMap{
+		"seq":        func() int64 { return atomic.AddInt64(&g.seq, 1) },
+		"randInt":    g.randInt,
+		"randFloat":  g.randFloat,
+		"randString": g.randString,
+		"pick":       g.pick,
+		"now":        func() string { return time.Now().Format(time.RFC3339) },
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the payload template: %w", err)
+	}
+	g.tmpl = tmpl
+	// catch mistakes before the test starts
+	if _, err := g.next(); err != nil {
+		return nil, err
+	}
+	atomic.StoreInt64(&g.seq, 0)
+	return g, nil
+}
+
+// next returns a new request.
+func (g *generator) next() (proto.Message, error) {
+	var buf bytes.Buffer
+	if err := g.tmpl.Execute(&buf, nil); err != nil {
+		return nil, fmt.Errorf("executing the payload template: %w", err)
+	}
+	m, err := newMessage(g.md)
+	if err != nil {
+		return nil, err
+	}
+	if err := protojson.Unmarshal(buf.Bytes(), m); err != nil {
+		return nil, fmt.Errorf("payload %s is not a %s: %w", strings.TrimSpace(buf.String()), g.md.FullName(), err)
+	}
+	return m, nil
+}
+
+func (g *generator) randInt(min, max int) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if max <= min {
+		return min
+	}
+	return min + g.rnd.Intn(max-min+1)
+}
+
+func (g *generator) randFloat(min, max float64) float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return min + g.rnd.Float64()*(max-min)
+}
+
+func (g *generator) randString(n int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = letters[g.rnd.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+func (g *generator) pick(values ...string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return values[g.rnd.Intn(len(values))]
+}
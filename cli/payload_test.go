@@ -0,0 +1,93 @@
+package main
+
+import (
+	"calculator/calculatorpb"
+	"greet/greetpb"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestGenerator(t *testing.T) {
+	sum := (&calculatorpb.SumRequest{}).ProtoReflect().Descriptor()
+	greet := (&greetpb.GreetRequest{}).ProtoReflect().Descriptor()
+	tests := []struct {
+		name  string
+		md    protoreflect.MessageDescriptor
+		text  string
+		check func(m proto.Message) bool
+	}{
+		{"empty", sum, "", func(m proto.Message) bool {
+			return proto.Equal(m, &calculatorpb.SumRequest{})
+		}},
+		{"randInt", sum, `{"first_number": {{randInt 5 7}}, "second_number": {{randInt 3 3}}}`, func(m proto.Message) bool {
+			r := m.(*calculatorpb.SumRequest)
+			return r.FirstNumber >= 5 && r.FirstNumber <= 7 && r.SecondNumber == 3
+		}},
+		{"randFloat", sum, `{"first_number": {{randFloat 1 2 | printf "%.0f"}}}`, func(m proto.Message) bool {
+			n := m.(*calculatorpb.SumRequest).FirstNumber
+			return n == 1 || n == 2
+		}},
+		{"randString and pick", greet, `{"greeting": {"first_name": "{{randString 8}}", "last_name": "{{pick "Hsu" "Lee"}}"}}`, func(m proto.Message) bool {
+			g := m.(*greetpb.GreetRequest).GetGreeting()
+			return len(g.FirstName) == 8 && strings.Trim(g.FirstName, "abcdefghijklmnopqrstuvwxyz") == "" &&
+				(g.LastName == "Hsu" || g.LastName == "Lee")
+		}},
+		{"now", greet, `{"greeting": {"first_name": "{{now}}"}}`, func(m proto.Message) bool {
+			_, err := time.Parse(time.RFC3339, m.(*greetpb.GreetRequest).GetGreeting().GetFirstName())
+			return err == nil
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := newGenerator(tt.md, tt.text)
+			if err != nil {
+				t.Fatalf("newGenerator: %v", err)
+			}
+			for i := 0; i < 20; i++ {
+				m, err := g.next()
+				if err != nil {
+					t.Fatalf("next: %v", err)
+				}
+				if !tt.check(m) {
+					t.Fatalf("unexpected request %v", m)
+				}
+			}
+		})
+	}
+}
+
+// TestGeneratorSeq checks that seq counts from 1 despite the trial request
+// newGenerator makes.
+func TestGeneratorSeq(t *testing.T) {
+	g, err := newGenerator((&calculatorpb.SumRequest{}).ProtoReflect().Descriptor(), `{"first_number": {{seq}}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for want := int32(1); want <= 3; want++ {
+		m, err := g.next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := m.(*calculatorpb.SumRequest).FirstNumber; got != want {
+			t.Errorf("seq = %d, want %d", got, want)
+		}
+	}
+}
+
+func TestGeneratorErrors(t *testing.T) {
+	md := (&calculatorpb.SumRequest{}).ProtoReflect().Descriptor()
+	for _, text := range []string{
+		`{"first_number": {{randInt 1}}`,
+		`{"first_number": {{nope}}}`,
+		`{"first_number": "x"}`,
+		`{"unknown": 1}`,
+	} {
+		if _, err := newGenerator(md, text); err == nil {
+			t.Errorf("newGenerator(%s) succeeded", text)
+		}
+	}
+}
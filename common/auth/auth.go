@@ -0,0 +1,185 @@
+// Package auth authenticates callers with JWT bearer tokens.
+//
+// Servers install the interceptors built from a Validator; the validated
+// claims are then available to handlers through FromContext. An optional
+// Policy restricts methods to callers holding given roles. Clients attach
+// their token to every call with TokenCredentials.
+package auth
+
+import (
+	"common/flagutil"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Claims are the JWT claims understood by the services.
+type Claims struct {
+	jwt.RegisteredClaims
+
+	// Roles lists the roles granted to the subject, e.g. "admin".
+	Roles []string `json:"roles,omitempty"`
+}
+
+// HasRole reports whether the claims grant role.
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type claimsKey struct{}
+
+// NewContext returns a copy of ctx carrying claims.
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// FromContext returns the claims of the authenticated caller, if any.
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
+
+// Config tells the server which keys and claims to accept.
+// At least one of HMACSecretFile, PublicKeyFile and JWKSFile must be set
+// for authentication to be enabled.
+type Config struct {
+	// HMACSecretFile holds the shared secret for HS256/384/512 tokens.
+	HMACSecretFile string
+	// PublicKeyFile is a PEM encoded RSA or ECDSA public key or certificate
+	// for RS*, PS* and ES* tokens.
+	PublicKeyFile string
+	// JWKSFile is a local JSON Web Key Set; tokens select a key by "kid".
+	JWKSFile string
+	// Issuer and Audience, when not empty, must match the "iss" and "aud" claims.
+	Issuer   string
+	Audience string
+	// Exempt lists full method name prefixes that may be called without a token.
+	Exempt flagutil.StringList
+	// PolicyFile is an optional JSON Policy restricting methods to roles.
+	PolicyFile string
+}
+
+// RegisterFlags binds the server authentication options to fs.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.HMACSecretFile, "auth-hmac-secret-file", "", "file holding the HMAC secret for HS* tokens")
+	fs.StringVar(&c.PublicKeyFile, "auth-public-key", "", "PEM RSA/ECDSA public key or certificate for RS*, PS* and ES* tokens")
+	fs.StringVar(&c.JWKSFile, "auth-jwks", "", "local JWKS file")
+	fs.StringVar(&c.Issuer, "auth-issuer", "", "required token issuer")
+	fs.StringVar(&c.Audience, "auth-audience", "", "required token audience")
+	c.Exempt = flagutil.StringList{"/grpc.reflection."}
+	fs.Var(&c.Exempt, "auth-exempt", "comma separated method prefixes that skip authentication")
+	fs.StringVar(&c.PolicyFile, "authz-policy", "", "JSON file mapping methods to the roles allowed to call them")
+}
+
+// Enabled reports whether any verification key is configured.
+func (c *Config) Enabled() bool {
+	return c.HMACSecretFile != "" || c.PublicKeyFile != "" || c.JWKSFile != ""
+}
+
+// Validator verifies bearer tokens against the configured keys.
+type Validator struct {
+	keys     *keySet
+	parser   *jwt.Parser
+	issuer   string
+	audience string
+	exempt   []string
+	policy   Policy
+}
+
+// NewValidator loads the keys named in cfg.
+func NewValidator(cfg Config) (*Validator, error) {
+	if !cfg.Enabled() {
+		return nil, errors.New("no token verification key configured")
+	}
+	keys, err := loadKeySet(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var policy Policy
+	if cfg.PolicyFile != "" {
+		if policy, err = LoadPolicy(cfg.PolicyFile); err != nil {
+			return nil, err
+		}
+	}
+	return &Validator{
+		keys:     keys,
+		parser:   jwt.NewParser(jwt.WithValidMethods(keys.methods())),
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+		exempt:   cfg.Exempt,
+		policy:   policy,
+	}, nil
+}
+
+// Validate checks the signature and claims of token.
+func (v *Validator) Validate(token string) (*Claims, error) {
+	claims := &Claims{}
+	// exp, nbf and iat are checked by the parser
+	if _, err := v.parser.ParseWithClaims(token, claims, v.keys.keyFunc); err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+	if v.issuer != "" && !claims.VerifyIssuer(v.issuer, true) {
+		return nil, fmt.Errorf("unexpected issuer %q", claims.Issuer)
+	}
+	if v.audience != "" && !claims.VerifyAudience(v.audience, true) {
+		return nil, fmt.Errorf("token is not meant for audience %q", v.audience)
+	}
+	return claims, nil
+}
+
+// Authenticate is a grpc_auth.AuthFunc that validates the bearer token of the
+// incoming call, checks the policy and stores the claims in the returned
+// context.
+func (v *Validator) Authenticate(ctx context.Context) (context.Context, error) {
+	method, _ := grpc.Method(ctx)
+	if v.isExempt(method) {
+		return ctx, nil
+	}
+	token, err := grpc_auth.AuthFromMD(ctx, "bearer")
+	if err != nil {
+		return nil, err
+	}
+	claims, err := v.Validate(token)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid auth token: %v", err)
+	}
+	if !v.policy.Allowed(method, claims) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed to call %s", claims.Subject, method)
+	}
+	return NewContext(ctx, claims), nil
+}
+
+func (v *Validator) isExempt(method string) bool {
+	for _, prefix := range v.exempt {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnaryServerInterceptor rejects unary calls without a valid token.
+func (v *Validator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return grpc_auth.UnaryServerInterceptor(v.Authenticate)
+}
+
+// StreamServerInterceptor rejects streams without a valid token.
+func (v *Validator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return grpc_auth.StreamServerInterceptor(v.Authenticate)
+}
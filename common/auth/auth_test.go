@@ -0,0 +1,257 @@
+package auth
+
+import (
+	"common/grpctest"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+)
+
+var hmacSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// testKeys are a key of each type the validator accepts.
+type testKeys struct {
+	rsa   *rsa.PrivateKey
+	ecdsa *ecdsa.PrivateKey
+	// rsaPEM is the PEM encoded RSA public key
+	rsaPEM []byte
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testKeys{
+		rsa:    rsaKey,
+		ecdsa:  ecKey,
+		rsaPEM: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+	}
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// config accepts HS* tokens signed with hmacSecret, RS* and PS* tokens
+// signed with the RSA key, and ES* tokens signed with the ECDSA key, whose
+// JWKS id is "ec".
+func (k *testKeys) config(t *testing.T) Config {
+	b64 := base64.RawURLEncoding.EncodeToString
+	jwks := fmt.Sprintf(`{"kty": "EC", "kid": "ec", "crv": "P-256", "x": %q, "y": %q}`,
+		b64(k.ecdsa.X.Bytes()), b64(k.ecdsa.Y.Bytes()))
+	return Config{
+		HMACSecretFile: writeFile(t, "secret", hmacSecret),
+		PublicKeyFile:  writeFile(t, "rsa.pem", k.rsaPEM),
+		JWKSFile:       writeJWKS(t, jwks),
+		Issuer:         "https://issuer.example.com",
+		Audience:       "calculator",
+	}
+}
+
+// claims are valid for the config of testKeys.
+func claims(subject string) *Claims {
+	now := time.Now()
+	return &Claims{RegisteredClaims: jwt.RegisteredClaims{
+		Subject:   subject,
+		Issuer:    "https://issuer.example.com",
+		Audience:  jwt.ClaimStrings{"calculator"},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+	}}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, c *Claims, key interface{}) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, c)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestValidate(t *testing.T) {
+	keys := newTestKeys(t)
+	v, err := NewValidator(keys.config(t))
+	if err != nil {
+		t.Fatalf("NewValidator: %v", err)
+	}
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modify := func(f func(c *Claims)) *Claims {
+		c := claims("alice")
+		f(c)
+		return c
+	}
+	hour := time.Hour
+
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"HS256", sign(t, jwt.SigningMethodHS256, "", claims("alice"), hmacSecret), true},
+		{"HS512", sign(t, jwt.SigningMethodHS512, "", claims("alice"), hmacSecret), true},
+		{"RS256", sign(t, jwt.SigningMethodRS256, "", claims("alice"), keys.rsa), true},
+		{"PS384", sign(t, jwt.SigningMethodPS384, "", claims("alice"), keys.rsa), true},
+		{"ES256 by key id", sign(t, jwt.SigningMethodES256, "ec", claims("alice"), keys.ecdsa), true},
+		{"wrong HMAC secret", sign(t, jwt.SigningMethodHS256, "", claims("alice"), []byte("guess")), false},
+		{"other RSA key", sign(t, jwt.SigningMethodRS256, "", claims("alice"), other), false},
+		{"unknown key id", sign(t, jwt.SigningMethodES256, "nope", claims("alice"), keys.ecdsa), false},
+		{"ES256 without its key id", sign(t, jwt.SigningMethodES256, "", claims("alice"), keys.ecdsa), false},
+		{"expired", sign(t, jwt.SigningMethodHS256, "", modify(func(c *Claims) {
+			c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-hour))
+		}), hmacSecret), false},
+		{"not yet valid", sign(t, jwt.SigningMethodHS256, "", modify(func(c *Claims) {
+			c.NotBefore = jwt.NewNumericDate(time.Now().Add(hour))
+		}), hmacSecret), false},
+		{"wrong issuer", sign(t, jwt.SigningMethodHS256, "", modify(func(c *Claims) {
+			c.Issuer = "https://evil.example.com"
+		}), hmacSecret), false},
+		{"no issuer", sign(t, jwt.SigningMethodHS256, "", modify(func(c *Claims) {
+			c.Issuer = ""
+		}), hmacSecret), false},
+		{"wrong audience", sign(t, jwt.SigningMethodHS256, "", modify(func(c *Claims) {
+			c.Audience = jwt.ClaimStrings{"blog"}
+		}), hmacSecret), false},
+		{"one of several audiences", sign(t, jwt.SigningMethodHS256, "", modify(func(c *Claims) {
+			c.Audience = jwt.ClaimStrings{"blog", "calculator"}
+		}), hmacSecret), true},
+		{"no subject", sign(t, jwt.SigningMethodHS256, "", claims(""), hmacSecret), false},
+		{"alg none", sign(t, jwt.SigningMethodNone, "", claims("alice"), jwt.UnsafeAllowNoneSignatureType), false},
+		{"malformed", "not.a.token", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := v.Validate(tt.token)
+			if (err == nil) != tt.ok {
+				t.Fatalf("Validate: got error %v, want success %v", err, tt.ok)
+			}
+			if tt.ok && c.Subject != "alice" {
+				t.Errorf("Validate: got subject %q, want alice", c.Subject)
+			}
+		})
+	}
+}
+
+// TestAlgorithmConfusion checks that the public key cannot serve as an HMAC
+// secret: a token signed with HS256 and the public key in the PEM file, which
+// anyone may know, is refused.
+func TestAlgorithmConfusion(t *testing.T) {
+	keys := newTestKeys(t)
+	forged := sign(t, jwt.SigningMethodHS256, "", claims("mallory"), keys.rsaPEM)
+
+	// with only the RSA key, HS* tokens are not accepted at all
+	rsaOnly, err := NewValidator(Config{PublicKeyFile: writeFile(t, "rsa.pem", keys.rsaPEM)})
+	if err != nil {
+		t.Fatalf("NewValidator: %v", err)
+	}
+	if _, err := rsaOnly.Validate(forged); err == nil {
+		t.Error("RSA validator accepted an HS256 token signed with the public key")
+	}
+
+	// with an HMAC secret too, HS* tokens are checked against the secret only
+	both, err := NewValidator(keys.config(t))
+	if err != nil {
+		t.Fatalf("NewValidator: %v", err)
+	}
+	if _, err := both.Validate(forged); err == nil {
+		t.Error("validator accepted an HS256 token signed with the public key")
+	}
+	// nor may a token name the RSA key by id to have it used as a secret
+	withKid := writeJWKS(t, fmt.Sprintf(`{"kty": "RSA", "kid": "rsa", "n": %q, "e": "AQAB"}`,
+		base64.RawURLEncoding.EncodeToString(keys.rsa.N.Bytes())))
+	byID, err := NewValidator(Config{HMACSecretFile: writeFile(t, "secret", hmacSecret), JWKSFile: withKid})
+	if err != nil {
+		t.Fatalf("NewValidator: %v", err)
+	}
+	if _, err := byID.Validate(sign(t, jwt.SigningMethodHS256, "rsa", claims("mallory"), keys.rsaPEM)); err == nil {
+		t.Error("validator accepted an HS256 token naming the RSA key")
+	}
+}
+
+func TestAuthenticateExemptsReflection(t *testing.T) {
+	keys := newTestKeys(t)
+	cfg := keys.config(t)
+	cfg.Exempt = []string{"/grpc.reflection."}
+	v, err := NewValidator(cfg)
+	if err != nil {
+		t.Fatalf("NewValidator: %v", err)
+	}
+	cc := grpctest.NewServer(t, func(s *grpc.Server) {
+		healthpb.RegisterHealthServer(s, health.NewServer())
+		reflection.Register(s)
+	},
+		grpc.ChainUnaryInterceptor(v.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(v.StreamServerInterceptor()),
+	)
+	ctx := context.Background()
+
+	// reflection works without a token
+	stream, err := rpb.NewServerReflectionClient(cc).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_ListServices{}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Errorf("reflection without a token: %v", err)
+	}
+
+	// other methods need one
+	client := healthpb.NewHealthClient(cc)
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Check without a token: got %v, want Unauthenticated", err)
+	}
+	bearer := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+sign(t, jwt.SigningMethodHS256, "", claims("alice"), hmacSecret))
+	if _, err := client.Check(bearer, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Errorf("Check with a token: %v", err)
+	}
+	expired := claims("alice")
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	bearer = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+sign(t, jwt.SigningMethodHS256, "", expired, hmacSecret))
+	if _, err := client.Check(bearer, &healthpb.HealthCheckRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Check with an expired token: got %v, want Unauthenticated", err)
+	}
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// TokenCredentials implements credentials.PerRPCCredentials by sending a
+// bearer token in the "authorization" metadata of every call.
+type TokenCredentials struct {
+	token    func() (string, error)
+	insecure bool
+}
+
+var _ credentials.PerRPCCredentials = (*TokenCredentials)(nil)
+
+// NewTokenCredentials sends a fixed token. Unless allowInsecure is set, gRPC
+// refuses to send it over a connection without transport security.
+func NewTokenCredentials(token string, allowInsecure bool) *TokenCredentials {
+	return &TokenCredentials{
+		token:    func() (string, error) { return token, nil },
+		insecure: allowInsecure,
+	}
+}
+
+// NewTokenFileCredentials reads the token from path before every call, so a
+// token refreshed on disk is picked up without reconnecting.
+func NewTokenFileCredentials(path string, allowInsecure bool) *TokenCredentials {
+	return &TokenCredentials{
+		token: func() (string, error) {
+			b, err := ioutil.ReadFile(path)
+			if err != nil {
+				return "", fmt.Errorf("reading token: %w", err)
+			}
+			return strings.TrimSpace(string(b)), nil
+		},
+		insecure: allowInsecure,
+	}
+}
+
+// GetRequestMetadata returns the authorization header for a call.
+func (c *TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := c.token()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+// RequireTransportSecurity reports whether the token may only be sent over TLS.
+func (c *TokenCredentials) RequireTransportSecurity() bool {
+	return !c.insecure
+}
+
+// ClientConfig selects the token a client sends.
+type ClientConfig struct {
+	Token     string
+	TokenFile string
+}
+
+// RegisterFlags binds the client authentication options to fs.
+func (c *ClientConfig) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.Token, "auth-token", "", "bearer token sent with every call")
+	fs.StringVar(&c.TokenFile, "auth-token-file", "", "file holding the bearer token, re-read before every call")
+}
+
+// Credentials returns the per-RPC credentials for the configured token, or
+// nil when no token is configured.
+func (c *ClientConfig) Credentials(allowInsecure bool) credentials.PerRPCCredentials {
+	switch {
+	case c.TokenFile != "":
+		return NewTokenFileCredentials(c.TokenFile, allowInsecure)
+	case c.Token != "":
+		return NewTokenCredentials(c.Token, allowInsecure)
+	default:
+		return nil
+	}
+}
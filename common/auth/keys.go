@@ -0,0 +1,282 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// keySet holds the verification keys. Keys without an id are used for
+// tokens that carry no "kid" header, one per key type.
+type keySet struct {
+	byID  map[string]interface{}
+	hmac  []byte
+	rsa   *rsa.PublicKey
+	ecdsa *ecdsa.PublicKey
+}
+
+func loadKeySet(cfg Config) (*keySet, error) {
+	ks := &keySet{byID: make(map[string]interface{})}
+	if cfg.HMACSecretFile != "" {
+		secret, err := ioutil.ReadFile(cfg.HMACSecretFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading HMAC secret: %w", err)
+		}
+		secret = []byte(strings.TrimSpace(string(secret)))
+		if len(secret) == 0 {
+			return nil, errors.New("HMAC secret file is empty")
+		}
+		ks.hmac = secret
+	}
+	if cfg.PublicKeyFile != "" {
+		key, err := loadPublicKey(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		ks.addDefault(key)
+	}
+	if cfg.JWKSFile != "" {
+		if err := ks.loadJWKS(cfg.JWKSFile); err != nil {
+			return nil, err
+		}
+	}
+	return ks, nil
+}
+
+func (ks *keySet) addDefault(key interface{}) {
+	switch k := key.(type) {
+	case []byte:
+		ks.hmac = k
+	case *rsa.PublicKey:
+		ks.rsa = k
+	case *ecdsa.PublicKey:
+		ks.ecdsa = k
+	}
+}
+
+// methods lists the signing algorithms for which a key is available, so that
+// a token cannot pick an algorithm family the server does not expect.
+func (ks *keySet) methods() []string {
+	var hasHMAC, hasRSA, hasECDSA bool
+	check := func(key interface{}) {
+		switch key.(type) {
+		case []byte:
+			hasHMAC = true
+		case *rsa.PublicKey:
+			hasRSA = true
+		case *ecdsa.PublicKey:
+			hasECDSA = true
+		}
+	}
+	for _, key := range ks.byID {
+		check(key)
+	}
+	hasHMAC = hasHMAC || ks.hmac != nil
+	hasRSA = hasRSA || ks.rsa != nil
+	hasECDSA = hasECDSA || ks.ecdsa != nil
+
+	var methods []string
+	if hasHMAC {
+		methods = append(methods, "HS256", "HS384", "HS512")
+	}
+	if hasRSA {
+		methods = append(methods, "RS256", "RS384", "RS512", "PS256", "PS384", "PS512")
+	}
+	if hasECDSA {
+		methods = append(methods, "ES256", "ES384", "ES512")
+	}
+	return methods
+}
+
+// keyFunc is a jwt.Keyfunc returning the key matching the token's "kid"
+// header and signing algorithm.
+func (ks *keySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	var key interface{}
+	if kid, _ := token.Header["kid"].(string); kid != "" {
+		k, ok := ks.byID[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		key = k
+	}
+
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if key == nil {
+			key = ks.hmac
+		}
+		if k, ok := key.([]byte); ok && len(k) > 0 {
+			return k, nil
+		}
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		if key == nil {
+			key = ks.rsa
+		}
+		if k, ok := key.(*rsa.PublicKey); ok && k != nil {
+			return k, nil
+		}
+	case *jwt.SigningMethodECDSA:
+		if key == nil {
+			key = ks.ecdsa
+		}
+		if k, ok := key.(*ecdsa.PublicKey); ok && k != nil {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s key available", token.Method.Alg())
+}
+
+func loadPublicKey(path string) (interface{}, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading public key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", path)
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		key = cert.PublicKey
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("%s: unsupported public key type %T", path, key)
+	}
+}
+
+// jwk is a single JSON Web Key (RFC 7517).
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	// symmetric
+	K string `json:"k"`
+}
+
+func (ks *keySet) loadJWKS(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading JWKS: %w", err)
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("parsing JWKS: %w", err)
+	}
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return fmt.Errorf("JWKS key %d: %w", i, err)
+		}
+		if k.Kid == "" {
+			ks.addDefault(key)
+			continue
+		}
+		ks.byID[k.Kid] = key
+	}
+	return nil
+}
+
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("exponent: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("x: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("y: %w", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return nil, fmt.Errorf("k: %w", err)
+		}
+		// an empty secret would verify tokens signed with an empty secret
+		if len(secret) == 0 {
+			return nil, errors.New("k: empty value")
+		}
+		return secret, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
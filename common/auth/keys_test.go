@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func writeJWKS(t *testing.T, keys string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := ioutil.WriteFile(path, []byte(`{"keys": [`+keys+`]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestJWKSSymmetricKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{"secret", `{"kty": "oct", "kid": "a", "k": "c2VjcmV0"}`, false},
+		{"empty secret", `{"kty": "oct", "kid": "a", "k": ""}`, true},
+		{"empty default secret", `{"kty": "oct"}`, true},
+		{"bad encoding", `{"kty": "oct", "k": "!!"}`, true},
+	}
+	for _, tt := range tests {
+		_, err := loadKeySet(Config{JWKSFile: writeJWKS(t, tt.key)})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: loadKeySet error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestEmptySecretTokenRejected(t *testing.T) {
+	// a key set that somehow holds an empty secret must not verify tokens
+	// signed with one
+	ks := &keySet{byID: map[string]interface{}{"a": []byte{}}, hmac: []byte{}}
+	for _, kid := range []string{"", "a"} {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: "mallory"})
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		signed, err := token.SignedString([]byte{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = jwt.NewParser(jwt.WithValidMethods([]string{"HS256"})).Parse(signed, ks.keyFunc)
+		if err == nil || !strings.Contains(err.Error(), "no HS256 key available") {
+			t.Errorf("kid %q: got %v, want no HS256 key available", kid, err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// RoleAdmin may act on resources owned by any caller.
+const RoleAdmin = "admin"
+
+// anyRole in a rule admits every authenticated caller.
+const anyRole = "*"
+
+// Policy maps full method names, or whole services written as
+// "/blog.BlogService/*", to the roles allowed to call them. Methods without a
+// rule are open to every authenticated caller.
+//
+// A policy file is a JSON object in the same shape:
+//
+//	{
+//	    "/blog.BlogService/DeleteBlog": ["admin", "editor"],
+//	    "/calculator.CalculatorService/*": ["*"]
+//	}
+type Policy map[string][]string
+
+// LoadPolicy reads a policy from a JSON file.
+func LoadPolicy(path string) (Policy, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading policy: %w", err)
+	}
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing policy: %w", err)
+	}
+	for method := range p {
+		if !strings.HasPrefix(method, "/") {
+			return nil, fmt.Errorf("policy rule %q: method must look like /package.Service/Method", method)
+		}
+	}
+	return p, nil
+}
+
+// Allowed reports whether the caller holding claims may call method.
+// An exact method rule takes precedence over a service wildcard.
+func (p Policy) Allowed(method string, claims *Claims) bool {
+	roles, ok := p[method]
+	if !ok {
+		i := strings.LastIndex(method, "/")
+		if roles, ok = p[method[:i+1]+"*"]; !ok {
+			return true
+		}
+	}
+	if claims == nil {
+		return false
+	}
+	for _, role := range roles {
+		if role == anyRole || claims.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
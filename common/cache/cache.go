@@ -0,0 +1,243 @@
+// Package cache answers repeated calls to deterministic methods from memory.
+//
+// The responses of unary and server streaming calls are kept in a bounded
+// LRU cache, keyed on the method and the canonical encoding of the request,
+// and expire after a TTL. Calls with errors are not cached. A call skips the
+// cache with a bool field of its request named by Config.BypassField, or,
+// for any method, with the cache-control metadata which Bypass adds to a
+// context:
+//
+//	cache-control: no-cache
+//
+// The field is never part of the cache key: the calls setting it are not
+// cached, and the encoding of the others leaves it out as false.
+//
+// Responses carry an x-cache header saying whether they came from the cache:
+// hit, miss or bypass.
+package cache
+
+import (
+	"container/list"
+	"flag"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// MetadataKey is the metadata key with which a call skips the cache.
+	MetadataKey = "cache-control"
+	// NoCache is the value of MetadataKey skipping the cache: the call is
+	// handled and its response is neither read from the cache nor stored.
+	NoCache = "no-cache"
+	// HeaderKey is the header key telling where the response comes from.
+	HeaderKey = "x-cache"
+)
+
+// Config bounds a cache.
+type Config struct {
+	// MaxEntries is the number of responses kept; 0 disables the cache.
+	MaxEntries int
+	// MaxBytes bounds the encoded size of the cached responses. A response,
+	// or all the messages of a stream, larger than an eighth of it is not
+	// cached, so that it does not flush the others.
+	MaxBytes int64
+	// TTL is how long a response stays cached; 0 means until it is evicted.
+	TTL time.Duration
+	// Methods are the full method names, or whole services written as
+	// "/pkg.Service/*", whose calls are cached; all of them when empty.
+	// Their responses must only depend on their requests.
+	Methods []string
+	// BypassField is the name of a bool field of the requests with which a
+	// call skips the cache, as with the cache-control metadata; requests
+	// without it can only skip it with the metadata.
+	BypassField string
+}
+
+// RegisterFlags binds the cache options to fs.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.IntVar(&c.MaxEntries, "cache-entries", 0, "number of responses cached; 0 disables the cache")
+	fs.Int64Var(&c.MaxBytes, "cache-bytes", 64<<20, "size of the cached responses, in bytes")
+	fs.DurationVar(&c.TTL, "cache-ttl", 10*time.Minute, "how long responses stay cached; 0 means until they are evicted")
+}
+
+// Enabled reports whether responses are cached.
+func (c *Config) Enabled() bool {
+	return c.MaxEntries > 0 && c.MaxBytes > 0
+}
+
+// caches reports whether the calls to method are cached.
+func (c *Config) caches(method string) bool {
+	if len(c.Methods) == 0 {
+		return true
+	}
+	service := method[:strings.LastIndex(method, "/")+1] + "*"
+	for _, m := range c.Methods {
+		if m == method || m == service {
+			return true
+		}
+	}
+	return false
+}
+
+// bypassRequested reports whether req has its BypassField set.
+func (c *Config) bypassRequested(req interface{}) bool {
+	m, ok := req.(proto.Message)
+	if c.BypassField == "" || !ok {
+		return false
+	}
+	r := m.ProtoReflect()
+	fd := r.Descriptor().Fields().ByName(protoreflect.Name(c.BypassField))
+	return fd != nil && fd.Kind() == protoreflect.BoolKind && !fd.IsList() && r.Get(fd).Bool()
+}
+
+// Stats are the counters of a cache.
+type Stats struct {
+	Hits, Misses, Bypasses uint64
+	// Evictions counts the responses removed to make room, Expirations
+	// those removed after their TTL.
+	Evictions, Expirations uint64
+	Entries                int
+	Bytes                  int64
+}
+
+// HitRate returns the fraction of the cached calls answered from the cache,
+// bypasses left out.
+func (s Stats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+type entry struct {
+	key       string
+	responses []proto.Message
+	size      int64
+	expires   time.Time
+}
+
+// Cache is an LRU cache of responses, safe for concurrent use.
+type Cache struct {
+	cfg Config
+	now func() time.Time
+
+	mu    sync.Mutex
+	lru   *list.List // of *entry, the most recently used first
+	items map[string]*list.Element
+	stats Stats
+}
+
+// New returns a cache bounded by cfg.
+func New(cfg Config) *Cache {
+	return &Cache{
+		cfg:   cfg,
+		now:   time.Now,
+		lru:   list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Stats returns the current counters.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.stats
+	s.Entries = c.lru.Len()
+	return s
+}
+
+// get returns the responses cached under key, counting a hit or a miss.
+func (c *Cache) get(key string) ([]proto.Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry)
+		if c.cfg.TTL <= 0 || c.now().Before(e.expires) {
+			c.lru.MoveToFront(el)
+			c.stats.Hits++
+			return e.responses, true
+		}
+		c.remove(el)
+		c.stats.Expirations++
+	}
+	c.stats.Misses++
+	return nil, false
+}
+
+// put caches the responses of a call, evicting the least recently used ones
+// beyond the bounds.
+func (c *Cache) put(key string, responses []proto.Message, size int64) {
+	size += int64(len(key))
+	if size > c.cfg.MaxBytes/8 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		// a concurrent miss got there first
+		c.remove(el)
+	}
+	e := &entry{key: key, responses: responses, size: size, expires: c.now().Add(c.cfg.TTL)}
+	c.items[key] = c.lru.PushFront(e)
+	c.stats.Bytes += size
+	for c.lru.Len() > c.cfg.MaxEntries || c.stats.Bytes > c.cfg.MaxBytes {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *Cache) remove(el *list.Element) {
+	e := c.lru.Remove(el).(*entry)
+	delete(c.items, e.key)
+	c.stats.Bytes -= e.size
+}
+
+func (c *Cache) bypass() {
+	c.mu.Lock()
+	c.stats.Bypasses++
+	c.mu.Unlock()
+}
+
+// Collector returns the Prometheus metrics of the cache.
+func (c *Cache) Collector() prometheus.Collector {
+	counter := func(name, help string, value func(Stats) uint64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{Name: name, Help: help}, func() float64 {
+			return float64(value(c.Stats()))
+		})
+	}
+	gauge := func(name, help string, value func(Stats) float64) prometheus.Collector {
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: name, Help: help}, func() float64 {
+			return value(c.Stats())
+		})
+	}
+	return collectors{
+		counter("grpc_cache_hits_total", "Calls answered from the response cache.", func(s Stats) uint64 { return s.Hits }),
+		counter("grpc_cache_misses_total", "Cached calls that were handled.", func(s Stats) uint64 { return s.Misses }),
+		counter("grpc_cache_bypasses_total", "Calls that skipped the cache.", func(s Stats) uint64 { return s.Bypasses }),
+		counter("grpc_cache_evictions_total", "Responses removed to make room.", func(s Stats) uint64 { return s.Evictions }),
+		counter("grpc_cache_expirations_total", "Responses removed after their TTL.", func(s Stats) uint64 { return s.Expirations }),
+		gauge("grpc_cache_entries", "Responses in the cache.", func(s Stats) float64 { return float64(s.Entries) }),
+		gauge("grpc_cache_bytes", "Encoded size of the responses in the cache.", func(s Stats) float64 { return float64(s.Bytes) }),
+		gauge("grpc_cache_hit_rate", "Fraction of the cached calls answered from the cache.", Stats.HitRate),
+	}
+}
+
+// collectors gathers several collectors into one.
+type collectors []prometheus.Collector
+
+func (cs collectors) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range cs {
+		c.Describe(ch)
+	}
+}
+
+func (cs collectors) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range cs {
+		c.Collect(ch)
+	}
+}
@@ -0,0 +1,253 @@
+package cache
+
+import (
+	"common/grpctest"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// newClient serves the health service behind c. Its responses change with
+// the serving status, which shows when they come from the cache.
+func newClient(t *testing.T, c *Cache) (healthpb.HealthClient, *health.Server) {
+	h := health.NewServer()
+	cc := grpctest.NewServer(t, func(s *grpc.Server) {
+		healthpb.RegisterHealthServer(s, h)
+	},
+		grpc.ChainUnaryInterceptor(c.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(c.StreamServerInterceptor()),
+	)
+	return healthpb.NewHealthClient(cc), h
+}
+
+// check returns the serving status of service and the x-cache header.
+func check(t *testing.T, ctx context.Context, client healthpb.HealthClient, service string) (healthpb.HealthCheckResponse_ServingStatus, string) {
+	t.Helper()
+	var md metadata.MD
+	res, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service}, grpc.Header(&md))
+	if err != nil {
+		return healthpb.HealthCheckResponse_UNKNOWN, err.Error()
+	}
+	var from string
+	if v := md.Get(HeaderKey); len(v) > 0 {
+		from = v[0]
+	}
+	return res.GetStatus(), from
+}
+
+func TestUnary(t *testing.T) {
+	c := New(Config{MaxEntries: 10, MaxBytes: 1 << 20, TTL: time.Minute})
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	client, h := newClient(t, c)
+	ctx := context.Background()
+	serving, notServing := healthpb.HealthCheckResponse_SERVING, healthpb.HealthCheckResponse_NOT_SERVING
+
+	h.SetServingStatus("a", serving)
+	if st, from := check(t, ctx, client, "a"); st != serving || from != "miss" {
+		t.Errorf("first call = %v, %q; want SERVING, miss", st, from)
+	}
+	h.SetServingStatus("a", notServing)
+	if st, from := check(t, ctx, client, "a"); st != serving || from != "hit" {
+		t.Errorf("second call = %v, %q; want the cached SERVING, hit", st, from)
+	}
+	bypass := metadata.AppendToOutgoingContext(ctx, MetadataKey, "max-age=0, No-Cache")
+	if st, from := check(t, bypass, client, "a"); st != notServing || from != "bypass" {
+		t.Errorf("call with no-cache = %v, %q; want NOT_SERVING, bypass", st, from)
+	}
+
+	now = now.Add(time.Minute)
+	if st, from := check(t, ctx, client, "a"); st != notServing || from != "miss" {
+		t.Errorf("call after the TTL = %v, %q; want NOT_SERVING, miss", st, from)
+	}
+
+	// errors are not cached
+	if _, from := check(t, ctx, client, "unknown"); from == "hit" {
+		t.Errorf("failed call came from the cache")
+	}
+	if _, from := check(t, ctx, client, "unknown"); from == "hit" {
+		t.Errorf("failed call came from the cache")
+	}
+
+	want := Stats{Hits: 1, Misses: 4, Bypasses: 1, Expirations: 1, Entries: 1}
+	got := c.Stats()
+	got.Bytes = 0
+	if got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+	if r := got.HitRate(); r != 0.2 {
+		t.Errorf("HitRate() = %v, want 0.2", r)
+	}
+}
+
+func TestBypass(t *testing.T) {
+	c := New(Config{MaxEntries: 10, MaxBytes: 1 << 20})
+	client, h := newClient(t, c)
+	ctx := context.Background()
+	h.SetServingStatus("a", healthpb.HealthCheckResponse_SERVING)
+	if _, from := check(t, ctx, client, "a"); from != "miss" {
+		t.Fatalf("first call: got %q, want miss", from)
+	}
+
+	h.SetServingStatus("a", healthpb.HealthCheckResponse_NOT_SERVING)
+	if st, from := check(t, Bypass(ctx), client, "a"); st != healthpb.HealthCheckResponse_NOT_SERVING || from != "bypass" {
+		t.Errorf("call with Bypass = %v, %q; want NOT_SERVING, bypass", st, from)
+	}
+	// the bypassing call did not replace the cached response
+	if st, from := check(t, ctx, client, "a"); st != healthpb.HealthCheckResponse_SERVING || from != "hit" {
+		t.Errorf("call after the bypass = %v, %q; want the cached SERVING, hit", st, from)
+	}
+
+	h.SetServingStatus("b", healthpb.HealthCheckResponse_SERVING)
+	stream, err := client.Watch(Bypass(ctx), &healthpb.HealthCheckRequest{Service: "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Watch with Bypass: %v", err)
+	}
+	if md, _ := stream.Header(); len(md.Get(HeaderKey)) != 1 || md.Get(HeaderKey)[0] != "bypass" {
+		t.Errorf("Watch with Bypass: got header %v, want %s: bypass", md, HeaderKey)
+	}
+}
+
+func TestBypassField(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		req   interface{}
+		want  bool
+	}{
+		{"set", "value", wrapperspb.Bool(true), true},
+		{"unset", "value", wrapperspb.Bool(false), false},
+		{"no field configured", "", wrapperspb.Bool(true), false},
+		{"other field", "no_cache", wrapperspb.Bool(true), false},
+		{"not a bool", "value", wrapperspb.String("true"), false},
+		{"not a message", "value", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{BypassField: tt.field}
+			if got := cfg.bypassRequested(tt.req); got != tt.want {
+				t.Errorf("bypassRequested(%v) = %v, want %v", tt.req, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestHitIsACopy checks that a caller modifying a response from the cache
+// does not change the cached one.
+func TestHitIsACopy(t *testing.T) {
+	interceptor := New(Config{MaxEntries: 10, MaxBytes: 1 << 20}).UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+	}
+	for i := 0; i < 3; i++ {
+		res, err := interceptor(context.Background(), &healthpb.HealthCheckRequest{}, info, handler)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st := res.(*healthpb.HealthCheckResponse).Status; st != healthpb.HealthCheckResponse_SERVING {
+			t.Fatalf("call %d = %v, want SERVING", i, st)
+		}
+		res.(*healthpb.HealthCheckResponse).Status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+}
+
+func TestEviction(t *testing.T) {
+	c := New(Config{MaxEntries: 2, MaxBytes: 1 << 20})
+	client, h := newClient(t, c)
+	ctx := context.Background()
+	for _, s := range []string{"a", "b", "c"} {
+		h.SetServingStatus(s, healthpb.HealthCheckResponse_SERVING)
+	}
+	check(t, ctx, client, "a")
+	check(t, ctx, client, "b")
+	check(t, ctx, client, "a") // b is now the least recently used
+	check(t, ctx, client, "c")
+	if _, from := check(t, ctx, client, "a"); from != "hit" {
+		t.Errorf("a: %q, want hit", from)
+	}
+	if _, from := check(t, ctx, client, "b"); from != "miss" {
+		t.Errorf("b: %q, want miss after its eviction", from)
+	}
+	if s := c.Stats(); s.Entries != 2 || s.Evictions != 2 {
+		t.Errorf("Stats() = %+v, want 2 entries and 2 evictions", s)
+	}
+
+	// a response larger than an eighth of MaxBytes is not cached
+	small := New(Config{MaxEntries: 2, MaxBytes: 8})
+	client, h = newClient(t, small)
+	h.SetServingStatus("a", healthpb.HealthCheckResponse_SERVING)
+	check(t, ctx, client, "a")
+	if _, from := check(t, ctx, client, "a"); from != "miss" {
+		t.Errorf("large response: %q, want miss", from)
+	}
+}
+
+func TestMethods(t *testing.T) {
+	cfg := Config{Methods: []string{"/pkg.A/*", "/pkg.B/Get"}}
+	for method, want := range map[string]bool{
+		"/pkg.A/Get":  true,
+		"/pkg.A/List": true,
+		"/pkg.B/Get":  true,
+		"/pkg.B/List": false,
+	} {
+		if got := cfg.caches(method); got != want {
+			t.Errorf("caches(%q) = %v, want %v", method, got, want)
+		}
+	}
+
+	c := New(Config{MaxEntries: 10, MaxBytes: 1 << 20, Methods: []string{"/pkg.A/*"}})
+	client, h := newClient(t, c)
+	h.SetServingStatus("a", healthpb.HealthCheckResponse_SERVING)
+	check(t, context.Background(), client, "a")
+	if _, from := check(t, context.Background(), client, "a"); from != "" {
+		t.Errorf("uncached method: x-cache %q, want none", from)
+	}
+}
+
+func TestCollector(t *testing.T) {
+	c := New(Config{MaxEntries: 10, MaxBytes: 1 << 20})
+	client, h := newClient(t, c)
+	h.SetServingStatus("a", healthpb.HealthCheckResponse_SERVING)
+	for i := 0; i < 4; i++ {
+		check(t, context.Background(), client, "a")
+	}
+
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(c.Collector()); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	values := make(map[string]float64)
+	for _, f := range families {
+		m := f.GetMetric()[0]
+		if m.GetCounter() != nil {
+			values[f.GetName()] = m.GetCounter().GetValue()
+		} else {
+			values[f.GetName()] = m.GetGauge().GetValue()
+		}
+	}
+	for name, want := range map[string]float64{
+		"grpc_cache_hits_total":   3,
+		"grpc_cache_misses_total": 1,
+		"grpc_cache_entries":      1,
+		"grpc_cache_hit_rate":     0.75,
+	} {
+		if values[name] != want {
+			t.Errorf("%s = %v, want %v", name, values[name], want)
+		}
+	}
+}
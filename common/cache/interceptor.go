@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// errHit stops a stream handler before it handles a request found in the
+// cache.
+var errHit = errors.New("cache: hit")
+
+// key returns the cache key of a request: its method and its deterministic
+// encoding, in which map entries are sorted and default values left out.
+func key(method string, req interface{}) (string, bool) {
+	m, ok := req.(proto.Message)
+	if !ok {
+		return "", false
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return "", false
+	}
+	return method + "\x00" + string(b), true
+}
+
+// Bypass returns a copy of ctx whose calls skip the cache of the server:
+// their responses are neither read from it nor stored.
+func Bypass(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, NoCache)
+}
+
+// bypassed reports whether the call asks to skip the cache, with the
+// metadata set by Bypass or a list of directives including no-cache.
+func bypassed(ctx context.Context) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get(MetadataKey) {
+		for _, directive := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(directive), NoCache) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func header(value string) metadata.MD {
+	return metadata.Pairs(HeaderKey, value)
+}
+
+// UnaryServerInterceptor answers the calls whose response is cached, and
+// caches the responses of the others. Calls asking to skip the cache are
+// handled as if there were no cache.
+func (c *Cache) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !c.cfg.caches(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if bypassed(ctx) || c.cfg.bypassRequested(req) {
+			c.bypass()
+			_ = grpc.SetHeader(ctx, header("bypass"))
+			return handler(ctx, req)
+		}
+		k, ok := key(info.FullMethod, req)
+		if !ok {
+			return handler(ctx, req)
+		}
+		if responses, ok := c.get(k); ok {
+			_ = grpc.SetHeader(ctx, header("hit"))
+			// the caller may modify the response
+			return proto.Clone(responses[0]), nil
+		}
+		_ = grpc.SetHeader(ctx, header("miss"))
+		res, err := handler(ctx, req)
+		if m, ok := res.(proto.Message); ok && err == nil {
+			c.put(k, []proto.Message{proto.Clone(m)}, int64(proto.Size(m)))
+		}
+		return res, err
+	}
+}
+
+// StreamServerInterceptor answers the server streaming calls whose
+// responses are cached, and caches the responses of the others once they
+// have all been sent. Other streams are left alone.
+func (c *Cache) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !info.IsServerStream || info.IsClientStream || !c.cfg.caches(info.FullMethod) {
+			return handler(srv, stream)
+		}
+		if bypassed(stream.Context()) {
+			c.bypass()
+			_ = stream.SetHeader(header("bypass"))
+			return handler(srv, stream)
+		}
+		cs := &cachingStream{ServerStream: stream, cache: c, method: info.FullMethod}
+		err := handler(srv, cs)
+		if err == errHit {
+			for _, m := range cs.responses {
+				if err := stream.SendMsg(m); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err == nil && cs.key != "" && !cs.skip {
+			c.put(cs.key, cs.responses, cs.size)
+		}
+		return err
+	}
+}
+
+// cachingStream looks the request of a server streaming call up when the
+// handler receives it, and records the responses it sends.
+type cachingStream struct {
+	grpc.ServerStream
+	cache  *Cache
+	method string
+
+	key string
+	// responses are those cached for a hit, those sent for a miss
+	responses []proto.Message
+	size      int64
+	// skip is set when the responses cannot be cached
+	skip bool
+}
+
+func (s *cachingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.cache.cfg.bypassRequested(m) {
+		s.cache.bypass()
+		_ = s.ServerStream.SetHeader(header("bypass"))
+		s.skip = true
+		return nil
+	}
+	k, ok := key(s.method, m)
+	if !ok {
+		s.skip = true
+		return nil
+	}
+	s.key = k
+	if responses, ok := s.cache.get(k); ok {
+		_ = s.ServerStream.SetHeader(header("hit"))
+		s.responses = responses
+		return errHit
+	}
+	_ = s.ServerStream.SetHeader(header("miss"))
+	return nil
+}
+
+func (s *cachingStream) SendMsg(m interface{}) error {
+	if err := s.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	if s.skip {
+		return nil
+	}
+	pm, ok := m.(proto.Message)
+	if !ok {
+		s.skip = true
+		return nil
+	}
+	s.size += int64(proto.Size(pm))
+	if s.size > s.cache.cfg.MaxBytes/8 {
+		// too large to be cached: stop recording
+		s.skip, s.responses = true, nil
+		return nil
+	}
+	// handlers may reuse the message for the next one
+	s.responses = append(s.responses, proto.Clone(pm))
+	return nil
+}
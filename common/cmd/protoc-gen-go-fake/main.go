@@ -0,0 +1,371 @@
+// Command protoc-gen-go-fake is a protoc plugin generating fakes of the gRPC
+// services of a file, for the tests of the code that calls them.
+//
+// For a file of package greetpb, it writes package greetfake in the greetfake
+// directory under greetpb. Each service Foo gets:
+//   - FooClient, a fake FooClient, including its stream clients, answering
+//     with scripted results and recording the calls;
+//   - FooServer, a fake FooServer with the same scripting and recording,
+//     plus latency and error injection, served in process with Client.
+//
+// The fakes are built on the runtime in package common/fake. Run it with the
+// import path of the generated package and the module, for example:
+//
+//	protoc proto/greet.proto --go-fake_out=Mproto/greet.proto=greet/greetpb,module=greet:.
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const (
+	contextPackage  = protogen.GoImportPath("context")
+	ioPackage       = protogen.GoImportPath("io")
+	testingPackage  = protogen.GoImportPath("testing")
+	grpcPackage     = protogen.GoImportPath("google.golang.org/grpc")
+	protoPackage    = protogen.GoImportPath("google.golang.org/protobuf/proto")
+	fakePackage     = protogen.GoImportPath("common/fake")
+	grpctestPackage = protogen.GoImportPath("common/grpctest")
+)
+
+func main() {
+	protogen.Options{}.Run(func(gen *protogen.Plugin) error {
+		for _, f := range gen.Files {
+			if f.Generate && len(f.Services) > 0 {
+				generateFile(gen, f)
+			}
+		}
+		return nil
+	})
+}
+
+// fakePackageName turns greetpb into greetfake.
+func fakePackageName(f *protogen.File) string {
+	return strings.TrimSuffix(string(f.GoPackageName), "pb") + "fake"
+}
+
+func generateFile(gen *protogen.Plugin, f *protogen.File) {
+	name := fakePackageName(f)
+	importPath := protogen.GoImportPath(path.Join(string(f.GoImportPath), name))
+	// next to the generated code of f, which is in path.Dir of the prefix
+	filename := path.Join(path.Dir(f.GeneratedFilenamePrefix), name, path.Base(f.GeneratedFilenamePrefix)+"_fake.pb.go")
+	g := gen.NewGeneratedFile(filename, importPath)
+	g.P("// Code generated by protoc-gen-go-fake. DO NOT EDIT.")
+	g.P("// source: ", f.Desc.Path())
+	g.P()
+	g.P("// Package ", name, " provides fakes of the services of package ", f.GoPackageName, ".")
+	g.P("package ", name)
+	g.P()
+	for _, s := range f.Services {
+		generateClient(g, s)
+		generateServer(g, s)
+	}
+}
+
+func fullMethod(m *protogen.Method) string {
+	return fmt.Sprintf("/%s/%s", m.Parent.Desc.FullName(), m.Desc.Name())
+}
+
+// scriptField is the unexported field holding the script of m.
+func scriptField(m *protogen.Method) string {
+	return strings.ToLower(m.GoName[:1]) + m.GoName[1:] + "Script"
+}
+
+func streamType(m *protogen.Method, side string) string {
+	s := m.Parent.GoName
+	return strings.ToLower(s[:1]) + s[1:] + m.GoName + side
+}
+
+func generateClient(g *protogen.GeneratedFile, s *protogen.Service) {
+	clientName := s.GoName + "Client"
+	iface := g.QualifiedGoIdent(protogen.GoIdent{GoName: clientName, GoImportPath: s.Methods[0].Input.GoIdent.GoImportPath})
+	g.P("// ", clientName, " is a fake ", iface, ". Every call is recorded, then")
+	g.P("// answered by the method's Func field when set, or else by its script:")
+	g.P("// the results queued with On<Method>. Unscripted calls fail with Unimplemented.")
+	g.P("type ", clientName, " struct {")
+	g.P(fakePackage.Ident("Recorder"))
+	g.P()
+	for _, m := range s.Methods {
+		g.P(m.GoName, "Func func(", clientSignature(g, m), ")")
+	}
+	g.P()
+	for _, m := range s.Methods {
+		g.P(scriptField(m), " ", fakePackage.Ident("Script"))
+	}
+	g.P("}")
+	g.P()
+	g.P("var _ ", iface, " = (*", clientName, ")(nil)")
+	g.P()
+	for _, m := range s.Methods {
+		generateOn(g, clientName, m)
+		generateClientMethod(g, clientName, m)
+	}
+}
+
+// clientSignature returns the parameters and results of the client method m.
+func clientSignature(g *protogen.GeneratedFile, m *protogen.Method) string {
+	ctx := g.QualifiedGoIdent(contextPackage.Ident("Context"))
+	opts := "opts ..." + g.QualifiedGoIdent(grpcPackage.Ident("CallOption"))
+	in := "in *" + g.QualifiedGoIdent(m.Input.GoIdent)
+	out := "*" + g.QualifiedGoIdent(m.Output.GoIdent)
+	stream := g.QualifiedGoIdent(protogen.GoIdent{
+		GoName:       m.Parent.GoName + "_" + m.GoName + "Client",
+		GoImportPath: m.Input.GoIdent.GoImportPath,
+	})
+	switch {
+	case m.Desc.IsStreamingClient():
+		return fmt.Sprintf("ctx %s, %s) (%s, error", ctx, opts, stream)
+	case m.Desc.IsStreamingServer():
+		return fmt.Sprintf("ctx %s, %s, %s) (%s, error", ctx, in, opts, stream)
+	}
+	return fmt.Sprintf("ctx %s, %s, %s) (%s, error", ctx, in, opts, out)
+}
+
+// generateOn writes the method scripting the results of m.
+func generateOn(g *protogen.GeneratedFile, recv string, m *protogen.Method) {
+	out := "*" + g.QualifiedGoIdent(m.Output.GoIdent)
+	result := g.QualifiedGoIdent(fakePackage.Ident("Result"))
+	message := g.QualifiedGoIdent(protoPackage.Ident("Message"))
+	if m.Desc.IsStreamingServer() {
+		g.P("// On", m.GoName, " queues the result of a ", m.GoName, " call: the responses, in order,")
+		g.P("// then err, or the end of the stream when err is nil.")
+		g.P("func (f *", recv, ") On", m.GoName, "(responses []", out, ", err error) {")
+		g.P("r := ", result, "{Err: err}")
+		g.P("for _, res := range responses {")
+		g.P("r.Responses = append(r.Responses, res)")
+		g.P("}")
+		g.P("f.", scriptField(m), ".Add(r)")
+		g.P("}")
+		g.P()
+		return
+	}
+	g.P("// On", m.GoName, " queues the result of a ", m.GoName, " call: res, or err when not nil.")
+	g.P("func (f *", recv, ") On", m.GoName, "(res ", out, ", err error) {")
+	g.P("if res == nil {")
+	g.P("res = &", m.Output.GoIdent, "{}")
+	g.P("}")
+	g.P("f.", scriptField(m), ".Add(", result, "{Responses: []", message, "{res}, Err: err})")
+	g.P("}")
+	g.P()
+}
+
+func generateClientMethod(g *protogen.GeneratedFile, recv string, m *protogen.Method) {
+	method := fmt.Sprintf("%q", fullMethod(m))
+	unary := !m.Desc.IsStreamingClient() && !m.Desc.IsStreamingServer()
+	g.P("func (f *", recv, ") ", m.GoName, "(", clientSignature(g, m), ") {")
+	switch {
+	case unary:
+		g.P("f.Record(", method, ", ", fakePackage.Ident("OutgoingMetadata"), "(ctx), in)")
+	case m.Desc.IsStreamingClient():
+		g.P("call := f.Record(", method, ", ", fakePackage.Ident("OutgoingMetadata"), "(ctx))")
+	default:
+		g.P("call := f.Record(", method, ", ", fakePackage.Ident("OutgoingMetadata"), "(ctx), in)")
+	}
+	g.P("if f.", m.GoName, "Func != nil {")
+	if m.Desc.IsStreamingClient() {
+		g.P("return f.", m.GoName, "Func(ctx, opts...)")
+	} else {
+		g.P("return f.", m.GoName, "Func(ctx, in, opts...)")
+	}
+	g.P("}")
+	g.P("r := f.", scriptField(m), ".Next(", method, ")")
+	if unary {
+		g.P("if r.Err != nil {")
+		g.P("return nil, r.Err")
+		g.P("}")
+		g.P("return r.Responses[0].(*", m.Output.GoIdent, "), nil")
+		g.P("}")
+		g.P()
+		return
+	}
+	typ := streamType(m, "Client")
+	g.P("return &", typ, "{", fakePackage.Ident("NewClientStream"), "(ctx, &f.Recorder, call, r)}, nil")
+	g.P("}")
+	g.P()
+
+	g.P("type ", typ, " struct {")
+	g.P("*", fakePackage.Ident("ClientStream"))
+	g.P("}")
+	g.P()
+	if m.Desc.IsStreamingClient() {
+		g.P("func (s *", typ, ") Send(m *", m.Input.GoIdent, ") error {")
+		g.P("return s.SendMsg(m)")
+		g.P("}")
+		g.P()
+	}
+	if m.Desc.IsStreamingServer() {
+		g.P("func (s *", typ, ") Recv() (*", m.Output.GoIdent, ", error) {")
+		g.P("m := &", m.Output.GoIdent, "{}")
+		g.P("if err := s.RecvMsg(m); err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P("return m, nil")
+		g.P("}")
+		g.P()
+		return
+	}
+	g.P("func (s *", typ, ") CloseAndRecv() (*", m.Output.GoIdent, ", error) {")
+	g.P("if err := s.CloseSend(); err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P("m := &", m.Output.GoIdent, "{}")
+	g.P("if err := s.RecvMsg(m); err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P("return m, nil")
+	g.P("}")
+	g.P()
+}
+
+// serverSignature returns the parameters and results of the server method m.
+func serverSignature(g *protogen.GeneratedFile, m *protogen.Method) string {
+	in := "in *" + g.QualifiedGoIdent(m.Input.GoIdent)
+	stream := "stream " + g.QualifiedGoIdent(protogen.GoIdent{
+		GoName:       m.Parent.GoName + "_" + m.GoName + "Server",
+		GoImportPath: m.Input.GoIdent.GoImportPath,
+	})
+	switch {
+	case m.Desc.IsStreamingClient():
+		return stream + ") error"
+	case m.Desc.IsStreamingServer():
+		return in + ", " + stream + ") error"
+	}
+	return fmt.Sprintf("ctx %s, %s) (*%s, error)", g.QualifiedGoIdent(contextPackage.Ident("Context")), in, g.QualifiedGoIdent(m.Output.GoIdent))
+}
+
+func generateServer(g *protogen.GeneratedFile, s *protogen.Service) {
+	serverName := s.GoName + "Server"
+	pkg := s.Methods[0].Input.GoIdent.GoImportPath
+	iface := g.QualifiedGoIdent(protogen.GoIdent{GoName: serverName, GoImportPath: pkg})
+	g.P("// ", serverName, " is a programmable fake ", iface, ". Every call is")
+	g.P("// recorded, delayed and failed as set with the Faults methods, then answered")
+	g.P("// by the method's Func field when set, or else by the results queued with")
+	g.P("// On<Method>. Unscripted calls fail with Unimplemented.")
+	g.P("//")
+	g.P("// Streaming calls read the client's messages as they come. Bidirectional")
+	g.P("// ones send the next scripted response after each message received, and")
+	g.P("// the responses left once the client is done.")
+	g.P("type ", serverName, " struct {")
+	g.P(protogen.GoIdent{GoName: "Unimplemented" + serverName, GoImportPath: pkg})
+	g.P(fakePackage.Ident("Recorder"))
+	g.P(fakePackage.Ident("Faults"))
+	g.P()
+	for _, m := range s.Methods {
+		g.P(m.GoName, "Func func(", serverSignature(g, m))
+	}
+	g.P()
+	for _, m := range s.Methods {
+		g.P(scriptField(m), " ", fakePackage.Ident("Script"))
+	}
+	g.P("}")
+	g.P()
+	g.P("var _ ", iface, " = (*", serverName, ")(nil)")
+	g.P()
+	g.P("// Client serves f in process until the test ends and returns a client of it.")
+	g.P("func (f *", serverName, ") Client(t ", testingPackage.Ident("TB"), ", opts ...", grpcPackage.Ident("ServerOption"), ") ",
+		protogen.GoIdent{GoName: s.GoName + "Client", GoImportPath: pkg}, " {")
+	g.P("cc := ", grpctestPackage.Ident("NewServer"), "(t, func(s *", grpcPackage.Ident("Server"), ") {")
+	g.P(protogen.GoIdent{GoName: "Register" + serverName, GoImportPath: pkg}, "(s, f)")
+	g.P("}, opts...)")
+	g.P("return ", protogen.GoIdent{GoName: "New" + s.GoName + "Client", GoImportPath: pkg}, "(cc)")
+	g.P("}")
+	g.P()
+	for _, m := range s.Methods {
+		generateOn(g, serverName, m)
+		generateServerMethod(g, serverName, m)
+	}
+}
+
+func generateServerMethod(g *protogen.GeneratedFile, recv string, m *protogen.Method) {
+	method := fmt.Sprintf("%q", fullMethod(m))
+	g.P("func (f *", recv, ") ", m.GoName, "(", serverSignature(g, m), " {")
+	unary := !m.Desc.IsStreamingClient() && !m.Desc.IsStreamingServer()
+	if unary {
+		g.P("f.Record(", method, ", ", fakePackage.Ident("IncomingMetadata"), "(ctx), in)")
+		g.P("if err := f.Inject(ctx, ", method, "); err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P("if f.", m.GoName, "Func != nil {")
+		g.P("return f.", m.GoName, "Func(ctx, in)")
+		g.P("}")
+		g.P("r := f.", scriptField(m), ".Next(", method, ")")
+		g.P("if r.Err != nil {")
+		g.P("return nil, r.Err")
+		g.P("}")
+		g.P("return r.Responses[0].(*", m.Output.GoIdent, "), nil")
+		g.P("}")
+		g.P()
+		return
+	}
+
+	g.P("ctx := stream.Context()")
+	if m.Desc.IsStreamingClient() {
+		g.P("call := f.Record(", method, ", ", fakePackage.Ident("IncomingMetadata"), "(ctx))")
+	} else {
+		g.P("f.Record(", method, ", ", fakePackage.Ident("IncomingMetadata"), "(ctx), in)")
+	}
+	g.P("if err := f.Inject(ctx, ", method, "); err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("if f.", m.GoName, "Func != nil {")
+	if m.Desc.IsStreamingClient() {
+		g.P("return f.", m.GoName, "Func(stream)")
+	} else {
+		g.P("return f.", m.GoName, "Func(in, stream)")
+	}
+	g.P("}")
+	g.P("r := f.", scriptField(m), ".Next(", method, ")")
+	switch {
+	case !m.Desc.IsStreamingClient():
+		g.P("for _, res := range r.Responses {")
+		g.P("if err := stream.Send(res.(*", m.Output.GoIdent, ")); err != nil {")
+		g.P("return err")
+		g.P("}")
+		g.P("}")
+		g.P("return r.Err")
+	case !m.Desc.IsStreamingServer():
+		generateServerRecv(g, m, "")
+		g.P("if r.Err != nil {")
+		g.P("return r.Err")
+		g.P("}")
+		g.P("return stream.SendAndClose(r.Responses[0].(*", m.Output.GoIdent, "))")
+	default:
+		g.P("responses := r.Responses")
+		generateServerRecv(g, m, "responses")
+		g.P("for _, res := range responses {")
+		g.P("if err := stream.Send(res.(*", m.Output.GoIdent, ")); err != nil {")
+		g.P("return err")
+		g.P("}")
+		g.P("}")
+		g.P("return r.Err")
+	}
+	g.P("}")
+	g.P()
+}
+
+// generateServerRecv writes the loop recording the messages of the client;
+// with responses, the next response is sent after every message.
+func generateServerRecv(g *protogen.GeneratedFile, m *protogen.Method, responses string) {
+	g.P("for {")
+	g.P("req, err := stream.Recv()")
+	g.P("if err == ", ioPackage.Ident("EOF"), " {")
+	g.P("break")
+	g.P("}")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("f.Append(call, req)")
+	if responses != "" {
+		g.P("if len(", responses, ") > 0 {")
+		g.P("if err := stream.Send(", responses, "[0].(*", m.Output.GoIdent, ")); err != nil {")
+		g.P("return err")
+		g.P("}")
+		g.P(responses, " = ", responses, "[1:]")
+		g.P("}")
+	}
+	g.P("}")
+}
@@ -0,0 +1,78 @@
+// Command token signs JWTs for trying out the authenticated services.
+//
+//	go run ./cmd/token -alg HS256 -key secret.txt -sub ming -roles admin
+package main
+
+import (
+	"common/auth"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func main() {
+	alg := flag.String("alg", "HS256", "signing algorithm, e.g. HS256, RS256 or ES256")
+	keyFile := flag.String("key", "", "HMAC secret file or PEM private key")
+	kid := flag.String("kid", "", "key id put in the token header")
+	sub := flag.String("sub", "", "subject (the caller identity)")
+	roles := flag.String("roles", "", "comma separated roles")
+	iss := flag.String("iss", "", "issuer")
+	aud := flag.String("aud", "", "audience")
+	ttl := flag.Duration("ttl", time.Hour, "token lifetime")
+	flag.Parse()
+
+	method := jwt.GetSigningMethod(*alg)
+	if method == nil {
+		log.Fatalf("unknown signing algorithm %q", *alg)
+	}
+	data, err := ioutil.ReadFile(*keyFile)
+	if err != nil {
+		log.Fatalf("reading key: %v", err)
+	}
+
+	var key interface{}
+	switch method.(type) {
+	case *jwt.SigningMethodHMAC:
+		key = []byte(strings.TrimSpace(string(data)))
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		key, err = jwt.ParseRSAPrivateKeyFromPEM(data)
+	case *jwt.SigningMethodECDSA:
+		key, err = jwt.ParseECPrivateKeyFromPEM(data)
+	default:
+		log.Fatalf("unsupported signing algorithm %q", *alg)
+	}
+	if err != nil {
+		log.Fatalf("parsing key: %v", err)
+	}
+
+	now := time.Now()
+	claims := auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   *sub,
+			Issuer:    *iss,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(*ttl)),
+		},
+	}
+	if *aud != "" {
+		claims.Audience = jwt.ClaimStrings{*aud}
+	}
+	if *roles != "" {
+		claims.Roles = strings.Split(*roles, ",")
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	if *kid != "" {
+		token.Header["kid"] = *kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		log.Fatalf("signing token: %v", err)
+	}
+	fmt.Println(signed)
+}
@@ -0,0 +1,322 @@
+// Package fake is the runtime of the fakes generated by protoc-gen-go-fake:
+// scripted results, call recording, in-memory client streams and fault
+// injection for fake servers.
+//
+// The generated code lives next to each service's generated package, for
+// example greet/greetpb/greetfake, and is what tests use; this package holds
+// the parts shared by every service.
+package fake
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Call is a recorded call.
+type Call struct {
+	// Method is the full method name, such as "/greet.GreetService/Greet".
+	Method string
+	// Requests are the messages the caller sent: one for unary and server
+	// streaming methods, all those sent on the stream otherwise.
+	Requests []proto.Message
+	// Metadata is the outgoing metadata of a client call, or the incoming
+	// metadata of a server call.
+	Metadata metadata.MD
+}
+
+// Request returns the first request of the call, or nil.
+func (c *Call) Request() proto.Message {
+	if len(c.Requests) == 0 {
+		return nil
+	}
+	return c.Requests[0]
+}
+
+// Recorder records calls. Its methods may be called concurrently.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []*Call
+}
+
+// Record records a call and returns it; the requests of streaming calls are
+// added with Append as they are sent.
+func (r *Recorder) Record(method string, md metadata.MD, requests ...proto.Message) *Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := &Call{Method: method, Requests: requests, Metadata: md}
+	r.calls = append(r.calls, c)
+	return c
+}
+
+// Append adds a request sent on the stream of c.
+func (r *Recorder) Append(c *Call, req proto.Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c.Requests = append(c.Requests, req)
+}
+
+// Calls returns a copy of the calls made so far, in order.
+func (r *Recorder) Calls() []Call {
+	return r.CallsTo("")
+}
+
+// CallsTo returns a copy of the calls made to method, or of all calls when
+// method is empty.
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for _, c := range r.calls {
+		if method == "" || c.Method == method {
+			cp := *c
+			cp.Requests = append([]proto.Message(nil), c.Requests...)
+			calls = append(calls, cp)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the recorded calls.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+// Result is a scripted outcome of a call: the responses sent back, in order,
+// then Err, or a clean end of the call when Err is nil.
+type Result struct {
+	Responses []proto.Message
+	Err       error
+}
+
+// Script holds the scripted results of a method. Each call takes the next
+// result; the last one is repeated once the others are used up.
+type Script struct {
+	mu      sync.Mutex
+	results []Result
+}
+
+// Add queues results.
+func (s *Script) Add(results ...Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = append(s.results, results...)
+}
+
+// Next returns the result of the next call. Without a script, the call fails
+// with Unimplemented.
+func (s *Script) Next(method string) Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.results) == 0 {
+		return Result{Err: status.Errorf(codes.Unimplemented, "fake: no result scripted for %s", method)}
+	}
+	r := s.results[0]
+	if len(s.results) > 1 {
+		s.results = s.results[1:]
+	}
+	return r
+}
+
+// Reset drops the queued results.
+func (s *Script) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = nil
+}
+
+// ClientStream is an in-memory grpc.ClientStream returning the responses of
+// a Result and recording what is sent in a Call.
+type ClientStream struct {
+	ctx      context.Context
+	recorder *Recorder
+	call     *Call
+
+	mu        sync.Mutex
+	responses []proto.Message
+	err       error
+	closed    bool
+	// set with SetHeader
+	header, trailer metadata.MD
+}
+
+// NewClientStream returns a stream answering with result, whose sent
+// messages are added to call.
+func NewClientStream(ctx context.Context, recorder *Recorder, call *Call, result Result) *ClientStream {
+	return &ClientStream{
+		ctx:       ctx,
+		recorder:  recorder,
+		call:      call,
+		responses: result.Responses,
+		err:       result.Err,
+	}
+}
+
+// SetHeader sets the metadata returned by Header and Trailer.
+func (s *ClientStream) SetHeader(header, trailer metadata.MD) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.header, s.trailer = header, trailer
+}
+
+// Header returns the scripted header.
+func (s *ClientStream) Header() (metadata.MD, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.header, nil
+}
+
+// Trailer returns the scripted trailer.
+func (s *ClientStream) Trailer() metadata.MD {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.trailer
+}
+
+// CloseSend half-closes the stream; later sends fail.
+func (s *ClientStream) CloseSend() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+// Context returns the context of the call.
+func (s *ClientStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg records m.
+func (s *ClientStream) SendMsg(m interface{}) error {
+	if err := s.ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	s.mu.Lock()
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		return status.Error(codes.Internal, "fake: SendMsg called after CloseSend")
+	}
+	s.recorder.Append(s.call, proto.Clone(m.(proto.Message)))
+	return nil
+}
+
+// RecvMsg fills m with the next scripted response. After the last one, it
+// returns the scripted error, or io.EOF.
+func (s *ClientStream) RecvMsg(m interface{}) error {
+	if err := s.ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.responses) == 0 {
+		if s.err != nil {
+			return s.err
+		}
+		return io.EOF
+	}
+	proto.Merge(m.(proto.Message), s.responses[0])
+	s.responses = s.responses[1:]
+	return nil
+}
+
+var _ grpc.ClientStream = (*ClientStream)(nil)
+
+// Faults makes a fake server misbehave. The zero value injects nothing.
+type Faults struct {
+	mu      sync.Mutex
+	latency map[string]time.Duration
+	errs    map[string]fault
+	rnd     *rand.Rand
+}
+
+type fault struct {
+	err  error
+	rate float64
+}
+
+// SetLatency delays the calls to method, or to every method when method is
+// empty, by d.
+func (f *Faults) SetLatency(method string, d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.latency == nil {
+		f.latency = make(map[string]time.Duration)
+	}
+	f.latency[method] = d
+}
+
+// SetError fails the given fraction of the calls to method, or to every
+// method when method is empty, with err. A rate of 1 fails them all, and a
+// nil err removes the fault.
+func (f *Faults) SetError(method string, err error, rate float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.errs == nil {
+		f.errs = make(map[string]fault)
+	}
+	if err == nil {
+		delete(f.errs, method)
+		return
+	}
+	f.errs[method] = fault{err: err, rate: rate}
+}
+
+// Inject applies the faults of method: it waits for the latency, then
+// returns the injected error, if any. A call canceled while waiting fails
+// with its context's status.
+func (f *Faults) Inject(ctx context.Context, method string) error {
+	f.mu.Lock()
+	d, ok := f.latency[method]
+	if !ok {
+		d = f.latency[""]
+	}
+	flt, ok := f.errs[method]
+	if !ok {
+		flt = f.errs[""]
+	}
+	fail := false
+	if flt.err != nil {
+		if f.rnd == nil {
+			f.rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+		}
+		fail = flt.rate >= 1 || f.rnd.Float64() < flt.rate
+	}
+	f.mu.Unlock()
+
+	if d > 0 {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	if fail {
+		return flt.err
+	}
+	return nil
+}
+
+// IncomingMetadata returns the metadata of a server call.
+func IncomingMetadata(ctx context.Context) metadata.MD {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return md.Copy()
+}
+
+// OutgoingMetadata returns the metadata of a client call.
+func OutgoingMetadata(ctx context.Context) metadata.MD {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	return md.Copy()
+}
@@ -0,0 +1,261 @@
+// Package fault makes servers misbehave on purpose, to test how clients cope
+// with it: their retries, circuit breakers and recovery.
+//
+// A rule says how often the calls to a method are delayed, failed with a
+// status code, made to panic, and how often the messages of its streams are
+// dropped. Rules come from the server's configuration or, when the server
+// allows it, from the x-fault metadata of each call:
+//
+//	x-fault: delay=300ms,error-rate=0.5,code=UNAVAILABLE
+package fault
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// MetadataKey is the metadata key of the rule set by a call.
+const MetadataKey = "x-fault"
+
+// Duration is a time.Duration written as a string such as "300ms" in JSON.
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalJSON formats the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Rule configures the faults of a method. Rates are fractions of the calls,
+// or of the stream messages, between 0 (never) and 1 (always).
+type Rule struct {
+	// Delay is how long the delayed calls wait before being handled.
+	Delay Duration `json:"delay"`
+	// DelayRate is the fraction of calls delayed; it defaults to 1 when Delay
+	// is set.
+	DelayRate float64 `json:"delay_rate"`
+	// Code is the status of the failed calls; it defaults to UNAVAILABLE.
+	Code      codes.Code `json:"code"`
+	ErrorRate float64    `json:"error_rate"`
+	// DropRate is the fraction of stream messages, sent or received, that
+	// are silently dropped.
+	DropRate  float64 `json:"drop_rate"`
+	PanicRate float64 `json:"panic_rate"`
+}
+
+func (r Rule) delayRate() float64 {
+	if r.Delay > 0 && r.DelayRate == 0 {
+		return 1
+	}
+	return r.DelayRate
+}
+
+func (r Rule) code() codes.Code {
+	if r.Code == codes.OK {
+		return codes.Unavailable
+	}
+	return r.Code
+}
+
+func (r Rule) enabled() bool {
+	return r.Delay > 0 || r.ErrorRate > 0 || r.DropRate > 0 || r.PanicRate > 0
+}
+
+// ParseRule parses the x-fault metadata form of a rule: comma separated
+// key=value pairs among delay, delay-rate, code, error-rate, drop-rate and
+// panic-rate.
+func ParseRule(s string) (Rule, error) {
+	var r Rule
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		i := strings.Index(field, "=")
+		if i < 0 {
+			return Rule{}, fmt.Errorf("%q is not a key=value pair", field)
+		}
+		key, value := strings.TrimSpace(field[:i]), strings.TrimSpace(field[i+1:])
+		var err error
+		switch key {
+		case "delay":
+			var d time.Duration
+			d, err = time.ParseDuration(value)
+			r.Delay = Duration(d)
+		case "delay-rate":
+			r.DelayRate, err = parseRate(value)
+		case "code":
+			err = r.Code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(value))))
+		case "error-rate":
+			r.ErrorRate, err = parseRate(value)
+		case "drop-rate":
+			r.DropRate, err = parseRate(value)
+		case "panic-rate":
+			r.PanicRate, err = parseRate(value)
+		default:
+			return Rule{}, fmt.Errorf("unknown key %q", key)
+		}
+		if err != nil {
+			return Rule{}, fmt.Errorf("%s: %v", key, err)
+		}
+	}
+	return r, nil
+}
+
+func parseRate(s string) (float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 || v > 1 {
+		return 0, fmt.Errorf("rate %v is not between 0 and 1", v)
+	}
+	return v, nil
+}
+
+// Config holds the faults of a server.
+//
+// A config file is the JSON form of Config. Methods are full method names or
+// whole services written as "/pkg.Service/*":
+//
+//	{
+//	    "default": {"delay": "100ms", "delay_rate": 0.1},
+//	    "methods": {"/greet.GreetService/Greet": {"code": "UNAVAILABLE", "error_rate": 0.3}},
+//	    "metadata": true
+//	}
+type Config struct {
+	// Default applies to methods without an entry in Methods.
+	Default Rule            `json:"default"`
+	Methods map[string]Rule `json:"methods"`
+	// Metadata lets each call replace the configured rule with the one in
+	// its x-fault metadata. Only turn it on for servers under test.
+	Metadata bool `json:"metadata"`
+
+	file string
+}
+
+// codeValue is a flag.Value setting a status code by name.
+type codeValue struct{ code *codes.Code }
+
+func (v codeValue) String() string {
+	if v.code == nil {
+		return ""
+	}
+	return v.code.String()
+}
+
+func (v codeValue) Set(s string) error {
+	return v.code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(s))))
+}
+
+// RegisterFlags binds the fault injection options to fs.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.Var((*durationValue)(&c.Default.Delay), "fault-delay", "delay injected before handling calls")
+	fs.Float64Var(&c.Default.DelayRate, "fault-delay-rate", 0, "fraction of calls delayed (default: all of them when -fault-delay is set)")
+	fs.Var(codeValue{&c.Default.Code}, "fault-code", "status code of the injected errors (default UNAVAILABLE)")
+	fs.Float64Var(&c.Default.ErrorRate, "fault-error-rate", 0, "fraction of calls failed with -fault-code")
+	fs.Float64Var(&c.Default.DropRate, "fault-drop-rate", 0, "fraction of stream messages dropped")
+	fs.Float64Var(&c.Default.PanicRate, "fault-panic-rate", 0, "fraction of calls whose handler panics")
+	fs.BoolVar(&c.Metadata, "fault-metadata", false, "let calls choose their faults with x-fault metadata; for test servers only")
+	fs.StringVar(&c.file, "fault-config", "", "JSON file with per method faults, overriding the other fault flags")
+}
+
+// durationValue is a flag.Value setting a Duration.
+type durationValue Duration
+
+func (d *durationValue) String() string { return time.Duration(*d).String() }
+
+func (d *durationValue) Set(s string) error {
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = durationValue(v)
+	return nil
+}
+
+// Load reads the config file given on the command line, if any.
+func (c *Config) Load() error {
+	if c.file == "" {
+		return c.validate()
+	}
+	data, err := ioutil.ReadFile(c.file)
+	if err != nil {
+		return fmt.Errorf("reading fault config: %w", err)
+	}
+	loaded := Config{file: c.file}
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return fmt.Errorf("parsing fault config: %w", err)
+	}
+	if err := loaded.validate(); err != nil {
+		return err
+	}
+	*c = loaded
+	return nil
+}
+
+func (c *Config) validate() error {
+	check := func(where string, r Rule) error {
+		for _, rate := range []float64{r.DelayRate, r.ErrorRate, r.DropRate, r.PanicRate} {
+			if rate < 0 || rate > 1 {
+				return fmt.Errorf("fault config: %s: rate %v is not between 0 and 1", where, rate)
+			}
+		}
+		return nil
+	}
+	if err := check("default", c.Default); err != nil {
+		return err
+	}
+	for method, r := range c.Methods {
+		if err := check(method, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Enabled reports whether any fault is configured.
+func (c *Config) Enabled() bool {
+	if c.Metadata || c.Default.enabled() {
+		return true
+	}
+	for _, r := range c.Methods {
+		if r.enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleFor returns the rule of method; an exact entry takes precedence over a
+// service wildcard.
+func (c *Config) ruleFor(method string) Rule {
+	if r, ok := c.Methods[method]; ok {
+		return r
+	}
+	i := strings.LastIndex(method, "/")
+	if r, ok := c.Methods[method[:i+1]+"*"]; ok {
+		return r
+	}
+	return c.Default
+}
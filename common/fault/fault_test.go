@@ -0,0 +1,137 @@
+package fault
+
+import (
+	"common/grpctest"
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const checkMethod = "/grpc.health.v1.Health/Check"
+
+// newClient serves the health service behind the faults of cfg, with a
+// recovery interceptor turning panics into INTERNAL errors.
+func newClient(t *testing.T, cfg Config) healthpb.HealthClient {
+	in := New(cfg)
+	recoverUnary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = status.Errorf(codes.Internal, "panic: %v", p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+	cc := grpctest.NewServer(t, func(s *grpc.Server) {
+		h := health.NewServer()
+		h.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+		healthpb.RegisterHealthServer(s, h)
+	},
+		grpc.ChainUnaryInterceptor(recoverUnary, in.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(in.StreamServerInterceptor()),
+	)
+	return healthpb.NewHealthClient(cc)
+}
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Rule
+		wantErr bool
+	}{
+		{"", Rule{}, false},
+		{"delay=300ms, error-rate=0.5,code=not_found", Rule{Delay: Duration(300 * time.Millisecond), ErrorRate: 0.5, Code: codes.NotFound}, false},
+		{"drop-rate=1,panic-rate=0.1,delay-rate=0.2", Rule{DropRate: 1, PanicRate: 0.1, DelayRate: 0.2}, false},
+		{"error-rate=2", Rule{}, true},
+		{"code=NOPE", Rule{}, true},
+		{"delay", Rule{}, true},
+		{"jitter=1s", Rule{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRule(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRule(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRule(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestConfigRules(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		code codes.Code
+	}{
+		{"none", Config{}, codes.OK},
+		{"default", Config{Default: Rule{ErrorRate: 1}}, codes.Unavailable},
+		{"method", Config{Methods: map[string]Rule{checkMethod: {ErrorRate: 1, Code: codes.Aborted}}}, codes.Aborted},
+		{"service", Config{Methods: map[string]Rule{"/grpc.health.v1.Health/*": {ErrorRate: 1}}}, codes.Unavailable},
+		{"other method", Config{Methods: map[string]Rule{"/grpc.health.v1.Health/Watch": {ErrorRate: 1}}}, codes.OK},
+		{"panic", Config{Default: Rule{PanicRate: 1}}, codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(t, tt.cfg)
+			_, err := c.Check(context.Background(), &healthpb.HealthCheckRequest{})
+			if status.Code(err) != tt.code {
+				t.Errorf("got %v, want %v", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestDelay(t *testing.T) {
+	c := newClient(t, Config{Default: Rule{Delay: Duration(time.Second)}})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.Check(ctx, &healthpb.HealthCheckRequest{}); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("got %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   Config
+		fault string
+		code  codes.Code
+	}{
+		{"ignored", Config{}, "error-rate=1", codes.OK},
+		{"allowed", Config{Metadata: true}, "error-rate=1,code=RESOURCE_EXHAUSTED", codes.ResourceExhausted},
+		{"replaces the config", Config{Metadata: true, Default: Rule{ErrorRate: 1}}, "delay=1ms", codes.OK},
+		{"invalid", Config{Metadata: true}, "error-rate=x", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(t, tt.cfg)
+			ctx := metadata.AppendToOutgoingContext(context.Background(), MetadataKey, tt.fault)
+			_, err := c.Check(ctx, &healthpb.HealthCheckRequest{})
+			if status.Code(err) != tt.code {
+				t.Errorf("got %v, want %v", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestDropStreamMessages(t *testing.T) {
+	c := newClient(t, Config{Default: Rule{DropRate: 1}})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	stream, err := c.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the serving status sent when the watch starts is lost
+	if res, err := stream.Recv(); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Recv = %v, %v, want DeadlineExceeded", res, err)
+	}
+}
@@ -0,0 +1,148 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Injector injects the faults of a Config.
+type Injector struct {
+	cfg Config
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// New returns an Injector for cfg.
+func New(cfg Config) *Injector {
+	return &Injector{cfg: cfg, rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// roll reports whether an event of the given rate happens this time.
+func (in *Injector) roll(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	if rate >= 1 {
+		return true
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.rnd.Float64() < rate
+}
+
+// ruleFor returns the rule of a call: the one in its metadata when allowed
+// and present, the configured one otherwise.
+func (in *Injector) ruleFor(ctx context.Context, method string) (Rule, error) {
+	if in.cfg.Metadata {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if v := md.Get(MetadataKey); len(v) > 0 {
+			r, err := ParseRule(v[0])
+			if err != nil {
+				return Rule{}, status.Errorf(codes.InvalidArgument, "invalid %s metadata: %v", MetadataKey, err)
+			}
+			return r, nil
+		}
+	}
+	return in.cfg.ruleFor(method), nil
+}
+
+// inject applies the faults of r to a call of method: it panics, waits or
+// fails as the rule says. A call canceled while delayed fails with its
+// context's status.
+func (in *Injector) inject(ctx context.Context, method string, r Rule) error {
+	if in.roll(r.PanicRate) {
+		panic(fmt.Sprintf("fault injected in %s", method))
+	}
+	if r.Delay > 0 && in.roll(r.delayRate()) {
+		t := time.NewTimer(time.Duration(r.Delay))
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	if in.roll(r.ErrorRate) {
+		return status.Errorf(r.code(), "fault injected in %s", method)
+	}
+	return nil
+}
+
+// UnaryServerInterceptor injects faults into unary calls. It panics in the
+// handler's place, so it must run after any recovery interceptor.
+func (in *Injector) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		r, err := in.ruleFor(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if err := in.inject(ctx, info.FullMethod, r); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor injects faults into streams when they are opened,
+// then drops their messages at the rule's rate.
+func (in *Injector) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		r, err := in.ruleFor(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		if err := in.inject(stream.Context(), info.FullMethod, r); err != nil {
+			return err
+		}
+		if r.DropRate > 0 {
+			// only the streamed side of a call loses messages, never the
+			// single request or response of the other
+			stream = &droppingStream{
+				ServerStream: stream,
+				in:           in,
+				rate:         r.DropRate,
+				send:         info.IsServerStream,
+				recv:         info.IsClientStream,
+			}
+		}
+		return handler(srv, stream)
+	}
+}
+
+// droppingStream loses some of the messages sent and received on a stream.
+type droppingStream struct {
+	grpc.ServerStream
+	in   *Injector
+	rate float64
+	// the directions in which messages are dropped
+	send, recv bool
+}
+
+// SendMsg pretends dropped messages were sent.
+func (s *droppingStream) SendMsg(m interface{}) error {
+	if s.send && s.in.roll(s.rate) {
+		return nil
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+// RecvMsg skips dropped messages and returns the next one.
+func (s *droppingStream) RecvMsg(m interface{}) error {
+	for {
+		if err := s.ServerStream.RecvMsg(m); err != nil {
+			return err
+		}
+		if !s.recv || !s.in.roll(s.rate) {
+			return nil
+		}
+	}
+}
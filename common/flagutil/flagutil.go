@@ -0,0 +1,23 @@
+// Package flagutil holds flag.Value types shared by the command line options
+// of the services.
+package flagutil
+
+import "strings"
+
+// StringList is a flag.Value holding a comma separated list.
+type StringList []string
+
+func (l *StringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set replaces the list with the comma separated values in s.
+func (l *StringList) Set(s string) error {
+	*l = nil
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+module common
+
+go 1.15
+
+require (
+	github.com/fsnotify/fsnotify v1.4.9
+	github.com/golang-jwt/jwt/v4 v4.2.0
+	github.com/grpc-ecosystem/go-grpc-middleware v1.2.2
+	github.com/prometheus/client_golang v1.3.0
+	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc v0.25.0
+	go.opentelemetry.io/otel v1.0.1
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc v1.0.1
+	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.0.1
+	go.opentelemetry.io/otel/sdk v1.0.1
+	golang.org/x/net v0.0.0-20200822124328-c89045814202
+	golang.org/x/time v0.0.0-20201208040808-7e3f01d25324
+	google.golang.org/genproto v0.0.0-20200806141610-86f49bd18e98
+	google.golang.org/grpc v1.41.0
+	google.golang.org/grpc/examples v0.0.0-20210424002626-9572fd6faeae // indirect
+	google.golang.org/protobuf v1.27.1
+)
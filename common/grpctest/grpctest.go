@@ -0,0 +1,39 @@
+// Package grpctest runs a gRPC server in process for tests, on an in-memory
+// bufconn listener, so that calls go through the real transport, codecs and
+// interceptors without opening a port.
+package grpctest
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const bufSize = 1024 * 1024
+
+// NewServer starts a server with opts, on which register registers the
+// services under test, and returns a client connection to it. The server and
+// the connection are closed when the test ends.
+func NewServer(t testing.TB, register func(*grpc.Server), opts ...grpc.ServerOption) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(bufSize)
+	s := grpc.NewServer(opts...)
+	register(s)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	cc, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatalf("dialing the test server: %v", err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return cc
+}
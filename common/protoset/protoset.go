@@ -0,0 +1,142 @@
+// Package protoset downloads the descriptors of a service from a server with
+// reflection enabled, as a FileDescriptorSet that tools such as grpcurl,
+// Evans and buf read with their protoset options.
+package protoset
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Fetch returns the file defining service, such as "greet.GreetService", and
+// all the files it imports. Dependencies come before the files importing
+// them, like protoc --include_imports writes them.
+func Fetch(ctx context.Context, cc *grpc.ClientConn, service string) (*descriptorpb.FileDescriptorSet, error) {
+	stream, err := rpb.NewServerReflectionClient(cc).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.CloseSend()
+
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+	// ask asks for the files matching req and keeps those not seen yet;
+	// the server may send the dependencies along
+	ask := func(req *rpb.ServerReflectionRequest) ([]*descriptorpb.FileDescriptorProto, error) {
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			if status.Code(err) == codes.Unimplemented {
+				return nil, fmt.Errorf("the server does not have reflection enabled: %w", err)
+			}
+			return nil, err
+		}
+		if e := res.GetErrorResponse(); e != nil {
+			return nil, status.Error(codes.Code(e.GetErrorCode()), e.GetErrorMessage())
+		}
+		var added []*descriptorpb.FileDescriptorProto
+		for _, raw := range res.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(raw, fd); err != nil {
+				return nil, fmt.Errorf("parsing file descriptor: %w", err)
+			}
+			if _, ok := files[fd.GetName()]; !ok {
+				files[fd.GetName()] = fd
+				added = append(added, fd)
+			}
+		}
+		return added, nil
+	}
+
+	pending, err := ask(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("looking up %s: %w", service, err)
+	}
+	if len(pending) == 0 {
+		return nil, fmt.Errorf("looking up %s: no file returned", service)
+	}
+	root := pending[0].GetName()
+	// fetch the imports the server did not send along
+	for len(pending) > 0 {
+		fd := pending[0]
+		pending = pending[1:]
+		for _, dep := range fd.GetDependency() {
+			if _, ok := files[dep]; ok {
+				continue
+			}
+			added, err := ask(&rpb.ServerReflectionRequest{
+				MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("looking up %s: %w", dep, err)
+			}
+			pending = append(pending, added...)
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var add func(name string) error
+	add = func(name string) error {
+		if seen[name] {
+			return nil
+		}
+		seen[name] = true
+		fd, ok := files[name]
+		if !ok {
+			return fmt.Errorf("missing file %s", name)
+		}
+		for _, dep := range fd.GetDependency() {
+			if err := add(dep); err != nil {
+				return err
+			}
+		}
+		set.File = append(set.File, fd)
+		return nil
+	}
+	if err := add(root); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// Write saves set to path in the binary protobuf format.
+func Write(path string, set *descriptorpb.FileDescriptorSet) error {
+	data, err := proto.Marshal(set)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// Describe fetches the descriptors of service from cc and saves them to
+// path, such as greet.protoset for "greet.GreetService" when empty. It
+// returns the path written.
+func Describe(cc *grpc.ClientConn, service, path string) (string, error) {
+	if path == "" {
+		path = service[:strings.LastIndex(service, ".")+1] + "protoset"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	set, err := Fetch(ctx, cc, service)
+	if err != nil {
+		return "", fmt.Errorf("describing %s: %w", service, err)
+	}
+	if err := Write(path, set); err != nil {
+		return "", err
+	}
+	return path, nil
+}
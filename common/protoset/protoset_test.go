@@ -0,0 +1,45 @@
+package protoset
+
+import (
+	"common/grpctest"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestDescribe(t *testing.T) {
+	cc := grpctest.NewServer(t, func(s *grpc.Server) {
+		healthpb.RegisterHealthServer(s, health.NewServer())
+		reflection.Register(s)
+	})
+	path := filepath.Join(t.TempDir(), "health.protoset")
+	got, err := Describe(cc, "grpc.health.v1.Health", path)
+	if err != nil {
+		t.Fatalf("Describe: %v", err)
+	}
+	if got != path {
+		t.Errorf("Describe wrote %s, want %s", got, path)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		t.Fatalf("reading the protoset: %v", err)
+	}
+	if n := len(set.File); n != 1 || set.File[0].GetName() != "grpc/health/v1/health.proto" {
+		t.Errorf("got %d files, want grpc/health/v1/health.proto", n)
+	}
+
+	if _, err := Describe(cc, "nope.Service", path); err == nil {
+		t.Error("Describe of an unknown service succeeded")
+	}
+}
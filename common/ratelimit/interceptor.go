@@ -0,0 +1,160 @@
+package ratelimit
+
+import (
+	"common/auth"
+	"common/tlsutil"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Caller identifies who is calling: the subject of the JWT when the call is
+// authenticated, otherwise the client certificate subject, otherwise the
+// peer's IP address.
+func Caller(ctx context.Context) string {
+	if claims, ok := auth.FromContext(ctx); ok {
+		return "sub:" + claims.Subject
+	}
+	if id, ok := tlsutil.IdentityFromContext(ctx); ok {
+		return "cert:" + id.Subject
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		addr := p.Addr.String()
+		// all connections from one host share a bucket
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+		return "addr:" + addr
+	}
+	return "unknown"
+}
+
+// ForwardedForKey is the metadata key in which proxies pass on the address
+// of the client they relay, as in the X-Forwarded-For HTTP header: a comma
+// separated list to which every proxy appends the address it got the call
+// from. The gRPC gateway sets it.
+const ForwardedForKey = "x-forwarded-for"
+
+// caller is Caller, except that calls relayed by a proxy are told apart by
+// the address they were forwarded for: the last one in ForwardedForKey that
+// is not a trusted proxy itself. Earlier addresses were set by the client and
+// may be forged. Relayed calls are those that come through a ProxyListener or
+// from a trusted proxy.
+func (l *Limiter) caller(ctx context.Context) string {
+	c := Caller(ctx)
+	if !strings.HasPrefix(c, "addr:") {
+		return c
+	}
+	p, _ := peer.FromContext(ctx)
+	if _, ok := p.Addr.(proxyAddr); !ok && !l.trusted(net.ParseIP(c[len("addr:"):])) {
+		return c
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	var hops []string
+	for _, v := range md.Get(ForwardedForKey) {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			break
+		}
+		if !l.trusted(ip) {
+			return "addr:" + ip.String()
+		}
+	}
+	return c
+}
+
+func (l *Limiter) trusted(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, n := range l.proxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func exhausted(msg string, retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, msg)
+	if retryAfter <= 0 {
+		return st.Err()
+	}
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// check takes the tokens of a call, or of the calls req stands for when it
+// is not nil, from the buckets of the caller, all of them or none.
+func (l *Limiter) check(ctx context.Context, method string, req interface{}) error {
+	calls := map[string]int{method: 1}
+	if l.cfg.Calls != nil && req != nil {
+		if c := l.cfg.Calls(method, req); c != nil {
+			calls = c
+		}
+	}
+	caller := l.caller(ctx)
+	now := l.now()
+	var taken []func()
+	for m, n := range calls {
+		ok, retryAfter, cancel := l.reserve(m, caller, n, now)
+		if ok {
+			taken = append(taken, cancel)
+			continue
+		}
+		for _, cancel := range taken {
+			cancel()
+		}
+		if retryAfter <= 0 {
+			return exhausted(fmt.Sprintf("rate limit exceeded for %s: %d calls at once is more than its burst", m, n), 0)
+		}
+		return exhausted("rate limit exceeded for "+m+", retry in "+retryAfter.Round(time.Millisecond).String(), retryAfter)
+	}
+	return nil
+}
+
+// UnaryServerInterceptor rejects calls beyond the caller's rate limit.
+// It must run after authentication so that callers are told apart by identity.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.check(ctx, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects streams beyond the caller's rate limit or
+// concurrent stream cap. Each stream takes one token when it is opened.
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := stream.Context()
+		if err := l.check(ctx, info.FullMethod, nil); err != nil {
+			return err
+		}
+		release, ok := l.acquireStream(l.caller(ctx))
+		if !ok {
+			return exhausted("too many concurrent streams", 0)
+		}
+		defer release()
+		return handler(srv, stream)
+	}
+}
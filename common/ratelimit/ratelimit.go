@@ -0,0 +1,267 @@
+// Package ratelimit protects servers from callers that send too many requests.
+//
+// Every caller gets a token bucket per method. A call that finds its bucket
+// empty fails with RESOURCE_EXHAUSTED and a RetryInfo detail telling the
+// client when the next token is available. The number of streams a caller may
+// keep open at once can be capped as well.
+//
+// Calls relayed by a proxy, a REST gateway serving from a ProxyListener or a
+// trusted proxy, are counted against the client address the proxy forwards
+// in the x-forwarded-for metadata rather than against the proxy's.
+package ratelimit
+
+import (
+	"common/flagutil"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Limit configures a token bucket.
+type Limit struct {
+	// Rate is the number of calls per second added to the bucket.
+	// Zero means unlimited.
+	Rate float64 `json:"rate"`
+	// Burst is the size of the bucket; it defaults to Rate rounded up.
+	Burst int `json:"burst"`
+}
+
+func (l Limit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return int(math.Max(1, math.Ceil(l.Rate)))
+}
+
+// Config holds the limits of a server.
+//
+// A config file is the JSON form of Config. Methods are full method names or
+// whole services written as "/pkg.Service/*":
+//
+//	{
+//	    "default": {"rate": 10, "burst": 20},
+//	    "methods": {"/greet.GreetService/GreetWithDeadline": {"rate": 1}},
+//	    "max_streams_per_client": 4,
+//	    "trusted_proxies": ["10.0.0.0/8"]
+//	}
+type Config struct {
+	// Default applies to methods without an entry in Methods.
+	Default Limit            `json:"default"`
+	Methods map[string]Limit `json:"methods"`
+	// MaxStreamsPerClient caps the concurrent streams of a caller; 0 means no cap.
+	MaxStreamsPerClient int `json:"max_streams_per_client"`
+	// TrustedProxies are the IP addresses or CIDR ranges of the proxies
+	// whose x-forwarded-for metadata is believed.
+	TrustedProxies []string `json:"trusted_proxies"`
+	// Calls, when set, tells which calls a unary request stands for, as a
+	// number of calls by full method name, each charged against the limit
+	// of its method; nil means a single call of the request's own method. A
+	// batch, for instance, can be charged for every operation it carries.
+	Calls func(method string, req interface{}) map[string]int `json:"-"`
+
+	file string
+}
+
+// RegisterFlags binds the rate limiting options to fs.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.Float64Var(&c.Default.Rate, "ratelimit-rate", 0, "calls per second allowed per caller and method; 0 disables rate limiting")
+	fs.IntVar(&c.Default.Burst, "ratelimit-burst", 0, "token bucket size per caller and method (default: the rate)")
+	fs.IntVar(&c.MaxStreamsPerClient, "ratelimit-max-streams", 0, "concurrent streams allowed per caller; 0 means no cap")
+	fs.Var((*flagutil.StringList)(&c.TrustedProxies), "ratelimit-trusted-proxies", "comma separated IP addresses or CIDR ranges of the proxies whose x-forwarded-for metadata is believed")
+	fs.StringVar(&c.file, "ratelimit-config", "", "JSON file with per method limits, overriding the other ratelimit flags")
+}
+
+// Load reads the config file given on the command line, if any, and checks
+// the trusted proxies.
+func (c *Config) Load() error {
+	if c.file != "" {
+		data, err := ioutil.ReadFile(c.file)
+		if err != nil {
+			return fmt.Errorf("reading rate limit config: %w", err)
+		}
+		loaded := Config{file: c.file}
+		if err := json.Unmarshal(data, &loaded); err != nil {
+			return fmt.Errorf("parsing rate limit config: %w", err)
+		}
+		*c = loaded
+	}
+	if _, err := parseProxies(c.TrustedProxies); err != nil {
+		return fmt.Errorf("rate limit config: %w", err)
+	}
+	return nil
+}
+
+// parseProxies parses IP addresses and CIDR ranges; an address is a range of
+// its own. It returns those that parse along with the first error.
+func parseProxies(proxies []string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	var firstErr error
+	for _, p := range proxies {
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip != nil {
+				bits := 8 * net.IPv6len
+				if ip4 := ip.To4(); ip4 != nil {
+					ip, bits = ip4, 8*net.IPv4len
+				}
+				nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			} else if firstErr == nil {
+				firstErr = fmt.Errorf("trusted proxy %q is not an IP address", p)
+			}
+			continue
+		}
+		if _, n, err := net.ParseCIDR(p); err == nil {
+			nets = append(nets, n)
+		} else if firstErr == nil {
+			firstErr = fmt.Errorf("trusted proxy %q: %w", p, err)
+		}
+	}
+	return nets, firstErr
+}
+
+// Enabled reports whether any limit is configured.
+func (c *Config) Enabled() bool {
+	if c.Default.Rate > 0 || c.MaxStreamsPerClient > 0 {
+		return true
+	}
+	for _, l := range c.Methods {
+		if l.Rate > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// limitFor returns the limit of method; an exact entry takes precedence over
+// a service wildcard.
+func (c *Config) limitFor(method string) Limit {
+	if l, ok := c.Methods[method]; ok {
+		return l
+	}
+	i := strings.LastIndex(method, "/")
+	if l, ok := c.Methods[method[:i+1]+"*"]; ok {
+		return l
+	}
+	return c.Default
+}
+
+// idleTimeout is how long a bucket may stay unused before it is forgotten;
+// buckets that take longer than this to refill are kept until they are full.
+const idleTimeout = 10 * time.Minute
+
+type bucketKey struct {
+	method string
+	caller string
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+	// refill is the time an empty bucket takes to fill up again
+	refill time.Duration
+}
+
+// Limiter enforces a Config.
+type Limiter struct {
+	cfg     Config
+	proxies []*net.IPNet
+	now     func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+	streams   map[string]int
+}
+
+// New returns a Limiter enforcing cfg. Trusted proxies that do not parse,
+// which Load reports, are ignored.
+func New(cfg Config) *Limiter {
+	proxies, _ := parseProxies(cfg.TrustedProxies)
+	return &Limiter{
+		cfg:     cfg,
+		proxies: proxies,
+		now:     time.Now,
+		buckets: make(map[bucketKey]*bucket),
+		streams: make(map[string]int),
+	}
+}
+
+// reserve takes n tokens from the bucket of caller for method. When the
+// bucket does not hold them it returns how long the caller has to wait
+// instead, or 0 when n is more than the bucket holds at all. The returned
+// function gives the tokens back.
+func (l *Limiter) reserve(method, caller string, n int, now time.Time) (ok bool, retryAfter time.Duration, cancel func()) {
+	limit := l.cfg.limitFor(method)
+	if limit.Rate <= 0 {
+		return true, 0, func() {}
+	}
+
+	l.mu.Lock()
+	l.sweep(now)
+	key := bucketKey{method: method, caller: caller}
+	b, found := l.buckets[key]
+	if !found {
+		b = &bucket{
+			limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.burst()),
+			refill:  time.Duration(float64(limit.burst()) / limit.Rate * float64(time.Second)),
+		}
+		l.buckets[key] = b
+	}
+	b.lastUsed = now
+	l.mu.Unlock()
+
+	r := b.limiter.ReserveN(now, n)
+	if !r.OK() {
+		return false, 0, nil
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		// give the tokens back, the call is rejected rather than delayed
+		r.CancelAt(now)
+		return false, delay, nil
+	}
+	return true, 0, func() { r.CancelAt(now) }
+}
+
+// sweep forgets buckets that have not been used for a while, so that the
+// memory used does not grow with every caller ever seen. It must be called
+// with l.mu held.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleTimeout {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		idle := now.Sub(b.lastUsed)
+		if idle > idleTimeout && idle > b.refill {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// acquireStream counts a new stream of caller, unless the caller already has
+// the maximum number of streams open. The returned function releases it.
+func (l *Limiter) acquireStream(caller string) (release func(), ok bool) {
+	if l.cfg.MaxStreamsPerClient <= 0 {
+		return func() {}, true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.streams[caller] >= l.cfg.MaxStreamsPerClient {
+		return nil, false
+	}
+	l.streams[caller]++
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.streams[caller]--; l.streams[caller] == 0 {
+			delete(l.streams, caller)
+		}
+	}, true
+}
@@ -0,0 +1,156 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// callFrom returns the context of a call from addr carrying the given
+// x-forwarded-for values.
+func callFrom(addr string, forwardedFor ...string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 40000},
+	})
+	if len(forwardedFor) > 0 {
+		md := metadata.MD{}
+		md.Append(ForwardedForKey, forwardedFor...)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	return ctx
+}
+
+// callThroughProxy returns the context of a call relayed through a
+// ProxyListener with the given x-forwarded-for values.
+func callThroughProxy(forwardedFor ...string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: proxyAddr{}})
+	if len(forwardedFor) > 0 {
+		md := metadata.MD{}
+		md.Append(ForwardedForKey, forwardedFor...)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	return ctx
+}
+
+func TestCallerBehindTrustedProxies(t *testing.T) {
+	l := New(Config{TrustedProxies: []string{"192.0.2.1", "2001:db8::53", "10.0.0.0/8"}})
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"direct", callFrom("203.0.113.7"), "addr:203.0.113.7"},
+		{"untrusted peer", callFrom("203.0.113.7", "198.51.100.1"), "addr:203.0.113.7"},
+		{"localhost", callFrom("127.0.0.1", "198.51.100.1"), "addr:127.0.0.1"},
+		{"trusted proxy", callFrom("192.0.2.1", "198.51.100.1"), "addr:198.51.100.1"},
+		{"ipv6 proxy", callFrom("2001:db8::53", "2001:db8::1"), "addr:2001:db8::1"},
+		{"forged hop", callFrom("192.0.2.1", "192.0.2.66, 198.51.100.1"), "addr:198.51.100.1"},
+		{"proxy chain", callFrom("10.0.0.1", "198.51.100.1, 10.1.2.3"), "addr:198.51.100.1"},
+		{"split values", callFrom("192.0.2.1", "198.51.100.1", "10.1.2.3"), "addr:198.51.100.1"},
+		{"no forwarded address", callFrom("192.0.2.1"), "addr:192.0.2.1"},
+		{"only proxies", callFrom("192.0.2.1", "10.1.2.3"), "addr:192.0.2.1"},
+		{"garbage", callFrom("192.0.2.1", "unknown"), "addr:192.0.2.1"},
+		{"gateway", callThroughProxy("198.51.100.1"), "addr:198.51.100.1"},
+		{"gateway forged hop", callThroughProxy("192.0.2.66, 198.51.100.1"), "addr:198.51.100.1"},
+		{"gateway behind a proxy", callThroughProxy("198.51.100.1, 10.1.2.3"), "addr:198.51.100.1"},
+		{"gateway without forwarded address", callThroughProxy(), "addr:proxy"},
+	}
+	for _, tt := range tests {
+		if got := l.caller(tt.ctx); got != tt.want {
+			t.Errorf("%s: caller = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	untrusting := New(Config{})
+	if got := untrusting.caller(callFrom("127.0.0.1", "198.51.100.1")); got != "addr:127.0.0.1" {
+		t.Errorf("without trusted proxies, caller = %q, want the peer address", got)
+	}
+	if got := untrusting.caller(callThroughProxy("198.51.100.1")); got != "addr:198.51.100.1" {
+		t.Errorf("through a proxy listener without trusted proxies, caller = %q, want the forwarded address", got)
+	}
+}
+
+func TestGatewayClientsHaveTheirOwnBuckets(t *testing.T) {
+	l := New(Config{Default: Limit{Rate: 1}})
+	const method = "/blog.BlogService/ListBlogPage"
+	if err := l.check(callThroughProxy("198.51.100.1"), method, nil); err != nil {
+		t.Fatalf("first call of a client: %v", err)
+	}
+	if err := l.check(callThroughProxy("198.51.100.1"), method, nil); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second call of a client: got %v, want ResourceExhausted", err)
+	}
+	if err := l.check(callThroughProxy("198.51.100.2"), method, nil); err != nil {
+		t.Errorf("another client through the same gateway: %v", err)
+	}
+	// a local client forging the address of another does not get its bucket
+	if err := l.check(callFrom("127.0.0.1", "198.51.100.3"), method, nil); err != nil {
+		t.Fatalf("first call of a local client: %v", err)
+	}
+	if err := l.check(callFrom("127.0.0.1", "198.51.100.4"), method, nil); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("second call of a local client forging its address: got %v, want ResourceExhausted", err)
+	}
+}
+
+func TestLoadTrustedProxies(t *testing.T) {
+	for _, proxies := range [][]string{{"127.0.0.1"}, {"::1", "10.0.0.0/8"}, nil} {
+		c := Config{TrustedProxies: proxies}
+		if err := c.Load(); err != nil {
+			t.Errorf("Load with trusted proxies %q: %v", proxies, err)
+		}
+	}
+	for _, proxies := range [][]string{{"localhost"}, {"10.0.0.0/33"}, {"127.0.0.1", ""}} {
+		c := Config{TrustedProxies: proxies}
+		if err := c.Load(); err == nil {
+			t.Errorf("Load with trusted proxies %q succeeded, want an error", proxies)
+		}
+	}
+}
+
+func TestBatchCalls(t *testing.T) {
+	l := New(Config{
+		Methods: map[string]Limit{
+			"/pkg.Service/A": {Rate: 0.001, Burst: 3},
+			"/pkg.Service/B": {Rate: 0.001, Burst: 2},
+		},
+		// a batch request is the number of calls of B it carries, and two
+		// calls of A
+		Calls: func(method string, req interface{}) map[string]int {
+			if method != "/pkg.Service/Batch" {
+				return nil
+			}
+			return map[string]int{method: 1, "/pkg.Service/A": 2, "/pkg.Service/B": req.(int)}
+		},
+	})
+	ctx := callFrom("203.0.113.7")
+	const batch, a, b = "/pkg.Service/Batch", "/pkg.Service/A", "/pkg.Service/B"
+
+	err := l.check(ctx, batch, 3)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("batch beyond the burst of B: got %v, want ResourceExhausted", err)
+	}
+	if d := status.Convert(err).Details(); len(d) != 0 {
+		t.Errorf("batch beyond the burst of B: got details %v, want no RetryInfo", d)
+	}
+	// the rejected batch took nothing from A
+	for i := 0; i < 3; i++ {
+		if err := l.check(ctx, a, "call"); err != nil {
+			t.Fatalf("call %d of A: %v", i, err)
+		}
+	}
+	if err := l.check(ctx, a, "call"); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("fourth call of A: got %v, want ResourceExhausted", err)
+	}
+
+	// A is exhausted, so is a batch, which has to take from A as well
+	if err := l.check(ctx, batch, 1); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("batch with A exhausted: got %v, want ResourceExhausted", err)
+	}
+	if err := l.check(ctx, b, "call"); err != nil {
+		t.Fatalf("call of B after a rejected batch: %v", err)
+	}
+}
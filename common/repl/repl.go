@@ -0,0 +1,232 @@
+// Package repl drives a bidirectional stream from a terminal: every line read
+// is sent as a message, and responses are printed as they arrive.
+//
+// Lines starting with a slash are commands:
+//
+//	/close   half-close the stream; the server sees the end of the requests
+//	/cancel  cancel the call
+//	/status  show the message counts, the stream state, headers and trailers
+//	/help    list the commands
+//
+// The end of the input half-closes the stream. Run returns once the server
+// has ended the stream, after printing its status and trailers.
+package repl
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// Session is an interactive bidirectional stream.
+type Session struct {
+	Stream grpc.ClientStream
+	// Cancel cancels the context of Stream.
+	Cancel context.CancelFunc
+	// NewRequest and NewResponse return empty messages of the method's types.
+	NewRequest  func() proto.Message
+	NewResponse func() proto.Message
+	// Parse fills req from a line typed by the user. Lines starting with "{"
+	// are always read as the JSON form of the request. Without Parse, every
+	// line has to be JSON.
+	Parse func(line string, req proto.Message) error
+	// Format returns how a response is printed; by default in the text format.
+	Format func(res proto.Message) string
+	// Out receives the responses and the command outputs.
+	Out io.Writer
+
+	// sendMu serializes the sends and the half-close. mu guards the state
+	// printed by /status, and is never held while sending, so that a send
+	// blocked by flow control does not keep responses from being received.
+	// halfClosed is written with both held.
+	sendMu     sync.Mutex
+	mu         sync.Mutex
+	sent       int
+	received   int
+	halfClosed bool
+	header     metadata.MD
+	ended      bool
+	err        error // final status of the stream once ended
+	done       chan struct{}
+}
+
+// Run reads lines from in until the stream ends.
+func (s *Session) Run(in io.Reader) error {
+	s.done = make(chan struct{})
+	go s.receive()
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	s.printf("Type a message per line, or /help for the commands\n")
+	input := lines
+	for {
+		select {
+		case <-s.done:
+			s.printStatus()
+			return s.err
+		case line, ok := <-input:
+			if !ok {
+				// stop reading, and let the server finish the stream
+				input = nil
+				s.closeSend()
+				continue
+			}
+			s.handle(strings.TrimSpace(line))
+		}
+	}
+}
+
+func (s *Session) handle(line string) {
+	switch line {
+	case "":
+		return
+	case "/close":
+		s.closeSend()
+		return
+	case "/cancel":
+		s.Cancel()
+		return
+	case "/status":
+		s.printStatus()
+		return
+	case "/help":
+		s.printf("/close   half-close the stream\n/cancel  cancel the call\n/status  show the stream state, headers and trailers\n")
+		return
+	}
+	if strings.HasPrefix(line, "/") {
+		s.printf("Unknown command %s, try /help\n", line)
+		return
+	}
+
+	req := s.NewRequest()
+	var err error
+	switch {
+	case strings.HasPrefix(line, "{"):
+		err = protojson.Unmarshal([]byte(line), req)
+	case s.Parse != nil:
+		err = s.Parse(line, req)
+	default:
+		err = errors.New("expected a JSON object")
+	}
+	if err != nil {
+		s.printf("Invalid message: %v\n", err)
+		return
+	}
+
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if s.halfClosed {
+		s.printf("The stream is half-closed, no more messages can be sent\n")
+		return
+	}
+	// a failed send means the stream is over; receive reports why
+	if err := s.Stream.SendMsg(req); err == nil {
+		s.mu.Lock()
+		s.sent++
+		s.mu.Unlock()
+	}
+}
+
+func (s *Session) closeSend() {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if s.halfClosed {
+		return
+	}
+	s.mu.Lock()
+	s.halfClosed = true
+	s.mu.Unlock()
+	s.Stream.CloseSend()
+	s.printf("Half-closed the stream\n")
+}
+
+func (s *Session) receive() {
+	defer close(s.done)
+	// Header blocks until the server sends the headers or ends the stream
+	header, _ := s.Stream.Header()
+	s.mu.Lock()
+	s.header = header
+	s.mu.Unlock()
+	for {
+		res := s.NewResponse()
+		err := s.Stream.RecvMsg(res)
+		s.mu.Lock()
+		if err != nil {
+			if err != io.EOF {
+				s.err = err
+			}
+			s.ended = true
+			s.mu.Unlock()
+			return
+		}
+		s.received++
+		s.mu.Unlock()
+		s.printf("< %s\n", s.format(res))
+	}
+}
+
+func (s *Session) format(res proto.Message) string {
+	if s.Format != nil {
+		return s.Format(res)
+	}
+	return prototext.MarshalOptions{}.Format(res)
+}
+
+func (s *Session) printStatus() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := "open"
+	switch {
+	case s.ended:
+		st := status.Convert(s.err)
+		state = fmt.Sprintf("ended with %v", st.Code())
+		if st.Message() != "" {
+			state += ": " + st.Message()
+		}
+	case s.halfClosed:
+		state = "half-closed"
+	}
+	fmt.Fprintf(s.Out, "Stream %s, %d sent, %d received\n", state, s.sent, s.received)
+	printMD(s.Out, "Header", s.header)
+	if s.ended {
+		printMD(s.Out, "Trailer", s.Stream.Trailer())
+	}
+}
+
+func (s *Session) printf(format string, args ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintf(s.Out, format, args...)
+}
+
+func printMD(w io.Writer, name string, md metadata.MD) {
+	keys := make([]string, 0, len(md))
+	for k := range md {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range md[k] {
+			fmt.Fprintf(w, "  %s %s: %s\n", name, k, v)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package repl
+
+import (
+	"bytes"
+	"common/grpctest"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	testpb "google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// echoServer echoes the messages of FullDuplexCall. With stall, it waits
+// before echoing the first message, and then stops reading.
+type echoServer struct {
+	testpb.UnimplementedTestServiceServer
+	stall time.Duration
+}
+
+func (e *echoServer) FullDuplexCall(stream testpb.TestService_FullDuplexCallServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			stream.SetTrailer(metadata.Pairs("echoed", "true"))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		time.Sleep(e.stall)
+		if err := stream.Send(&testpb.StreamingOutputCallResponse{Payload: req.GetPayload()}); err != nil {
+			return err
+		}
+		if e.stall > 0 {
+			<-stream.Context().Done()
+			return stream.Context().Err()
+		}
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// newSession opens a FullDuplexCall on srv whose lines are sent as payloads.
+// The server's receive window is kept small, so that sends block soon when
+// it does not read.
+func newSession(t *testing.T, srv *echoServer) (*Session, *syncBuffer) {
+	cc := grpctest.NewServer(t, func(s *grpc.Server) {
+		testpb.RegisterTestServiceServer(s, srv)
+	}, grpc.InitialWindowSize(1<<16), grpc.InitialConnWindowSize(1<<16))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	stream, err := testpb.NewTestServiceClient(cc).FullDuplexCall(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := &syncBuffer{}
+	return &Session{
+		Stream:      stream,
+		Cancel:      cancel,
+		NewRequest:  func() proto.Message { return &testpb.StreamingOutputCallRequest{} },
+		NewResponse: func() proto.Message { return &testpb.StreamingOutputCallResponse{} },
+		Parse: func(line string, req proto.Message) error {
+			req.(*testpb.StreamingOutputCallRequest).Payload = &testpb.Payload{Body: []byte(line)}
+			return nil
+		},
+		Format: func(res proto.Message) string {
+			return string(res.(*testpb.StreamingOutputCallResponse).GetPayload().GetBody())
+		},
+		Out: out,
+	}, out
+}
+
+func TestSession(t *testing.T) {
+	s, out := newSession(t, &echoServer{})
+	in := "hello\n\n{\"payload\": {\"body\": \"d29ybGQ=\"}}\n/nope\n/close\n"
+	if err := s.Run(strings.NewReader(in)); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	for _, want := range []string{
+		"< hello\n",
+		"< world\n",
+		"Unknown command /nope",
+		"Half-closed the stream\n",
+		"Stream ended with OK, 2 sent, 2 received\n",
+		"  Trailer echoed: true\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output %q does not contain %q", out.String(), want)
+		}
+	}
+}
+
+// TestSessionReceivesWhileSendIsBlocked checks that responses are still
+// received while a send waits for the server to read.
+func TestSessionReceivesWhileSendIsBlocked(t *testing.T) {
+	s, out := newSession(t, &echoServer{stall: 200 * time.Millisecond})
+	// the first line is echoed once the following ones have filled the
+	// server's receive window
+	in := "first\n" + strings.Repeat(strings.Repeat("x", 32<<10)+"\n", 16)
+	result := make(chan error, 1)
+	go func() { result <- s.Run(strings.NewReader(in)) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(out.String(), "< first\n") {
+		if time.Now().After(deadline) {
+			t.Fatalf("no response received while sending, output %q", out.String())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	s.Cancel()
+	select {
+	case err := <-result:
+		if status.Code(err) != codes.Canceled {
+			t.Errorf("Run after cancel: got %v, want Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
@@ -0,0 +1,109 @@
+package tlsutil
+
+import (
+	"context"
+	"crypto/x509"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Identity describes the client certificate presented over mutual TLS.
+type Identity struct {
+	// Subject is the distinguished name, e.g. "CN=greet-client,O=Ming".
+	Subject        string
+	CommonName     string
+	DNSNames       []string
+	EmailAddresses []string
+	URIs           []string
+}
+
+// Names returns the common name followed by all SANs.
+func (id *Identity) Names() []string {
+	var names []string
+	if id.CommonName != "" {
+		names = append(names, id.CommonName)
+	}
+	names = append(names, id.DNSNames...)
+	names = append(names, id.EmailAddresses...)
+	return append(names, id.URIs...)
+}
+
+func (id *Identity) String() string {
+	return id.Subject
+}
+
+// IdentityFromContext returns the verified client certificate identity of the
+// caller. It reports false for plaintext connections and for TLS connections
+// without a client certificate.
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil, false
+	}
+	// only trust certificates that were verified against the client CA
+	chains := info.State.VerifiedChains
+	if len(chains) == 0 || len(chains[0]) == 0 {
+		return nil, false
+	}
+	return newIdentity(chains[0][0]), true
+}
+
+func newIdentity(cert *x509.Certificate) *Identity {
+	id := &Identity{
+		Subject:        cert.Subject.String(),
+		CommonName:     cert.Subject.CommonName,
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+	}
+	for _, u := range cert.URIs {
+		id.URIs = append(id.URIs, u.String())
+	}
+	return id
+}
+
+// AllowList admits only mutual TLS callers whose certificate carries one of
+// the listed names as its common name or as a SAN.
+type AllowList []string
+
+func (l AllowList) check(ctx context.Context) error {
+	id, ok := IdentityFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "a client certificate is required")
+	}
+	for _, name := range id.Names() {
+		for _, allowed := range l {
+			if name == allowed {
+				return nil
+			}
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "client %q is not allowed", id.Subject)
+}
+
+// UnaryServerInterceptor rejects unary calls from clients not in the list.
+func (l AllowList) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.check(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects streams from clients not in the list.
+func (l AllowList) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.check(stream.Context()); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,200 @@
+package tlsutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/credentials"
+)
+
+// reloadDelay lets a burst of file events, such as a key and a certificate
+// being written one after the other, settle into a single reload.
+const reloadDelay = 500 * time.Millisecond
+
+// Reloader serves the server key pair and client CA bundle named in a
+// ServerConfig and reloads them when the files change or the process receives
+// SIGHUP. Only new handshakes see the reloaded files, so established
+// connections and their streams are not interrupted.
+type Reloader struct {
+	cfg ServerConfig
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	leaf     *x509.Certificate
+	clientCA *x509.CertPool
+}
+
+// NewReloader loads the files named in cfg.
+func NewReloader(cfg ServerConfig) (*Reloader, error) {
+	if !cfg.MutualTLS() && len(cfg.AllowedClients) > 0 {
+		return nil, errors.New("allowed clients need a client CA")
+	}
+	r := &Reloader{cfg: cfg}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the key pair and client CA bundle again. When a file cannot be
+// loaded, the previous ones stay in use.
+func (r *Reloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("loading server key pair: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("parsing server certificate: %w", err)
+	}
+	var pool *x509.CertPool
+	if r.cfg.MutualTLS() {
+		if pool, err = loadCertPool(r.cfg.ClientCAFile); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	r.cert, r.leaf, r.clientCA = &cert, leaf, pool
+	r.mu.Unlock()
+
+	log.Printf("Loaded TLS certificate %q, expires %v (%.0f days left)",
+		leaf.Subject.CommonName, leaf.NotAfter.Format(time.RFC3339), daysUntil(leaf.NotAfter))
+	return nil
+}
+
+func daysUntil(t time.Time) float64 {
+	return time.Until(t).Hours() / 24
+}
+
+func (r *Reloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// TLSConfig returns a server configuration that picks up the current key pair
+// and client CA bundle on every handshake.
+func (r *Reloader) TLSConfig() *tls.Config {
+	base := &tls.Config{
+		GetCertificate: r.getCertificate,
+		MinVersion:     tls.VersionTLS12,
+		// HTTP/1.1 for browsers when serving gRPC-Web next to gRPC
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+	if !r.cfg.MutualTLS() {
+		return base
+	}
+	base.ClientAuth = tls.RequireAndVerifyClientCert
+	return &tls.Config{
+		// the CA pool cannot be swapped on a shared config,
+		// so every handshake gets a copy holding the current one
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			c := base.Clone()
+			c.ClientCAs = r.clientCA
+			return c, nil
+		},
+		NextProtos: base.NextProtos,
+	}
+}
+
+// ServerCredentials returns gRPC transport credentials backed by TLSConfig.
+func (r *Reloader) ServerCredentials() credentials.TransportCredentials {
+	return credentials.NewTLS(r.TLSConfig())
+}
+
+// Watch reloads the files whenever they change on disk or the process gets
+// SIGHUP, until ctx is done. The directories holding the files are watched
+// rather than the files themselves, so that files replaced by a rename, as
+// editors and Kubernetes secret volumes do, are noticed too. When they cannot
+// be watched, the files are still reloaded on SIGHUP.
+func (r *Reloader) Watch(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	// the channels of the watcher stay nil, and never ready, without one
+	var events <-chan fsnotify.Event
+	var errs <-chan error
+	watcher, err := r.watchFiles()
+	if err != nil {
+		log.Printf("TLS files will only be reloaded on SIGHUP: %v", err)
+	} else {
+		defer watcher.Close()
+		events, errs = watcher.Events, watcher.Errors
+	}
+
+	reload := func() {
+		if err := r.Reload(); err != nil {
+			log.Printf("Failed reloading TLS files, keeping the previous ones: %v", err)
+		}
+	}
+	timer := time.NewTimer(reloadDelay)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			log.Println("Received SIGHUP, reloading TLS files")
+			reload()
+		case ev := <-events:
+			if ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) != 0 {
+				timer.Reset(reloadDelay)
+			}
+		case <-timer.C:
+			reload()
+		case err := <-errs:
+			log.Printf("TLS file watcher error: %v", err)
+		}
+	}
+}
+
+// watchFiles returns a watcher of the directories holding the files.
+func (r *Reloader) watchFiles() (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("creating file watcher: %w", err)
+	}
+	dirs := make(map[string]bool)
+	for _, f := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.ClientCAFile} {
+		if f != "" {
+			dirs[filepath.Dir(f)] = true
+		}
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return nil, fmt.Errorf("watching %s: %w", dir, err)
+		}
+	}
+	return watcher, nil
+}
+
+// Collector returns a gauge reporting the days left before the current
+// server certificate expires.
+func (r *Reloader) Collector() prometheus.Collector {
+	return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "tls_certificate_expiry_days",
+		Help: "Days left before the served TLS certificate expires.",
+	}, func() float64 {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		return daysUntil(r.leaf.NotAfter)
+	})
+}
@@ -0,0 +1,126 @@
+package tlsutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// writeKeyPair writes a self-signed certificate for cn and its key to
+// cert.pem and key.pem in dir.
+func writeKeyPair(t *testing.T, dir, cn string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err := ioutil.WriteFile(filepath.Join(dir, "cert.pem"), certPEM, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "key.pem"), keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestReloader(t *testing.T, dir string) *Reloader {
+	t.Helper()
+	writeKeyPair(t, dir, "one")
+	r, err := NewReloader(ServerConfig{CertFile: filepath.Join(dir, "cert.pem"), KeyFile: filepath.Join(dir, "key.pem")})
+	if err != nil {
+		t.Fatalf("NewReloader: %v", err)
+	}
+	return r
+}
+
+func (r *Reloader) commonName() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.leaf.Subject.CommonName
+}
+
+// waitFor calls poke until the served certificate is that of cn.
+func waitFor(t *testing.T, r *Reloader, cn string, poke func()) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for r.commonName() != cn {
+		if time.Now().After(deadline) {
+			t.Fatalf("serving the certificate of %q, want %q", r.commonName(), cn)
+		}
+		poke()
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestWatchReloadsChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	r := newTestReloader(t, dir)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Watch(ctx)
+
+	// written again now and then, in case the watcher started after the
+	// first write, but not so often that the reload keeps being delayed
+	var written time.Time
+	waitFor(t, r, "two", func() {
+		if time.Since(written) > 2*reloadDelay {
+			writeKeyPair(t, dir, "two")
+			written = time.Now()
+		}
+	})
+}
+
+// TestWatchReloadsOnSIGHUPWithoutWatcher checks that SIGHUP still reloads
+// the files when their directory cannot be watched.
+func TestWatchReloadsOnSIGHUPWithoutWatcher(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "tls")
+	r := newTestReloader(t, dir)
+	// the directory is gone when Watch starts, so the watcher fails
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	// keep the test process alive if SIGHUP comes before Watch listens
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Watch(ctx)
+
+	writeKeyPair(t, dir, "two")
+	waitFor(t, r, "two", func() {
+		if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
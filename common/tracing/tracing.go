@@ -0,0 +1,143 @@
+// Package tracing configures OpenTelemetry tracing for the example services.
+//
+// Every client and server calls Init once at startup and installs the
+// interceptors returned by the helpers below. Trace context travels in gRPC
+// metadata using the W3C traceparent/tracestate headers, so a client span,
+// the server span it causes and any MongoDB spans beneath it end up in the
+// same trace.
+package tracing
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"google.golang.org/grpc"
+)
+
+// Exporter names accepted by Config.Exporter.
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterMemory = "memory"
+)
+
+// Config selects where spans are sent.
+type Config struct {
+	ServiceName string
+	// Exporter is one of "none", "otlp", "stdout" or "memory".
+	Exporter string
+	// OTLPEndpoint is the host:port of the OTLP/gRPC collector.
+	OTLPEndpoint string
+	// OTLPInsecure disables TLS towards the collector.
+	OTLPInsecure bool
+	// SampleRatio is the fraction of new traces that are recorded (0..1).
+	// Traces started upstream keep the parent's sampling decision.
+	SampleRatio float64
+}
+
+// RegisterFlags binds the tracing options to fs.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.Exporter, "trace-exporter", ExporterNone, "span exporter: none, otlp, stdout or memory")
+	fs.StringVar(&c.OTLPEndpoint, "trace-otlp-endpoint", "localhost:4317", "OTLP/gRPC collector address")
+	fs.BoolVar(&c.OTLPInsecure, "trace-otlp-insecure", true, "connect to the OTLP collector without TLS")
+	fs.Float64Var(&c.SampleRatio, "trace-sample-ratio", 1, "fraction of new traces to record")
+}
+
+// Provider wraps the SDK tracer provider installed by Init.
+type Provider struct {
+	*sdktrace.TracerProvider
+
+	// Memory holds the finished spans when the "memory" exporter is selected.
+	Memory *tracetest.InMemoryExporter
+}
+
+// Init builds a tracer provider from cfg and installs it, together with the
+// W3C trace context and baggage propagators, as the global OpenTelemetry
+// provider. Callers should Shutdown the provider before exiting so that
+// buffered spans are flushed.
+func Init(ctx context.Context, cfg Config) (*Provider, error) {
+	p := &Provider{}
+
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case "", ExporterNone:
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint)}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exp, err := otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating OTLP exporter: %w", err)
+		}
+		exporter = exp
+	case ExporterStdout:
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, fmt.Errorf("creating stdout exporter: %w", err)
+		}
+		exporter = exp
+	case ExporterMemory:
+		p.Memory = tracetest.NewInMemoryExporter()
+		exporter = p.Memory
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(cfg.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating resource: %w", err)
+	}
+
+	opts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	switch {
+	case exporter == nil:
+		// trace context is still propagated, but nothing is recorded
+		opts = append(opts, sdktrace.WithSampler(sdktrace.NeverSample()))
+	case p.Memory != nil:
+		// record everything and export synchronously so that tests
+		// can inspect the spans as soon as the RPC returns
+		opts = append(opts, sdktrace.WithSampler(sdktrace.AlwaysSample()), sdktrace.WithSyncer(exporter))
+	default:
+		opts = append(opts,
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+			sdktrace.WithBatcher(exporter),
+		)
+	}
+	p.TracerProvider = sdktrace.NewTracerProvider(opts...)
+
+	otel.SetTracerProvider(p.TracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	return p, nil
+}
+
+// ServerInterceptors returns the interceptors that start a server span for
+// every RPC and record a message event for every stream message sent or
+// received. They should run first so that later interceptors see the span.
+func ServerInterceptors() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	return otelgrpc.UnaryServerInterceptor(), otelgrpc.StreamServerInterceptor()
+}
+
+// ClientInterceptors returns the interceptors that start a client span for
+// every RPC and inject its context into the outgoing metadata.
+func ClientInterceptors() (grpc.UnaryClientInterceptor, grpc.StreamClientInterceptor) {
+	return otelgrpc.UnaryClientInterceptor(), otelgrpc.StreamClientInterceptor()
+}
@@ -0,0 +1,368 @@
+package webrpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// connectCodes are the names of the status codes in the Connect protocol.
+var connectCodes = map[codes.Code]string{
+	codes.Canceled:           "canceled",
+	codes.Unknown:            "unknown",
+	codes.InvalidArgument:    "invalid_argument",
+	codes.DeadlineExceeded:   "deadline_exceeded",
+	codes.NotFound:           "not_found",
+	codes.AlreadyExists:      "already_exists",
+	codes.PermissionDenied:   "permission_denied",
+	codes.ResourceExhausted:  "resource_exhausted",
+	codes.FailedPrecondition: "failed_precondition",
+	codes.Aborted:            "aborted",
+	codes.OutOfRange:         "out_of_range",
+	codes.Unimplemented:      "unimplemented",
+	codes.Internal:           "internal",
+	codes.Unavailable:        "unavailable",
+	codes.DataLoss:           "data_loss",
+	codes.Unauthenticated:    "unauthenticated",
+}
+
+// connectHTTPStatus maps status codes to the HTTP status of failed unary
+// Connect calls.
+var connectHTTPStatus = map[codes.Code]int{
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+type connectError struct {
+	Code    string          `json:"code"`
+	Message string          `json:"message,omitempty"`
+	Details []connectDetail `json:"details,omitempty"`
+}
+
+type connectDetail struct {
+	// Type is the full name of the message, without a type URL prefix.
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type connectEndStream struct {
+	Error    *connectError       `json:"error,omitempty"`
+	Metadata map[string][]string `json:"metadata,omitempty"`
+}
+
+func newConnectError(st *status.Status) *connectError {
+	e := &connectError{Code: connectCodes[st.Code()], Message: st.Message()}
+	if e.Code == "" {
+		e.Code = connectCodes[codes.Unknown]
+	}
+	for _, d := range st.Proto().GetDetails() {
+		e.Details = append(e.Details, connectDetail{
+			Type:  d.GetTypeUrl()[strings.LastIndexByte(d.GetTypeUrl(), '/')+1:],
+			Value: base64.RawStdEncoding.EncodeToString(d.GetValue()),
+		})
+	}
+	return e
+}
+
+// connectCodec converts messages between the codec of a Connect request and
+// the binary form spoken by the gRPC server.
+type connectCodec struct {
+	json   bool
+	input  protoreflect.MessageDescriptor
+	output protoreflect.MessageDescriptor
+}
+
+func (c *connectCodec) toGRPC(msg []byte) ([]byte, error) {
+	if !c.json {
+		return msg, nil
+	}
+	m := dynamicpb.NewMessage(c.input)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(msg, m); err != nil {
+		return nil, err
+	}
+	return proto.Marshal(m)
+}
+
+func (c *connectCodec) fromGRPC(msg []byte) ([]byte, error) {
+	if !c.json {
+		return msg, nil
+	}
+	m := dynamicpb.NewMessage(c.output)
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(m)
+}
+
+// lookupMethod finds the descriptor of a method from its path, such as
+// "/greet.GreetService/Greet", among the compiled in proto files.
+func lookupMethod(path string) (protoreflect.MethodDescriptor, bool) {
+	path = strings.TrimPrefix(path, "/")
+	i := strings.LastIndexByte(path, '/')
+	if i < 0 {
+		return nil, false
+	}
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(path[:i]))
+	if err != nil {
+		return nil, false
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, false
+	}
+	md := sd.Methods().ByName(protoreflect.Name(path[i+1:]))
+	return md, md != nil
+}
+
+// serveConnect handles a Connect request. Unary calls carry a single bare
+// message in the body and report errors with HTTP statuses; streaming calls
+// are framed like gRPC and end with a message holding the status and trailers.
+func (h *handler) serveConnect(w http.ResponseWriter, r *http.Request, codecName string, streaming bool) {
+	if codecName != "proto" && codecName != "json" {
+		http.Error(w, "unsupported codec "+codecName, http.StatusUnsupportedMediaType)
+		return
+	}
+	method, known := lookupMethod(r.URL.Path)
+	if known && streaming != (method.IsStreamingClient() || method.IsStreamingServer()) {
+		http.Error(w, "wrong protocol for "+r.URL.Path, http.StatusUnsupportedMediaType)
+		return
+	}
+
+	fail := func(st *status.Status) {
+		if streaming {
+			w.Header().Set("Content-Type", contentTypeConnect+codecName)
+			w.Write(appendFrame(nil, flagEndStream, mustJSON(connectEndStream{Error: newConnectError(st)})))
+			return
+		}
+		writeConnectError(w, st)
+	}
+	codec := &connectCodec{json: codecName == "json"}
+	if codec.json {
+		if !known {
+			fail(status.Newf(codes.Unimplemented, "unknown method %s", r.URL.Path))
+			return
+		}
+		codec.input, codec.output = method.Input(), method.Output()
+	}
+	encodingHeader := "Content-Encoding"
+	if streaming {
+		encodingHeader = "Connect-Content-Encoding"
+	}
+	if enc := r.Header.Get(encodingHeader); enc != "" && enc != "identity" {
+		fail(status.Newf(codes.Unimplemented, "unsupported compression %q", enc))
+		return
+	}
+
+	var body io.Reader
+	if streaming {
+		body = r.Body
+		if codec.json {
+			body = convertFrames(r.Body, codec.toGRPC)
+		}
+	} else {
+		msg, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBufferedRequest+1))
+		if err == nil && len(msg) > maxBufferedRequest {
+			err = fmt.Errorf("request body larger than %d bytes", maxBufferedRequest)
+		}
+		if err == nil {
+			msg, err = codec.toGRPC(msg)
+		}
+		if err != nil {
+			fail(status.Newf(codes.InvalidArgument, "reading request: %v", err))
+			return
+		}
+		body = bytes.NewReader(appendFrame(nil, 0, msg))
+	}
+	req, err := grpcRequest(r, body, contentTypeGRPC+"+proto")
+	if err != nil {
+		fail(status.Newf(codes.InvalidArgument, "reading request: %v", err))
+		return
+	}
+	for _, k := range []string{"Connect-Protocol-Version", "Connect-Timeout-Ms", "Connect-Content-Encoding", "Connect-Accept-Encoding", "Content-Encoding"} {
+		req.Header.Del(k)
+	}
+	if ms := r.Header.Get("Connect-Timeout-Ms"); ms != "" {
+		timeout, err := strconv.ParseInt(ms, 10, 64)
+		if err != nil || timeout < 0 {
+			fail(status.Newf(codes.InvalidArgument, "malformed Connect-Timeout-Ms %q", ms))
+			return
+		}
+		// grpc-timeout allows at most 8 digits
+		if timeout < 1e8 {
+			req.Header.Set("Grpc-Timeout", strconv.FormatInt(timeout, 10)+"m")
+		} else {
+			req.Header.Set("Grpc-Timeout", strconv.FormatInt(timeout/1000, 10)+"S")
+		}
+	}
+
+	if streaming {
+		h.serveConnectStream(w, req, codec, codecName)
+	} else {
+		h.serveConnectUnary(w, req, codec, codecName)
+	}
+}
+
+func (h *handler) serveConnectUnary(w http.ResponseWriter, req *http.Request, codec *connectCodec, codecName string) {
+	var header http.Header
+	var frames frameParser
+	resp := newGRPCResponse()
+	resp.onHeader = func(md http.Header) { header = md }
+	resp.onWrite = frames.write
+
+	h.grpc.ServeHTTP(resp, req)
+	resp.sendHeader()
+
+	for k, vv := range header {
+		w.Header()[k] = vv
+	}
+	for k, vv := range resp.trailers() {
+		if !strings.HasPrefix(k, "Grpc-") {
+			w.Header()["Trailer-"+k] = vv
+		}
+	}
+	st := resp.status()
+	if st.Code() != codes.OK {
+		writeConnectError(w, st)
+		return
+	}
+	flags, msg, ok := frames.next()
+	if !ok || flags&flagCompressed != 0 {
+		writeConnectError(w, status.New(codes.Internal, "no response message"))
+		return
+	}
+	out, err := codec.fromGRPC(msg)
+	if err != nil {
+		writeConnectError(w, status.Newf(codes.Internal, "encoding response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/"+codecName)
+	w.Write(out)
+}
+
+func (h *handler) serveConnectStream(w http.ResponseWriter, req *http.Request, codec *connectCodec, codecName string) {
+	flusher, _ := w.(http.Flusher)
+	var frames frameParser
+	var convErr error
+	resp := newGRPCResponse()
+	resp.onHeader = func(md http.Header) {
+		for k, vv := range md {
+			w.Header()[k] = vv
+		}
+		w.Header().Set("Content-Type", contentTypeConnect+codecName)
+		w.WriteHeader(http.StatusOK)
+	}
+	resp.onWrite = func(b []byte) {
+		frames.write(b)
+		for {
+			flags, msg, ok := frames.next()
+			if !ok || convErr != nil {
+				return
+			}
+			if flags&flagCompressed != 0 {
+				convErr = fmt.Errorf("compressed response message")
+				return
+			}
+			out, err := codec.fromGRPC(msg)
+			if err != nil {
+				convErr = fmt.Errorf("encoding response: %v", err)
+				return
+			}
+			w.Write(appendFrame(nil, 0, out))
+		}
+	}
+	resp.onFlush = func() {
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	h.grpc.ServeHTTP(resp, req)
+	resp.sendHeader()
+
+	end := connectEndStream{Metadata: make(map[string][]string)}
+	for k, vv := range resp.trailers() {
+		if !strings.HasPrefix(k, "Grpc-") {
+			end.Metadata[k] = vv
+		}
+	}
+	if st := resp.status(); st.Code() != codes.OK {
+		end.Error = newConnectError(st)
+	} else if convErr != nil {
+		end.Error = newConnectError(status.New(codes.Internal, convErr.Error()))
+	}
+	w.Write(appendFrame(nil, flagEndStream, mustJSON(end)))
+	resp.onFlush()
+}
+
+func writeConnectError(w http.ResponseWriter, st *status.Status) {
+	code, ok := connectHTTPStatus[st.Code()]
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(mustJSON(newConnectError(st)))
+}
+
+func mustJSON(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// convertFrames returns a reader of the frames read from r, with every
+// message converted by conv. A conversion error ends the stream.
+func convertFrames(r io.Reader, conv func([]byte) ([]byte, error)) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		for {
+			flags, msg, err := readFrame(r)
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if flags&flagEndStream != 0 {
+				pw.Close()
+				return
+			}
+			if msg, err = conv(msg); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if _, err := pw.Write(appendFrame(nil, flags, msg)); err != nil {
+				return
+			}
+		}
+	}()
+	return pr
+}
@@ -0,0 +1,114 @@
+package webrpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// serveGRPCWeb handles a gRPC-Web request. Requests and responses are framed
+// like gRPC, except that the trailers are sent as a last message in the body
+// and that the "-text" variants base64 encode the body.
+func (h *handler) serveGRPCWeb(w http.ResponseWriter, r *http.Request, contentType string) {
+	text := strings.HasPrefix(contentType, contentTypeGRPCWeb+"-text")
+	var body io.Reader = r.Body
+	if text {
+		decoded, err := decodeBase64Chunks(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = bytes.NewReader(decoded)
+	}
+	req, err := grpcRequest(r, body, contentTypeGRPC+"+proto")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	respType := contentTypeGRPCWeb + "+proto"
+	if text {
+		respType = contentTypeGRPCWeb + "-text+proto"
+	}
+
+	flusher, _ := w.(http.Flusher)
+	// text responses are encoded one flush at a time: every message is
+	// padded on its own, which gRPC-Web clients accept
+	var pending []byte
+	flush := func() {
+		if text && len(pending) > 0 {
+			io.WriteString(w, base64.StdEncoding.EncodeToString(pending))
+			pending = pending[:0]
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	resp := newGRPCResponse()
+	resp.onHeader = func(md http.Header) {
+		for k, vv := range md {
+			w.Header()[k] = vv
+		}
+		w.Header().Set("Content-Type", respType)
+		w.WriteHeader(http.StatusOK)
+	}
+	resp.onWrite = func(b []byte) {
+		if text {
+			pending = append(pending, b...)
+		} else {
+			w.Write(b)
+		}
+	}
+	resp.onFlush = flush
+
+	h.grpc.ServeHTTP(resp, req)
+
+	resp.sendHeader()
+	trailer := appendFrame(nil, flagTrailer, encodeTrailers(resp.trailers()))
+	resp.onWrite(trailer)
+	flush()
+}
+
+// encodeTrailers writes trailers as an HTTP/1 header block with lower case
+// names, as gRPC-Web expects.
+func encodeTrailers(t http.Header) []byte {
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b bytes.Buffer
+	for _, k := range keys {
+		for _, v := range t[k] {
+			fmt.Fprintf(&b, "%s: %s\r\n", strings.ToLower(k), v)
+		}
+	}
+	return b.Bytes()
+}
+
+// decodeBase64Chunks decodes a base64 body that may be made of several
+// padded chunks.
+func decodeBase64Chunks(r io.Reader) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxBufferedRequest*4/3+4))
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.Join(bytes.Fields(data), nil)
+	if len(data)%4 != 0 {
+		return nil, fmt.Errorf("malformed base64 body")
+	}
+	out := make([]byte, 0, len(data)/4*3)
+	var group [3]byte
+	for i := 0; i < len(data); i += 4 {
+		n, err := base64.StdEncoding.Decode(group[:], data[i:i+4])
+		if err != nil {
+			return nil, fmt.Errorf("malformed base64 body: %v", err)
+		}
+		out = append(out, group[:n]...)
+	}
+	return out, nil
+}
@@ -0,0 +1,220 @@
+package webrpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Messages on gRPC, gRPC-Web and Connect streams are framed the same way: a
+// flags byte, the length of the message as a big endian uint32 and the
+// message. The protocols differ in the flags they use.
+const (
+	frameHeaderLen = 5
+
+	flagCompressed = 0x01
+	// flagEndStream marks the Connect message carrying the status and trailers.
+	flagEndStream = 0x02
+	// flagTrailer marks the gRPC-Web message carrying the status and trailers.
+	flagTrailer = 0x80
+)
+
+func appendFrame(dst []byte, flags byte, msg []byte) []byte {
+	var hdr [frameHeaderLen]byte
+	hdr[0] = flags
+	binary.BigEndian.PutUint32(hdr[1:], uint32(len(msg)))
+	return append(append(dst, hdr[:]...), msg...)
+}
+
+// readFrame reads the next frame from r; it returns io.EOF at the end of r.
+func readFrame(r io.Reader) (flags byte, msg []byte, err error) {
+	var hdr [frameHeaderLen]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return 0, nil, fmt.Errorf("truncated message header")
+		}
+		return 0, nil, err
+	}
+	n := binary.BigEndian.Uint32(hdr[1:])
+	if n > maxBufferedRequest {
+		return 0, nil, fmt.Errorf("message of %d bytes is too large", n)
+	}
+	msg = make([]byte, n)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return 0, nil, fmt.Errorf("truncated message: %v", err)
+	}
+	return hdr[0], msg, nil
+}
+
+// frameParser splits the bytes written by the gRPC server into frames.
+type frameParser struct {
+	buf []byte
+}
+
+func (p *frameParser) write(b []byte) {
+	p.buf = append(p.buf, b...)
+}
+
+// next returns the next complete frame, if any.
+func (p *frameParser) next() (flags byte, msg []byte, ok bool) {
+	if len(p.buf) < frameHeaderLen {
+		return 0, nil, false
+	}
+	n := int(binary.BigEndian.Uint32(p.buf[1:]))
+	if len(p.buf) < frameHeaderLen+n {
+		return 0, nil, false
+	}
+	flags, msg = p.buf[0], p.buf[frameHeaderLen:frameHeaderLen+n]
+	p.buf = p.buf[frameHeaderLen+n:]
+	return flags, msg, true
+}
+
+// grpcRequest turns r into a gRPC request with the given body and content
+// type, for the grpc.Server to handle.
+func grpcRequest(r *http.Request, body io.Reader, contentType string) (*http.Request, error) {
+	// Over HTTP/1.1 the body cannot be read once the response has started,
+	// which the gRPC server may do, so it is read upfront. Browsers send whole
+	// requests anyway.
+	if r.ProtoMajor < 2 {
+		data, err := ioutil.ReadAll(io.LimitReader(body, maxBufferedRequest+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxBufferedRequest {
+			return nil, fmt.Errorf("request body larger than %d bytes", maxBufferedRequest)
+		}
+		body = bytes.NewReader(data)
+	}
+	req := r.Clone(r.Context())
+	req.ProtoMajor, req.ProtoMinor, req.Proto = 2, 0, "HTTP/2"
+	req.Body = ioutil.NopCloser(body)
+	req.ContentLength = -1
+	req.Header.Del("Content-Length")
+	req.Header.Set("Content-Type", contentType)
+	return req, nil
+}
+
+// grpcResponse is the http.ResponseWriter handed to the gRPC server for a
+// translated request. It passes on the headers and body as they are written
+// and keeps the trailers, which the server sets in the header map after the
+// body.
+type grpcResponse struct {
+	header     http.Header
+	sentHeader bool
+	code       int
+
+	// onHeader is called with the response metadata before the first body
+	// bytes or flush.
+	onHeader func(md http.Header)
+	onWrite  func(b []byte)
+	onFlush  func()
+}
+
+func newGRPCResponse() *grpcResponse {
+	return &grpcResponse{
+		header:   make(http.Header),
+		onHeader: func(http.Header) {},
+		onWrite:  func([]byte) {},
+		onFlush:  func() {},
+	}
+}
+
+func (g *grpcResponse) Header() http.Header {
+	return g.header
+}
+
+func (g *grpcResponse) WriteHeader(code int) {
+	if g.code == 0 {
+		g.code = code
+	}
+	g.sendHeader()
+}
+
+func (g *grpcResponse) Write(b []byte) (int, error) {
+	g.sendHeader()
+	g.onWrite(b)
+	return len(b), nil
+}
+
+func (g *grpcResponse) Flush() {
+	g.sendHeader()
+	g.onFlush()
+}
+
+func (g *grpcResponse) sendHeader() {
+	if g.sentHeader {
+		return
+	}
+	g.sentHeader = true
+	md := make(http.Header)
+	for k, vv := range g.header {
+		switch k {
+		case "Content-Type", "Trailer", "Date":
+			continue
+		}
+		md[k] = append([]string(nil), vv...)
+	}
+	g.onHeader(md)
+}
+
+// trailers returns the status and trailer metadata written by the server.
+func (g *grpcResponse) trailers() http.Header {
+	t := make(http.Header)
+	for _, k := range []string{"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin"} {
+		if v := g.header.Get(k); v != "" {
+			t.Set(k, v)
+		}
+	}
+	for k, vv := range g.header {
+		if strings.HasPrefix(k, http.TrailerPrefix) {
+			t[http.CanonicalHeaderKey(strings.TrimPrefix(k, http.TrailerPrefix))] = vv
+		}
+	}
+	return t
+}
+
+// status returns the status of the call from the trailers.
+func (g *grpcResponse) status() *status.Status {
+	if g.code != 0 && g.code != http.StatusOK {
+		// the gRPC server rejected the request before handling it
+		return status.New(codes.Internal, http.StatusText(g.code))
+	}
+	t := g.header
+	code, err := strconv.Atoi(t.Get("Grpc-Status"))
+	if err != nil {
+		return status.New(codes.Internal, "response without grpc-status")
+	}
+	if bin := t.Get("Grpc-Status-Details-Bin"); bin != "" {
+		if data, err := decodeBinHeader(bin); err == nil {
+			st := &spb.Status{}
+			if err := proto.Unmarshal(data, st); err == nil {
+				return status.FromProto(st)
+			}
+		}
+	}
+	msg := t.Get("Grpc-Message")
+	// the message is percent encoded
+	if decoded, err := url.PathUnescape(msg); err == nil {
+		msg = decoded
+	}
+	return status.New(codes.Code(code), msg)
+}
+
+func decodeBinHeader(v string) ([]byte, error) {
+	if len(v)%4 == 0 {
+		return base64.StdEncoding.DecodeString(v)
+	}
+	return base64.RawStdEncoding.DecodeString(v)
+}
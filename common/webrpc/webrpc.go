@@ -0,0 +1,168 @@
+// Package webrpc lets browsers call gRPC services. It serves gRPC-Web and the
+// Connect protocol next to native gRPC on the same listener, telling them
+// apart by content type, and answers CORS requests.
+//
+// gRPC-Web and Connect requests are translated into gRPC requests handled by
+// the grpc.Server itself, so they run through the same interceptors for
+// tracing, authentication and rate limiting as native calls. Unary and server
+// streaming methods work from browsers; client and bidirectional streaming
+// need HTTP/2 clients, such as Connect clients outside the browser.
+package webrpc
+
+import (
+	"common/flagutil"
+	"crypto/tls"
+	"flag"
+	"net"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+)
+
+// Config holds the browser access options of a server.
+type Config struct {
+	// Enabled serves gRPC-Web and Connect next to native gRPC.
+	Enabled bool
+	// AllowedOrigins are the origins, such as "https://example.com", whose
+	// pages may call the server; "*" allows every origin. Pages served from
+	// the server's own origin need no entry.
+	AllowedOrigins flagutil.StringList
+}
+
+// RegisterFlags binds the browser access options to fs.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&c.Enabled, "web", false, "serve gRPC-Web and the Connect protocol next to gRPC")
+	fs.Var(&c.AllowedOrigins, "web-allowed-origins", `comma separated origins allowed to call from a browser (CORS); "*" allows all`)
+}
+
+// maxBufferedRequest bounds the request bodies read into memory, see
+// grpcRequest.
+const maxBufferedRequest = 8 << 20
+
+const (
+	contentTypeGRPC    = "application/grpc"
+	contentTypeGRPCWeb = "application/grpc-web"
+	contentTypeConnect = "application/connect+"
+)
+
+// exposedHeaders are the response headers scripts from other origins may read.
+var exposedHeaders = strings.Join([]string{
+	"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin", "Grpc-Encoding",
+}, ", ")
+
+type handler struct {
+	grpc *grpc.Server
+	cfg  Config
+}
+
+// NewHandler returns a handler serving native gRPC, gRPC-Web and Connect
+// requests with s.
+func NewHandler(s *grpc.Server, cfg Config) http.Handler {
+	return &handler{grpc: s, cfg: cfg}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			h.preflight(w, r, origin)
+			return
+		}
+		h.allowOrigin(w, origin)
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	switch {
+	case r.Method != http.MethodPost:
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+	case contentType == contentTypeGRPCWeb || contentType == contentTypeGRPCWeb+"+proto" ||
+		contentType == contentTypeGRPCWeb+"-text" || contentType == contentTypeGRPCWeb+"-text+proto":
+		h.serveGRPCWeb(w, r, contentType)
+	case contentType == contentTypeGRPC || strings.HasPrefix(contentType, contentTypeGRPC+"+"):
+		h.grpc.ServeHTTP(w, r)
+	case strings.HasPrefix(contentType, contentTypeConnect):
+		h.serveConnect(w, r, strings.TrimPrefix(contentType, contentTypeConnect), true)
+	case contentType == "application/proto" || contentType == "application/json":
+		h.serveConnect(w, r, strings.TrimPrefix(contentType, "application/"), false)
+	default:
+		http.Error(w, "unsupported content type "+contentType, http.StatusUnsupportedMediaType)
+	}
+}
+
+// originAllowed reports whether origin may call the server, and whether it
+// was listed by name rather than matched by "*".
+func (h *handler) originAllowed(origin string) (allowed, named bool) {
+	for _, o := range h.cfg.AllowedOrigins {
+		if o == origin {
+			return true, true
+		}
+		if o == "*" {
+			allowed = true
+		}
+	}
+	return allowed, false
+}
+
+func (h *handler) allowOrigin(w http.ResponseWriter, origin string) bool {
+	w.Header().Add("Vary", "Origin")
+	allowed, named := h.originAllowed(origin)
+	if !allowed {
+		return false
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	// cookies and client certificates are only shared with origins listed by name
+	if named {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+	w.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
+	return true
+}
+
+func (h *handler) preflight(w http.ResponseWriter, r *http.Request, origin string) {
+	if !h.allowOrigin(w, origin) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Methods", http.MethodPost)
+	// request metadata travels in headers, so any header may be sent
+	if headers := r.Header.Get("Access-Control-Request-Headers"); headers != "" {
+		w.Header().Set("Access-Control-Allow-Headers", headers)
+	}
+	w.Header().Set("Access-Control-Max-Age", "7200")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// NewServer returns an HTTP server for h, the handler returned by NewHandler.
+// Without a TLS config, HTTP/2 is served in cleartext (h2c) next to HTTP/1.1
+// so that native gRPC clients can still connect.
+func NewServer(h http.Handler, tlsConfig *tls.Config) *http.Server {
+	if tlsConfig == nil {
+		h = h2c.NewHandler(h, &http2.Server{})
+	} else {
+		// browsers may speak HTTP/1.1, native gRPC clients need HTTP/2
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.NextProtos = []string{"h2", "http/1.1"}
+	}
+	return &http.Server{Handler: h, TLSConfig: tlsConfig}
+}
+
+// Serve accepts connections on lis until srv is shut down, over TLS when srv
+// has a TLS config.
+func Serve(srv *http.Server, lis net.Listener) error {
+	var err error
+	if srv.TLSConfig != nil {
+		err = srv.ServeTLS(lis, "", "")
+	} else {
+		err = srv.Serve(lis)
+	}
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
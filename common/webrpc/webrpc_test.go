@@ -0,0 +1,282 @@
+package webrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	checkPath = "/grpc.health.v1.Health/Check"
+	watchPath = "/grpc.health.v1.Health/Watch"
+)
+
+// newServer serves the health service, reporting the whole server as
+// serving, through a handler with cfg.
+func newServer(t *testing.T, cfg Config) *httptest.Server {
+	s := grpc.NewServer()
+	h := health.NewServer()
+	h.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, h)
+	ts := httptest.NewServer(NewServer(NewHandler(s, cfg), nil).Handler)
+	t.Cleanup(ts.Close)
+	t.Cleanup(s.Stop)
+	return ts
+}
+
+func post(t *testing.T, url, contentType string, header http.Header, body []byte) (*http.Response, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, vv := range header {
+		req.Header[k] = vv
+	}
+	req.Header.Set("Content-Type", contentType)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res, data
+}
+
+func marshal(t *testing.T, m proto.Message) []byte {
+	t.Helper()
+	data, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// compactJSON removes the spaces of a JSON document, which protojson varies
+// on purpose.
+func compactJSON(t *testing.T, data []byte) string {
+	t.Helper()
+	var b bytes.Buffer
+	if err := json.Compact(&b, data); err != nil {
+		t.Fatalf("compacting %s: %v", data, err)
+	}
+	return b.String()
+}
+
+type frame struct {
+	flags byte
+	msg   []byte
+}
+
+func readFrames(t *testing.T, data []byte) []frame {
+	t.Helper()
+	var frames []frame
+	r := bytes.NewReader(data)
+	for {
+		flags, msg, err := readFrame(r)
+		if err == io.EOF {
+			return frames
+		}
+		if err != nil {
+			t.Fatalf("reading frames: %v", err)
+		}
+		frames = append(frames, frame{flags, msg})
+	}
+}
+
+// grpcWebResult splits a gRPC-Web response into its messages, decoded as
+// health check responses, and its trailers.
+func grpcWebResult(t *testing.T, data []byte) (statuses []healthpb.HealthCheckResponse_ServingStatus, trailers string) {
+	t.Helper()
+	for _, f := range readFrames(t, data) {
+		if f.flags&flagTrailer != 0 {
+			trailers = string(f.msg)
+			continue
+		}
+		var res healthpb.HealthCheckResponse
+		if err := proto.Unmarshal(f.msg, &res); err != nil {
+			t.Fatalf("unmarshaling a response message: %v", err)
+		}
+		statuses = append(statuses, res.GetStatus())
+	}
+	return statuses, trailers
+}
+
+func TestNativeGRPC(t *testing.T) {
+	ts := newServer(t, Config{})
+	cc, err := grpc.Dial(strings.TrimPrefix(ts.URL, "http://"), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+	res, err := healthpb.NewHealthClient(cc).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check over h2c: %v", err)
+	}
+	if res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Check over h2c = %v, want SERVING", res.GetStatus())
+	}
+}
+
+func TestGRPCWeb(t *testing.T) {
+	ts := newServer(t, Config{})
+	serving := []healthpb.HealthCheckResponse_ServingStatus{healthpb.HealthCheckResponse_SERVING}
+	tests := []struct {
+		name     string
+		path     string
+		text     bool
+		req      *healthpb.HealthCheckRequest
+		header   http.Header
+		want     []healthpb.HealthCheckResponse_ServingStatus
+		trailers []string
+	}{
+		{"unary", checkPath, false, &healthpb.HealthCheckRequest{}, nil, serving, []string{"grpc-status: 0\r\n"}},
+		{"text", checkPath, true, &healthpb.HealthCheckRequest{}, nil, serving, []string{"grpc-status: 0\r\n"}},
+		{"error", checkPath, false, &healthpb.HealthCheckRequest{Service: "nope"}, nil, nil,
+			[]string{"grpc-status: 5\r\n", "grpc-message: unknown service\r\n"}},
+		// Watch streams until its deadline, then fails with CANCELLED
+		{"server streaming", watchPath, false, &healthpb.HealthCheckRequest{}, http.Header{"Grpc-Timeout": {"100m"}}, serving, []string{"grpc-status: 1\r\n"}},
+		{"text server streaming", watchPath, true, &healthpb.HealthCheckRequest{}, http.Header{"Grpc-Timeout": {"100m"}}, serving, []string{"grpc-status: 1\r\n"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType := "application/grpc-web+proto"
+			body := appendFrame(nil, 0, marshal(t, tt.req))
+			if tt.text {
+				contentType = "application/grpc-web-text"
+				body = []byte(base64.StdEncoding.EncodeToString(body))
+			}
+			res, data := post(t, ts.URL+tt.path, contentType, tt.header, body)
+			if res.StatusCode != http.StatusOK {
+				t.Fatalf("got HTTP status %d, want %d", res.StatusCode, http.StatusOK)
+			}
+			wantType := "application/grpc-web+proto"
+			if tt.text {
+				wantType = "application/grpc-web-text+proto"
+				decoded, err := decodeBase64Chunks(bytes.NewReader(data))
+				if err != nil {
+					t.Fatalf("decoding the text response: %v", err)
+				}
+				data = decoded
+			}
+			if got := res.Header.Get("Content-Type"); got != wantType {
+				t.Errorf("got content type %q, want %q", got, wantType)
+			}
+			statuses, trailers := grpcWebResult(t, data)
+			if len(statuses) != len(tt.want) || (len(statuses) > 0 && statuses[0] != tt.want[0]) {
+				t.Errorf("got messages %v, want %v", statuses, tt.want)
+			}
+			for _, want := range tt.trailers {
+				if !strings.Contains(trailers, want) {
+					t.Errorf("trailers %q do not contain %q", trailers, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConnectUnary(t *testing.T) {
+	ts := newServer(t, Config{})
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		code        int
+		want        string
+	}{
+		{"json", "application/json", []byte(`{}`), http.StatusOK, `{"status":"SERVING"}`},
+		{"json error", "application/json", []byte(`{"service": "nope"}`), http.StatusNotFound, `{"code":"not_found","message":"unknown service"}`},
+		{"malformed json", "application/json", []byte(`{"service": 3}`), http.StatusBadRequest, `"code":"invalid_argument"`},
+		{"proto", "application/proto", marshal(t, &healthpb.HealthCheckRequest{}), http.StatusOK,
+			string(marshal(t, &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, data := post(t, ts.URL+checkPath, tt.contentType, nil, tt.body)
+			if res.StatusCode != tt.code {
+				t.Errorf("got HTTP status %d, want %d", res.StatusCode, tt.code)
+			}
+			got := string(data)
+			if strings.HasSuffix(tt.contentType, "json") {
+				got = compactJSON(t, data)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("got body %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnectServerStreaming(t *testing.T) {
+	ts := newServer(t, Config{})
+	body := appendFrame(nil, 0, []byte(`{}`))
+	res, data := post(t, ts.URL+watchPath, "application/connect+json", http.Header{"Connect-Timeout-Ms": {"100"}}, body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("got HTTP status %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	frames := readFrames(t, data)
+	if len(frames) != 2 {
+		t.Fatalf("got %d frames, want a message and the end of the stream", len(frames))
+	}
+	if got := compactJSON(t, frames[0].msg); frames[0].flags != 0 || got != `{"status":"SERVING"}` {
+		t.Errorf("got message %s with flags %#x, want the SERVING status", got, frames[0].flags)
+	}
+	var end connectEndStream
+	if err := json.Unmarshal(frames[1].msg, &end); err != nil || frames[1].flags != flagEndStream {
+		t.Fatalf("got end of stream %s with flags %#x: %v", frames[1].msg, frames[1].flags, err)
+	}
+	// Watch streams until its deadline, then fails with CANCELLED
+	if end.Error == nil || end.Error.Code != "canceled" {
+		t.Errorf("got end of stream error %+v, want canceled", end.Error)
+	}
+
+	// a unary method called as a stream is refused
+	res, _ = post(t, ts.URL+checkPath, "application/connect+json", nil, body)
+	if res.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("streaming call of a unary method: got HTTP status %d, want %d", res.StatusCode, http.StatusUnsupportedMediaType)
+	}
+}
+
+func TestCORS(t *testing.T) {
+	ts := newServer(t, Config{AllowedOrigins: []string{"https://app.example.com"}})
+	preflight := func(origin string) *http.Response {
+		req, err := http.NewRequest(http.MethodOptions, ts.URL+checkPath, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "content-type,authorization")
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res
+	}
+	res := preflight("https://app.example.com")
+	if res.StatusCode != http.StatusNoContent {
+		t.Errorf("preflight of an allowed origin: got HTTP status %d, want %d", res.StatusCode, http.StatusNoContent)
+	}
+	if got := res.Header.Get("Access-Control-Allow-Headers"); got != "content-type,authorization" {
+		t.Errorf("preflight of an allowed origin: got allowed headers %q", got)
+	}
+	if res := preflight("https://evil.example.com"); res.StatusCode != http.StatusForbidden {
+		t.Errorf("preflight of another origin: got HTTP status %d, want %d", res.StatusCode, http.StatusForbidden)
+	}
+}
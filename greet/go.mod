@@ -3,13 +3,15 @@ module greet
 go 1.15
 
 require (
+	common v0.0.0
 	github.com/go-kit/kit v0.10.0
 	github.com/grpc-ecosystem/go-grpc-middleware v1.2.2
 	github.com/grpc-ecosystem/go-grpc-prometheus v1.2.0
-	github.com/sirupsen/logrus v1.4.2
+	github.com/prometheus/client_golang v1.3.0
 	github.com/sony/gobreaker v0.4.1
-	go.opencensus.io v0.22.2
 	golang.org/x/time v0.0.0-20201208040808-7e3f01d25324
-	google.golang.org/grpc v1.34.0
-	google.golang.org/protobuf v1.25.0
+	google.golang.org/grpc v1.41.0
+	google.golang.org/protobuf v1.27.1
 )
+
+replace common => ../common
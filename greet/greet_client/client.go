@@ -1,11 +1,19 @@
 package main
 
 import (
+	"common/auth"
+	"common/protoset"
+	"common/repl"
+	"common/tlsutil"
+	"common/tracing"
 	"context"
+	"flag"
 	"fmt"
 	"greet/greetpb"
 	"io"
 	"log"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/go-kit/kit/circuitbreaker"
@@ -17,8 +25,8 @@ import (
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // UnaryService is the interface of the unary methods
@@ -104,15 +112,30 @@ func decodeGRPCunaryResponse(_ context.Context, grpcReply interface{}) (interfac
 }
 
 func main() {
+	traceCfg := tracing.Config{ServiceName: "greet_client"}
+	traceCfg.RegisterFlags(flag.CommandLine)
+	var authCfg auth.ClientConfig
+	authCfg.RegisterFlags(flag.CommandLine)
+	tls := flag.Bool("tls", true, "connect over TLS")
+	var tlsCfg tlsutil.ClientConfig
+	tlsCfg.RegisterFlags(flag.CommandLine, "ssl/cert.pem")
+	interactive := flag.Bool("interactive", false, "drive GreetEveryone from stdin instead of running the examples")
+	flag.Parse()
 
 	fmt.Println("Hello I'm a client")
 
-	tls := true
+	tp, err := tracing.Init(context.Background(), traceCfg)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	// flush the spans that are still buffered
+	defer tp.Shutdown(context.Background())
+
 	opt := grpc.WithInsecure()
-	if tls {
+	if *tls {
 		//certFile := "ssl/ca.crt" // deprecated, should be compiled with GODEBUG=x509ignoreCN=0 if we want to use it
-		certFile := "ssl/cert.pem"
-		creds, sslErr := credentials.NewClientTLSFromFile(certFile, "")
+		// pass -tls-cert and -tls-key to present a client certificate for mutual TLS
+		creds, sslErr := tlsutil.ClientCredentials(tlsCfg)
 		if sslErr != nil {
 			log.Fatalf("Error while loading CA trust certificate: %v", sslErr)
 			return
@@ -126,25 +149,46 @@ func main() {
 		grpc_retry.WithCodes(codes.NotFound, codes.Aborted),
 	}
 
+	traceUnary, traceStream := tracing.ClientInterceptors()
+
 	// connection timeout after  3 seconds
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
 	// control client side max receive msg size and connection timeout
-	cc, err := grpc.DialContext(
-		ctx,
-		"localhost:10051",
+	dialOpts := []grpc.DialOption{
 		opt,
-		grpc.WithStreamInterceptor(grpc_retry.StreamClientInterceptor(retryOpts...)),
-		grpc.WithUnaryInterceptor(grpc_retry.UnaryClientInterceptor(retryOpts...)),
-		grpc.WithMaxMsgSize(1024*1024*8),
+		// the tracing interceptors come first so that every retry attempt
+		// is part of the same client span
+		grpc.WithChainStreamInterceptor(traceStream, grpc_retry.StreamClientInterceptor(retryOpts...)),
+		grpc.WithChainUnaryInterceptor(traceUnary, grpc_retry.UnaryClientInterceptor(retryOpts...)),
+		grpc.WithMaxMsgSize(1024 * 1024 * 8),
 		grpc.WithBlock(),
-	)
+	}
+	// send the bearer token with every call
+	if creds := authCfg.Credentials(!*tls); creds != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(creds))
+	}
+	cc, err := grpc.DialContext(ctx, "localhost:10051", dialOpts...)
 	if err != nil {
 		log.Fatalf("could not connect: %v", err)
 	}
 	defer cc.Close()
 
+	// "client describe [file]" saves the service descriptors for tooling
+	if flag.Arg(0) == "describe" {
+		path, err := protoset.Describe(cc, "greet.GreetService", flag.Arg(1))
+		if err != nil {
+			log.Fatalf("Failed to describe the service: %v", err)
+		}
+		fmt.Printf("Wrote the descriptors of greet.GreetService to %v\n", path)
+		return
+	}
+	if *interactive {
+		doInteractive(greetpb.NewGreetServiceClient(cc))
+		return
+	}
+
 	unarySvc := newUnaryService(cc)
 	var res string
 	res, err = unarySvc.Greet("ming", "hsu")
@@ -329,6 +373,39 @@ func doBiDiStreaming(c greetpb.GreetServiceClient) {
 	<-waitc
 }
 
+// doInteractive sends a greeting for every name typed on stdin, as "first" or
+// "first last", and prints the replies as they come.
+func doInteractive(c greetpb.GreetServiceClient) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := c.GreetEveryone(ctx)
+	if err != nil {
+		log.Fatalf("Error while creating stream: %v", err)
+	}
+	session := &repl.Session{
+		Stream:      stream,
+		Cancel:      cancel,
+		NewRequest:  func() proto.Message { return &greetpb.GreetEveryoneRequest{} },
+		NewResponse: func() proto.Message { return &greetpb.GreetEveryoneResponse{} },
+		Parse: func(line string, req proto.Message) error {
+			names := strings.SplitN(line, " ", 2)
+			greeting := &greetpb.Greeting{FirstName: names[0]}
+			if len(names) == 2 {
+				greeting.LastName = strings.TrimSpace(names[1])
+			}
+			req.(*greetpb.GreetEveryoneRequest).Greeting = greeting
+			return nil
+		},
+		Format: func(res proto.Message) string {
+			return res.(*greetpb.GreetEveryoneResponse).GetResult()
+		},
+		Out: os.Stdout,
+	}
+	if err := session.Run(os.Stdin); err != nil {
+		log.Printf("GreetEveryone ended with an error: %v", err)
+	}
+}
+
 func doUnaryWithDeadline(c greetpb.GreetServiceClient, timeout time.Duration) {
 	fmt.Println("Starting to do a UnaryWithDeadline RPC...")
 	req := &greetpb.GreetWithDeadlineRequest{
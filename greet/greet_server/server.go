@@ -1,29 +1,49 @@
 package main
 
 import (
+	"common/auth"
+	"common/fault"
+	"common/ratelimit"
+	"common/tlsutil"
+	"common/tracing"
+	"common/webrpc"
 	"context"
+	cryptotls "crypto/tls"
+	"flag"
 	"fmt"
 	"greet/greetpb"
 	"io"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
 	"strconv"
 	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
-	"go.opencensus.io/plugin/ocgrpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
-type server struct{}
+type server struct {
+	// delay is the pause between the messages of GreetManyTimes and the
+	// steps of GreetWithDeadline
+	delay time.Duration
+}
 
 func (*server) Greet(ctx context.Context, req *greetpb.GreetRequest) (*greetpb.GreetResponse, error) {
 	fmt.Printf("Greet function was invoked with %v\n", req)
+	// with mutual TLS the client is identified by its certificate
+	if id, ok := tlsutil.IdentityFromContext(ctx); ok {
+		fmt.Printf("Greet function was called by %v (names: %v)\n", id.Subject, id.Names())
+	}
 	firstName := req.GetGreeting().GetFirstName()
 	result := "Hello " + firstName
 	res := &greetpb.GreetResponse{
@@ -32,7 +52,7 @@ func (*server) Greet(ctx context.Context, req *greetpb.GreetRequest) (*greetpb.G
 	return res, nil
 }
 
-func (*server) GreetManyTimes(req *greetpb.GreetManyTimesRequest, stream greetpb.GreetService_GreetManyTimesServer) error {
+func (s *server) GreetManyTimes(req *greetpb.GreetManyTimesRequest, stream greetpb.GreetService_GreetManyTimesServer) error {
 	fmt.Printf("GreetManyTimes function was invoked with %v\n", req)
 	firstName := req.GetGreeting().GetFirstName()
 	for i := 0; i < 10; i++ {
@@ -41,7 +61,7 @@ func (*server) GreetManyTimes(req *greetpb.GreetManyTimesRequest, stream greetpb
 			Result: result,
 		}
 		stream.Send(res)
-		time.Sleep(1000 * time.Millisecond)
+		time.Sleep(s.delay)
 	}
 	return nil
 }
@@ -93,7 +113,7 @@ func (*server) GreetEveryone(stream greetpb.GreetService_GreetEveryoneServer) er
 
 }
 
-func (*server) GreetWithDeadline(ctx context.Context, req *greetpb.GreetWithDeadlineRequest) (*greetpb.GreetWithDeadlineResponse, error) {
+func (s *server) GreetWithDeadline(ctx context.Context, req *greetpb.GreetWithDeadlineRequest) (*greetpb.GreetWithDeadlineResponse, error) {
 	fmt.Printf("GreetWithDeadline function was invoked with %v\n", req)
 	for i := 0; i < 3; i++ {
 		if ctx.Err() == context.DeadlineExceeded {
@@ -101,7 +121,7 @@ func (*server) GreetWithDeadline(ctx context.Context, req *greetpb.GreetWithDead
 			fmt.Println("The client canceled the request!")
 			return nil, status.Error(codes.Canceled, "the client canceled the request")
 		}
-		time.Sleep(1 * time.Second)
+		time.Sleep(s.delay)
 	}
 	firstName := req.GetGreeting().GetFirstName()
 	result := "Hello " + firstName
@@ -116,8 +136,41 @@ var (
 )
 
 func main() {
+	traceCfg := tracing.Config{ServiceName: "greet_server"}
+	traceCfg.RegisterFlags(flag.CommandLine)
+	var authCfg auth.Config
+	authCfg.RegisterFlags(flag.CommandLine)
+	var rlCfg ratelimit.Config
+	rlCfg.RegisterFlags(flag.CommandLine)
+	var faultCfg fault.Config
+	faultCfg.RegisterFlags(flag.CommandLine)
+	tls := flag.Bool("tls", true, "serve over TLS")
+	var tlsCfg tlsutil.ServerConfig
+	tlsCfg.RegisterFlags(flag.CommandLine, "ssl/cert.pem", "ssl/prikey.pem")
+	metricsAddr := flag.String("metrics-addr", "0.0.0.0:10052", "address serving Prometheus metrics on /metrics")
+	enableReflection := flag.Bool("reflection", true, "register the server reflection service used by Evans and grpcurl")
+	var webCfg webrpc.Config
+	webCfg.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+	if !*tls && (tlsCfg.MutualTLS() || len(tlsCfg.AllowedClients) > 0) {
+		// without TLS there is no client certificate, and every call would
+		// be refused
+		log.Fatalf("-tls-client-ca and -tls-allowed-clients need -tls")
+	}
+	if err := rlCfg.Load(); err != nil {
+		log.Fatalf("Failed to set up rate limiting: %v", err)
+	}
+	if err := faultCfg.Load(); err != nil {
+		log.Fatalf("Failed to set up fault injection: %v", err)
+	}
+
 	fmt.Println("Hello world")
 
+	tp, err := tracing.Init(context.Background(), traceCfg)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+
 	lis, err := net.Listen("tcp", "0.0.0.0:10051")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
@@ -126,18 +179,21 @@ func main() {
 	opts := []grpc.ServerOption{
 		grpc.MaxRecvMsgSize(1024 * 1024 * 8), // increase to 8 MB (default: 4 MB)
 	}
-	tls := true
-	if tls {
-		// keyFile := "ssl/server.pem"
-		// certFile := "ssl/server.crt"
-		keyFile := "ssl/prikey.pem"
-		certFile := "ssl/cert.pem"
-		creds, sslErr := credentials.NewServerTLSFromFile(certFile, keyFile)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var reloader *tlsutil.Reloader
+	if *tls {
+		// the certificates made by ssl/instructions.sh are ssl/server.crt and ssl/server.pem
+		var sslErr error
+		reloader, sslErr = tlsutil.NewReloader(tlsCfg)
 		if sslErr != nil {
 			log.Fatalf("Failed loading certificates: %v", sslErr)
 			return
 		}
-		opts = append(opts, grpc.Creds(creds))
+		// rotate certificates on file change or SIGHUP without dropping streams
+		go reloader.Watch(ctx)
+		prometheus.MustRegister(reloader.Collector())
+		opts = append(opts, grpc.Creds(reloader.ServerCredentials()))
 	}
 
 	grpc_prometheus.EnableHandlingTimeHistogram()
@@ -149,22 +205,102 @@ func main() {
 		grpc_recovery.WithRecoveryHandler(recoveryFunc),
 	}
 
+	traceUnary, traceStream := tracing.ServerInterceptors()
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		traceStream,
+		grpc_prometheus.StreamServerInterceptor,
+		grpc_recovery.StreamServerInterceptor(recoveryOpts...),
+	}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		traceUnary,
+		grpc_prometheus.UnaryServerInterceptor,
+		grpc_recovery.UnaryServerInterceptor(recoveryOpts...),
+	}
+	if len(tlsCfg.AllowedClients) > 0 {
+		allowed := tlsutil.AllowList(tlsCfg.AllowedClients)
+		streamInterceptors = append(streamInterceptors, allowed.StreamServerInterceptor())
+		unaryInterceptors = append(unaryInterceptors, allowed.UnaryServerInterceptor())
+	}
+	if authCfg.Enabled() {
+		validator, err := auth.NewValidator(authCfg)
+		if err != nil {
+			log.Fatalf("Failed to set up authentication: %v", err)
+		}
+		streamInterceptors = append(streamInterceptors, validator.StreamServerInterceptor())
+		unaryInterceptors = append(unaryInterceptors, validator.UnaryServerInterceptor())
+	} else {
+		fmt.Println("Authentication is disabled")
+	}
+	if rlCfg.Enabled() {
+		// after authentication, so that limits apply per authenticated caller
+		limiter := ratelimit.New(rlCfg)
+		streamInterceptors = append(streamInterceptors, limiter.StreamServerInterceptor())
+		unaryInterceptors = append(unaryInterceptors, limiter.UnaryServerInterceptor())
+	}
+	if faultCfg.Enabled() {
+		// last, so that faults only hit calls that would have been handled,
+		// and injected panics are turned into errors by the recovery interceptor
+		fmt.Println("Fault injection is enabled")
+		injector := fault.New(faultCfg)
+		streamInterceptors = append(streamInterceptors, injector.StreamServerInterceptor())
+		unaryInterceptors = append(unaryInterceptors, injector.UnaryServerInterceptor())
+	}
 	opts = append(opts,
-		grpc.StatsHandler(&ocgrpc.ServerHandler{}),
-		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
-			grpc_prometheus.StreamServerInterceptor,
-			grpc_recovery.StreamServerInterceptor(recoveryOpts...),
-		)),
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-			grpc_prometheus.UnaryServerInterceptor,
-			grpc_recovery.UnaryServerInterceptor(recoveryOpts...),
-		)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
 	)
 
 	s := grpc.NewServer(opts...)
-	greetpb.RegisterGreetServiceServer(s, &server{})
+	greetpb.RegisterGreetServiceServer(s, &server{delay: time.Second})
+	if *enableReflection {
+		reflection.Register(s)
+	}
+	grpc_prometheus.Register(s)
+
+	go func() {
+		if err := http.ListenAndServe(*metricsAddr, promhttp.Handler()); err != nil {
+			log.Printf("Failed to serve metrics: %v", err)
+		}
+	}()
+	// with browser access, net/http owns the listener and hands native gRPC
+	// requests to s, telling them from gRPC-Web and Connect by content type
+	var httpServer *http.Server
+	if webCfg.Enabled {
+		var tlsConfig *cryptotls.Config
+		if reloader != nil {
+			tlsConfig = reloader.TLSConfig()
+		}
+		httpServer = webrpc.NewServer(webrpc.NewHandler(s, webCfg), tlsConfig)
+		go func() {
+			if err := webrpc.Serve(httpServer, lis); err != nil {
+				log.Fatalf("failed to serve: %v", err)
+			}
+		}()
+	} else {
+		go func() {
+			if err := s.Serve(lis); err != nil {
+				log.Fatalf("failed to serve: %v", err)
+			}
+		}()
+	}
 
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	// Wait for Control C to exit
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt)
+	<-ch
+
+	fmt.Println("Stopping the server")
+	if httpServer != nil {
+		// waits for the calls in progress, then s has nothing left to stop
+		if err := httpServer.Shutdown(context.Background()); err != nil {
+			log.Printf("Error while stopping the server: %v", err)
+		}
+		s.Stop()
+	} else {
+		s.GracefulStop()
+	}
+	// flush the spans that are still buffered
+	if err := tp.Shutdown(context.Background()); err != nil {
+		log.Printf("Error while shutting down tracing: %v", err)
 	}
 }
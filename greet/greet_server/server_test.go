@@ -0,0 +1,212 @@
+package main
+
+import (
+	"common/grpctest"
+	"context"
+	"fmt"
+	"greet/greetpb"
+	"io"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testDelay = 20 * time.Millisecond
+
+func newClient(t *testing.T) greetpb.GreetServiceClient {
+	cc := grpctest.NewServer(t, func(s *grpc.Server) {
+		greetpb.RegisterGreetServiceServer(s, &server{delay: testDelay})
+	})
+	return greetpb.NewGreetServiceClient(cc)
+}
+
+func greeting(firstName string) *greetpb.Greeting {
+	return &greetpb.Greeting{FirstName: firstName, LastName: "Maarek"}
+}
+
+func TestGreet(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		firstName string
+		want      string
+	}{
+		{"Stephane", "Hello Stephane"},
+		{"", "Hello "},
+	}
+	for _, tt := range tests {
+		res, err := c.Greet(context.Background(), &greetpb.GreetRequest{Greeting: greeting(tt.firstName)})
+		if err != nil {
+			t.Fatalf("Greet(%q): %v", tt.firstName, err)
+		}
+		if res.GetResult() != tt.want {
+			t.Errorf("Greet(%q) = %q, want %q", tt.firstName, res.GetResult(), tt.want)
+		}
+	}
+}
+
+func TestGreetManyTimes(t *testing.T) {
+	c := newClient(t)
+	stream, err := c.GreetManyTimes(context.Background(), &greetpb.GreetManyTimesRequest{Greeting: greeting("Lucy")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			if i != 10 {
+				t.Errorf("got %d messages, want 10", i)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if want := fmt.Sprintf("Hello Lucy number %d", i); res.GetResult() != want {
+			t.Errorf("message %d = %q, want %q", i, res.GetResult(), want)
+		}
+	}
+}
+
+func TestGreetManyTimesCanceled(t *testing.T) {
+	c := newClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.GreetManyTimes(ctx, &greetpb.GreetManyTimesRequest{Greeting: greeting("Lucy")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	cancel()
+	for {
+		_, err := stream.Recv()
+		if err == nil {
+			continue
+		}
+		if status.Code(err) != codes.Canceled {
+			t.Errorf("Recv after cancel: %v, want Canceled", err)
+		}
+		return
+	}
+}
+
+func TestLongGreet(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		name  string
+		names []string
+		want  string
+	}{
+		{"several", []string{"Stephane", "John", "Lucy"}, "Hello Stephane! Hello John! Hello Lucy! "},
+		{"one", []string{"Mark"}, "Hello Mark! "},
+		{"empty stream", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := c.LongGreet(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range tt.names {
+				if err := stream.Send(&greetpb.LongGreetRequest{Greeting: greeting(name)}); err != nil {
+					t.Fatalf("Send: %v", err)
+				}
+			}
+			res, err := stream.CloseAndRecv()
+			if err != nil {
+				t.Fatalf("CloseAndRecv: %v", err)
+			}
+			if res.GetResult() != tt.want {
+				t.Errorf("got %q, want %q", res.GetResult(), tt.want)
+			}
+		})
+	}
+}
+
+func TestGreetEveryone(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		name  string
+		names []string
+	}{
+		{"several", []string{"Stephane", "John", "Lucy", "Mark", "Piper"}},
+		{"closed at once", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := c.GreetEveryone(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			// every greeting is answered before the next one is sent
+			for _, name := range tt.names {
+				if err := stream.Send(&greetpb.GreetEveryoneRequest{Greeting: greeting(name)}); err != nil {
+					t.Fatalf("Send: %v", err)
+				}
+				res, err := stream.Recv()
+				if err != nil {
+					t.Fatalf("Recv: %v", err)
+				}
+				if want := "Hello " + name + "! "; res.GetResult() != want {
+					t.Errorf("got %q, want %q", res.GetResult(), want)
+				}
+			}
+			if err := stream.CloseSend(); err != nil {
+				t.Fatalf("CloseSend: %v", err)
+			}
+			if _, err := stream.Recv(); err != io.EOF {
+				t.Errorf("Recv after CloseSend: %v, want EOF", err)
+			}
+		})
+	}
+}
+
+func TestGreetEveryoneCanceled(t *testing.T) {
+	c := newClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.GreetEveryone(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&greetpb.GreetEveryoneRequest{Greeting: greeting("John")}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	cancel()
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if status.Code(err) != codes.Canceled {
+				t.Errorf("Recv after cancel: %v, want Canceled", err)
+			}
+			return
+		}
+	}
+}
+
+func TestGreetWithDeadline(t *testing.T) {
+	c := newClient(t)
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		code    codes.Code
+	}{
+		// the server takes three delays to answer
+		{"in time", 50 * testDelay, codes.OK},
+		{"too late", testDelay, codes.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			res, err := c.GreetWithDeadline(ctx, &greetpb.GreetWithDeadlineRequest{Greeting: greeting("Piper")})
+			if status.Code(err) != tt.code {
+				t.Fatalf("got %v, want %v", err, tt.code)
+			}
+			if err == nil && res.GetResult() != "Hello Piper" {
+				t.Errorf("got %q, want %q", res.GetResult(), "Hello Piper")
+			}
+		})
+	}
+}
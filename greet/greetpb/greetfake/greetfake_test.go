@@ -0,0 +1,159 @@
+package greetfake_test
+
+import (
+	"context"
+	"greet/greetpb"
+	"greet/greetpb/greetfake"
+	"io"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func greeting(firstName string) *greetpb.Greeting {
+	return &greetpb.Greeting{FirstName: firstName}
+}
+
+func TestClientScript(t *testing.T) {
+	c := &greetfake.GreetServiceClient{}
+	if _, err := c.Greet(context.Background(), &greetpb.GreetRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("unscripted Greet: got %v, want Unimplemented", err)
+	}
+
+	c.OnGreet(&greetpb.GreetResponse{Result: "Hello Lucy"}, nil)
+	c.OnGreet(nil, status.Error(codes.Unavailable, "down"))
+	tests := []struct {
+		want string
+		code codes.Code
+	}{
+		{"Hello Lucy", codes.OK},
+		{"", codes.Unavailable},
+		// the last result is repeated
+		{"", codes.Unavailable},
+	}
+	for i, tt := range tests {
+		res, err := c.Greet(context.Background(), &greetpb.GreetRequest{Greeting: greeting("Lucy")})
+		if status.Code(err) != tt.code || res.GetResult() != tt.want {
+			t.Errorf("call %d = %q, %v, want %q, %v", i, res.GetResult(), err, tt.want, tt.code)
+		}
+	}
+	if n := len(c.CallsTo("/greet.GreetService/Greet")); n != 4 {
+		t.Errorf("recorded %d calls, want 4", n)
+	}
+}
+
+func TestClientRecordsStreams(t *testing.T) {
+	c := &greetfake.GreetServiceClient{}
+	c.OnLongGreet(&greetpb.LongGreetResponse{Result: "Hello John! Hello Mark! "}, nil)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "42")
+	stream, err := c.LongGreet(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"John", "Mark"} {
+		if err := stream.Send(&greetpb.LongGreetRequest{Greeting: greeting(name)}); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("CloseAndRecv: %v", err)
+	}
+	if want := "Hello John! Hello Mark! "; res.GetResult() != want {
+		t.Errorf("got %q, want %q", res.GetResult(), want)
+	}
+
+	calls := c.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("recorded %d calls, want 1", len(calls))
+	}
+	if got := calls[0].Metadata.Get("x-request-id"); len(got) != 1 || got[0] != "42" {
+		t.Errorf("x-request-id = %v, want [42]", got)
+	}
+	if len(calls[0].Requests) != 2 || !proto.Equal(calls[0].Requests[1], &greetpb.LongGreetRequest{Greeting: greeting("Mark")}) {
+		t.Errorf("recorded requests %v", calls[0].Requests)
+	}
+}
+
+func TestClientServerStream(t *testing.T) {
+	c := &greetfake.GreetServiceClient{}
+	c.OnGreetManyTimes([]*greetpb.GreetManytimesResponse{{Result: "one"}, {Result: "two"}}, status.Error(codes.Aborted, "cut"))
+	stream, err := c.GreetManyTimes(context.Background(), &greetpb.GreetManyTimesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			if status.Code(err) != codes.Aborted {
+				t.Errorf("Recv: %v, want Aborted", err)
+			}
+			break
+		}
+		got = append(got, res.GetResult())
+	}
+	if len(got) != 2 || got[0] != "one" || got[1] != "two" {
+		t.Errorf("got %v, want [one two]", got)
+	}
+}
+
+func TestServer(t *testing.T) {
+	s := &greetfake.GreetServiceServer{}
+	s.OnGreetEveryone([]*greetpb.GreetEveryoneResponse{{Result: "Hello John! "}, {Result: "bye"}}, nil)
+	c := s.Client(t)
+
+	stream, err := c.GreetEveryone(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// one response per message, the rest once the client is done
+	if err := stream.Send(&greetpb.GreetEveryoneRequest{Greeting: greeting("John")}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if res, err := stream.Recv(); err != nil || res.GetResult() != "Hello John! " {
+		t.Fatalf("Recv = %v, %v", res, err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend: %v", err)
+	}
+	if res, err := stream.Recv(); err != nil || res.GetResult() != "bye" {
+		t.Fatalf("Recv = %v, %v", res, err)
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("Recv: %v, want EOF", err)
+	}
+	calls := s.CallsTo("/greet.GreetService/GreetEveryone")
+	if len(calls) != 1 || len(calls[0].Requests) != 1 {
+		t.Errorf("recorded %v", calls)
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	s := &greetfake.GreetServiceServer{}
+	s.OnGreet(&greetpb.GreetResponse{Result: "Hello"}, nil)
+	c := s.Client(t)
+
+	s.SetError("", status.Error(codes.Unavailable, "flaky"), 1)
+	if _, err := c.Greet(context.Background(), &greetpb.GreetRequest{}); status.Code(err) != codes.Unavailable {
+		t.Errorf("with error injected: got %v, want Unavailable", err)
+	}
+	s.SetError("", nil, 0)
+
+	s.SetLatency("/greet.GreetService/Greet", time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.Greet(ctx, &greetpb.GreetRequest{}); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("with latency injected: got %v, want DeadlineExceeded", err)
+	}
+	s.SetLatency("/greet.GreetService/Greet", 0)
+
+	res, err := c.Greet(context.Background(), &greetpb.GreetRequest{})
+	if err != nil || res.GetResult() != "Hello" {
+		t.Errorf("without faults: got %v, %v", res, err)
+	}
+}
@@ -1 +1 @@
-rm -f *.pem *.key *.crt *.csr
\ No newline at end of file
+rm -f *.pem *.key *.crt *.csr *.srl
@@ -0,0 +1,14 @@
+# client-ca.key: private key of the CA that signs client certificates (keep it secret)
+# client-ca.pem: CA certificate, given to the server with -tls-client-ca
+# client-key.pem: client private key
+# client.pem: client certificate signed by the CA, identified by the CN and SANs in client.conf
+# the CA is only created once, so running the script again issues another client certificate
+
+if [ ! -f client-ca.pem ]; then
+    openssl genrsa -out client-ca.key 2048
+    openssl req -new -x509 -sha256 -days 3650 -key client-ca.key -out client-ca.pem -subj "/O=Ming/CN=Greet Client CA"
+fi
+
+openssl genrsa -out client-key.pem 2048
+openssl req -new -sha256 -config client.conf -key client-key.pem -out client.csr
+openssl x509 -req -sha256 -days 365 -in client.csr -CA client-ca.pem -CAkey client-ca.key -CAcreateserial -extfile client.conf -extensions client_ext -out client.pem